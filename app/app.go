@@ -7,9 +7,14 @@ package app
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +24,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -35,6 +41,7 @@ import (
 	"github.com/obolnetwork/charon/app/peerinfo"
 	"github.com/obolnetwork/charon/app/privkeylock"
 	"github.com/obolnetwork/charon/app/promauto"
+	"github.com/obolnetwork/charon/app/recorder"
 	"github.com/obolnetwork/charon/app/retry"
 	"github.com/obolnetwork/charon/app/stacksnipe"
 	"github.com/obolnetwork/charon/app/tracer"
@@ -51,12 +58,14 @@ import (
 	"github.com/obolnetwork/charon/core/consensus/qbft"
 	"github.com/obolnetwork/charon/core/dutydb"
 	"github.com/obolnetwork/charon/core/fetcher"
+	"github.com/obolnetwork/charon/core/gossip"
 	"github.com/obolnetwork/charon/core/infosync"
 	"github.com/obolnetwork/charon/core/parsigdb"
 	"github.com/obolnetwork/charon/core/parsigex"
 	"github.com/obolnetwork/charon/core/priority"
 	"github.com/obolnetwork/charon/core/scheduler"
 	"github.com/obolnetwork/charon/core/sigagg"
+	"github.com/obolnetwork/charon/core/statesync"
 	"github.com/obolnetwork/charon/core/tracker"
 	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
@@ -74,11 +83,14 @@ type Config struct {
 	LockFile                string
 	ManifestFile            string
 	NoVerify                bool
+	ExecutionClientRPC      string
 	PrivKeyFile             string
 	PrivKeyLocking          bool
 	MonitoringAddr          string
 	DebugAddr               string
 	ValidatorAPIAddr        string
+	ValidatorAPITLS         validatorapi.TLSConfig
+	ValidatorAPITokensFile  string
 	BeaconNodeAddrs         []string
 	BeaconNodeTimeout       time.Duration
 	BeaconNodeSubmitTimeout time.Duration
@@ -89,11 +101,22 @@ type Config struct {
 	SimnetValidatorKeysDir  string
 	SimnetSlotDuration      time.Duration
 	SyntheticBlockProposals bool
+	SyntheticSyncCommittee  bool
 	BuilderAPI              bool
+	BuilderRelays           []string
+	BuilderBoostFactor      *uint64
+	BuilderMinBidGwei       uint64
+	BuilderRecastEpochs     uint64
+	BuilderRegistrationFile string
+	DoppelgangerEpochs      uint64
 	SimnetBMockFuzz         bool
 	TestnetConfig           eth2util.Network
 	ProcDirectory           string
 	ConsensusProtocol       string
+	SlotOffsets             []string
+	SlotOffsetsAdaptive     bool
+	SignatureDBSnapshotDir  string
+	RecordFile              string
 
 	TestConfig TestConfig
 }
@@ -152,6 +175,11 @@ func Run(ctx context.Context, conf Config) (err error) {
 		life.RegisterStop(lifecycle.StopPrivkeyLock, lifecycle.HookFuncMin(lockSvc.Close))
 	}
 
+	rec, err := wireRecorder(ctx, life, conf)
+	if err != nil {
+		return err
+	}
+
 	stackSniper := stacksnipe.New(conf.ProcDirectory, stackComponents)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartStackSnipe, lifecycle.HookFuncCtx(stackSniper.Run))
 
@@ -205,6 +233,10 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	if rec != nil {
+		p2p.SetRecorder(tcpNode, rec)
+	}
+
 	nodeIdx, err := manifest.ClusterNodeIdx(cluster, tcpNode.ID())
 	if err != nil {
 		return errors.Wrap(err, "private key not matching cluster manifest file")
@@ -240,7 +272,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	initStartupMetrics(p2p.PeerName(tcpNode.ID()), int(cluster.GetThreshold()), len(cluster.GetOperators()), len(cluster.GetValidators()), network)
 
-	eth2Cl, subEth2Cl, err := newETH2Client(ctx, conf, life, cluster, cluster.GetForkVersion(), conf.BeaconNodeTimeout, conf.BeaconNodeSubmitTimeout)
+	eth2Cl, subEth2Cl, err := newETH2Client(ctx, conf, life, cluster, cluster.GetForkVersion(), conf.BeaconNodeTimeout, conf.BeaconNodeSubmitTimeout, rec)
 	if err != nil {
 		return err
 	}
@@ -287,7 +319,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 		promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()))
 
 	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl, subEth2Cl,
-		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc)
+		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc, rec, clockwork.NewRealClock())
 	if err != nil {
 		return err
 	}
@@ -360,7 +392,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	eth2Cl, submissionEth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
 	consensusDebugger consensus.Debugger, seenPubkeys func(core.PubKey),
-	vapiCalls func(),
+	vapiCalls func(), rec *recorder.Recorder, clock clockwork.Clock,
 ) error {
 	// Convert and prep public keys and public shares
 	var (
@@ -419,10 +451,15 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	}
 
 	deadlinerFunc := func(label string) core.Deadliner {
-		return core.NewDeadliner(ctx, label, deadlineFunc)
+		return core.NewDeadlinerWithClock(ctx, label, deadlineFunc, clock)
 	}
 
-	sched, err := scheduler.New(corePubkeys, eth2Cl, conf.BuilderAPI)
+	schedOpts, err := schedulerOptions(conf, cluster.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	sched, err := scheduler.New(corePubkeys, eth2Cl, conf.BuilderAPI, append(schedOpts, scheduler.WithClock(clock))...)
 	if err != nil {
 		return err
 	}
@@ -479,7 +516,17 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, conf.BuilderAPI)
+	// Duties of this node are disabled until doppelganger detection completes.
+	nodeGaterFunc := gaterFunc
+	var doppelganger *scheduler.Doppelganger
+	if conf.DoppelgangerEpochs > 0 {
+		doppelganger = scheduler.NewDoppelganger(eth2Cl, nodeIdx.ShareIdx, conf.DoppelgangerEpochs)
+		nodeGaterFunc = func(duty core.Duty) bool {
+			return gaterFunc(duty) && doppelganger.DutyGater(duty)
+		}
+	}
+
+	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, conf.BuilderAPI, fetcherOptions(conf)...)
 	if err != nil {
 		return err
 	}
@@ -491,7 +538,12 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls, conf.BuilderAPI); err != nil {
+	vapiTokens, err := loadVAPITokens(conf, corePubkeys)
+	if err != nil {
+		return err
+	}
+
+	if err := wireVAPIRouter(ctx, life, conf, eth2Cl, vapi, vapiCalls, vapiTokens, rec); err != nil {
 		return err
 	}
 
@@ -509,6 +561,13 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		parSigEx = parsigex.NewParSigEx(tcpNode, sender.SendAsync, nodeIdx.PeerIdx, peerIDs, verifyFunc, gaterFunc)
 	}
 
+	if doppelganger != nil {
+		sched.SubscribeSlots(doppelganger.SlotTicked)
+		sched.RegisterDutyGater(doppelganger.DutyGater)
+		// Partial signatures received from peers are recorded as cluster activity even while duties are disabled.
+		parSigEx.Subscribe(doppelganger.ParSigReceived)
+	}
+
 	sigAgg, err := sigagg.New(int(cluster.GetThreshold()), sigagg.NewVerifier(eth2Cl))
 	if err != nil {
 		return err
@@ -521,17 +580,32 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		aggSigDB = aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
 	}
 
+	if conf.SignatureDBSnapshotDir != "" {
+		aggSnapshotDB, ok := aggSigDB.(snapshotDB)
+		if !ok {
+			return errors.New("bug: aggsigdb doesn't support snapshots")
+		}
+
+		err := wireSnapshots(ctx, life, conf.SignatureDBSnapshotDir, map[string]snapshotDB{
+			"parsigdb": parSigDB,
+			"aggsigdb": aggSnapshotDB,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	broadcaster, err := bcast.New(ctx, submissionEth2Cl)
 	if err != nil {
 		return err
 	}
 
-	retryer := retry.New(deadlineFunc)
+	retryer := retry.NewWithClock(deadlineFunc, clock)
 
 	// Consensus
 	consensusController, err := consensus.NewConsensusController(
 		ctx, tcpNode, sender, peers, p2pKey,
-		deadlineFunc, gaterFunc, consensusDebugger)
+		deadlineFunc, nodeGaterFunc, consensusDebugger, clock)
 	if err != nil {
 		return err
 	}
@@ -539,27 +613,56 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	defaultConsensus := consensusController.DefaultConsensus()
 	startConsensusCtrl := lifecycle.HookFuncCtx(consensusController.Start)
 
+	// QBFT is the only consensus protocol implementation supporting value validation.
+	if cons, ok := defaultConsensus.(*qbft.Consensus); ok && featureset.Enabled(featureset.ValidateConsensusValues) {
+		// Reject invalid values proposed by byzantine leaders.
+		for _, dutyType := range []core.DutyType{core.DutyAttester, core.DutyAggregator, core.DutySyncContribution, core.DutyProposer} {
+			cons.RegisterValidateValue(dutyType, fetch.ValidateValue)
+		}
+	}
+
 	coreConsensus := consensusController.CurrentConsensus() // initially points to DefaultConsensus()
 
 	// Priority protocol always uses QBFTv2.
-	err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
+	isync, err := wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
 		sender.SendReceive, defaultConsensus, sched, p2pKey, deadlineFunc,
-		consensusController, cluster.GetConsensusProtocol())
+		consensusController, cluster.GetConsensusProtocol(), cluster.GetLatestMutationHash())
 	if err != nil {
 		return err
 	}
 
-	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
-		conf.BuilderAPI, conf.TestConfig.BroadcastCallback); err != nil {
+	if isync != nil && featureset.Enabled(featureset.ValidateConsensusValues) {
+		fetch.RegisterManifestAgreed(isync.ManifestAgreed)
+	}
+
+	if featureset.Enabled(featureset.Gossip) {
+		wireGossip(tcpNode, peerIDs, p2pKey, isync, parSigEx, defaultConsensus)
+	}
+
+	if err = wireRecaster(ctx, conf, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators()); err != nil {
 		return errors.Wrap(err, "wire recaster")
 	}
 
-	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
+	var (
+		trackOpts []tracker.Option
+		inclOpts  []tracker.InclusionOption
+	)
+	if conf.SlotOffsetsAdaptive {
+		// Adapt slot offsets to late consensus and delayed inclusion.
+		trackOpts = append(trackOpts, tracker.WithLateDutyFunc(sched.ReportDuty))
+		inclOpts = append(inclOpts, tracker.WithInclusionDelayFunc(func(duty core.Duty, delay uint64) {
+			if delay > 1 {
+				sched.ReportDuty(duty, true)
+			}
+		}))
+	}
+
+	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl, tokenVCs(vapiTokens), clock, trackOpts...)
 	if err != nil {
 		return err
 	}
 
-	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked)
+	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked, inclOpts...)
 	if err != nil {
 		return err
 	}
@@ -570,8 +673,19 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		core.WithTracking(track, inclusion),
 		core.WithAsyncRetry(retryer),
 	}
+	if doppelganger != nil {
+		opts = append(opts, core.WithDutyGater(doppelganger.DutyGater))
+	}
 	core.Wire(sched, fetch, coreConsensus, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
 
+	if featureset.Enabled(featureset.StateSync) {
+		err := wireStateSync(ctx, life, tcpNode, peerIDs, int(cluster.GetThreshold()), corePubkeys, sender.SendReceive,
+			eth2Cl, aggSigDB, dutyDB, coreConsensus, deadlinerFunc("statesync"), clock)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = wireValidatorMock(ctx, conf, eth2Cl, pubshares, sched)
 	if err != nil {
 		return err
@@ -593,16 +707,41 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	return nil
 }
 
+// fetcherOptions returns the fetcher options for the configured builder bid policy.
+func fetcherOptions(conf Config) []fetcher.Option {
+	var opts []fetcher.Option
+	if conf.BuilderBoostFactor != nil {
+		opts = append(opts, fetcher.WithBuilderBoostFactor(*conf.BuilderBoostFactor))
+	}
+	if conf.BuilderMinBidGwei > 0 {
+		minBid := new(big.Int).Mul(new(big.Int).SetUint64(conf.BuilderMinBidGwei), big.NewInt(1e9))
+		opts = append(opts, fetcher.WithMinBuilderBid(minBid))
+	}
+
+	return opts
+}
+
+// builderConfig returns the builder proposal configuration that must be identical on all nodes in the cluster.
+func builderConfig(conf Config) string {
+	boostFactor := "default"
+	if conf.BuilderBoostFactor != nil {
+		boostFactor = strconv.FormatUint(*conf.BuilderBoostFactor, 10)
+	}
+
+	return fmt.Sprintf("builder_api=%v,boost_factor=%s,min_bid_gwei=%d", conf.BuilderAPI, boostFactor, conf.BuilderMinBidGwei)
+}
+
 // wirePrioritise wires the priority protocol which determines cluster wide priorities for the next epoch.
+// It returns the infosync component or nil if the priority protocol isn't supported.
 func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, tcpNode host.Host,
 	peers []peer.ID, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
 	sched core.Scheduler, p2pKey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	consensusController core.ConsensusController, clusterPreferredProtocol string,
-) error {
+	consensusController core.ConsensusController, clusterPreferredProtocol string, manifestHash []byte,
+) (*infosync.Component, error) {
 	cons, ok := coreCons.(*qbft.Consensus)
 	if !ok {
 		// Priority protocol not supported for leader cast.
-		return nil
+		return nil, nil //nolint:nilnil // No infosync without priority protocol.
 	}
 
 	// exchangeTimeout of 6 seconds (half a slot) is a good thumb suck.
@@ -612,7 +751,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	prio, err := priority.NewComponent(ctx, tcpNode, peers, threshold,
 		sendFunc, p2p.RegisterHandler, cons, exchangeTimeout, p2pKey, deadlineFunc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// The initial protocols order as defined by implementation is altered by:
@@ -628,12 +767,25 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 		allProtocols = protocols.PrioritizeProtocolsByName(conf.ConsensusProtocol, allProtocols)
 	}
 
+	isyncOpts := []infosync.Option{infosync.WithBuilderConfig(builderConfig(conf))}
+	if featureset.Enabled(featureset.ValidateConsensusValues) {
+		isyncOpts = append(isyncOpts, infosync.WithManifestHash(fmt.Sprintf("%#x", manifestHash)))
+	}
+	if featureset.Enabled(featureset.ReliableLeaders) {
+		isyncOpts = append(isyncOpts, infosync.WithUnreliableLeaders(cons.UnreliableLeaders))
+	}
+
 	isync := infosync.New(prio,
 		version.Supported(),
 		allProtocols,
 		ProposalTypes(conf.BuilderAPI, conf.SyntheticBlockProposals),
+		isyncOpts...,
 	)
 
+	if featureset.Enabled(featureset.ReliableLeaders) {
+		cons.EnableReliableLeaders(isync.UnreliableLeaders)
+	}
+
 	// Trigger info syncs in last slot of the epoch (for the next epoch).
 	sched.SubscribeSlots(func(ctx context.Context, slot core.Slot) error {
 		if !slot.LastInEpoch() {
@@ -669,16 +821,98 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(prio.Start))
 
+	return isync, nil
+}
+
+// wireGossip wires gossip dissemination of partial signatures and consensus messages,
+// used for slots for which the cluster wide infosync protocols include the respective gossip topics.
+// Gossip messages are sent synchronously, so peers assigned to unreachable relays are sent messages directly.
+func wireGossip(tcpNode host.Host, peers []peer.ID, p2pKey *k1.PrivateKey, isync *infosync.Component,
+	parSigEx core.ParSigEx, coreCons core.Consensus,
+) {
+	if isync == nil {
+		return
+	}
+
+	enabledFunc := func(topic protocol.ID) func(uint64) bool {
+		return func(slot uint64) bool {
+			return slices.Contains(isync.Protocols(slot), topic)
+		}
+	}
+
+	g := gossip.New(tcpNode, peers, p2p.Send, p2pKey)
+
+	if psx, ok := parSigEx.(*parsigex.ParSigEx); ok {
+		psx.EnableGossip(g, enabledFunc(parsigex.GossipProtocolID))
+	}
+
+	if cons, ok := coreCons.(*qbft.Consensus); ok {
+		cons.EnableGossip(g, enabledFunc(protocols.QBFTv2GossipProtocolID))
+	}
+}
+
+// wireStateSync wires the state sync component which provides recent state to peers
+// and syncs recent state from peers on startup.
+func wireStateSync(ctx context.Context, life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, threshold int,
+	pubkeys []core.PubKey, sendFunc p2p.SendReceiveFunc, eth2Cl eth2wrap.Client, aggSigDB core.AggSigDB,
+	dutyDB core.DutyDB, coreCons core.Consensus, deadliner core.Deadliner, clock clockwork.Clock,
+) error {
+	syncAggSigDB, ok := aggSigDB.(statesync.AggSigDB)
+	if !ok {
+		return errors.New("bug: aggsigdb doesn't support state sync")
+	}
+
+	genesisTime, err := eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return err
+	}
+
+	slotDuration, ok := eth2Resp.Data["SECONDS_PER_SLOT"].(time.Duration)
+	if !ok {
+		return errors.New("fetch slot duration")
+	}
+
+	stateSync := statesync.New(tcpNode, peers, threshold, pubkeys, sendFunc, syncAggSigDB, dutyDB,
+		sigagg.NewVerifier(eth2Cl), deadliner)
+	coreCons.Subscribe(stateSync.Decided)
+
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartStateSync, lifecycle.HookFuncCtx(stateSync.Run))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartStateSync, lifecycle.HookFuncCtx(func(ctx context.Context) {
+		// Only the state of the current and later slots is relevant for resuming duties.
+		stateSync.Sync(ctx, uint64(clock.Since(genesisTime)/slotDuration))
+	}))
+
 	return nil
 }
 
 // wireRecaster wires the rebroadcaster component to scheduler, sigAgg and broadcaster.
 // This is not done in core.Wire since recaster isn't really part of the official core workflow (yet).
-func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
-	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool,
-	callback func(context.Context, core.Duty, core.SignedDataSet) error,
+func wireRecaster(ctx context.Context, conf Config, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
+	broadcaster core.Broadcaster, validators []*manifestpb.Validator,
 ) error {
-	recaster, err := bcast.NewRecaster(func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+	var opts []bcast.RecasterOption
+	if conf.BuilderRecastEpochs > 0 {
+		opts = append(opts, bcast.WithRecastEpochs(conf.BuilderRecastEpochs))
+	}
+	if conf.BuilderRegistrationFile != "" {
+		feeRecipients := make(map[core.PubKey]string)
+		for _, val := range validators {
+			pubkey, err := core.PubKeyFromBytes(val.GetPublicKey())
+			if err != nil {
+				return errors.Wrap(err, "core pubkey from bytes")
+			}
+			feeRecipients[pubkey] = val.GetFeeRecipientAddress()
+		}
+
+		opts = append(opts, bcast.WithRegistrationFile(conf.BuilderRegistrationFile), bcast.WithFeeRecipients(feeRecipients))
+	}
+
+	recaster, err := bcast.NewRecaster(ctx, func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
 		valList, err := eth2Cl.ActiveValidators(ctx)
 		if err != nil {
 			return nil, err
@@ -691,7 +925,7 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 		}
 
 		return ret, nil
-	})
+	}, opts...)
 	if err != nil {
 		return errors.Wrap(err, "recaster init")
 	}
@@ -700,14 +934,25 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 	sigAgg.Subscribe(recaster.Store)
 	recaster.Subscribe(broadcaster.Broadcast)
 
-	if callback != nil {
-		recaster.Subscribe(callback)
+	if conf.TestConfig.BroadcastCallback != nil {
+		recaster.Subscribe(conf.TestConfig.BroadcastCallback)
 	}
 
-	if !builderAPI {
+	if !conf.BuilderAPI {
 		return nil
 	}
 
+	if len(conf.BuilderRelays) > 0 {
+		relays, err := bcast.NewRelayBroadcaster(conf.BuilderRelays, conf.BeaconNodeSubmitTimeout)
+		if err != nil {
+			return err
+		}
+
+		// Submit new registrations to relays immediately, and rebroadcast them with the rest.
+		sigAgg.Subscribe(relays.Broadcast)
+		recaster.Subscribe(relays.Broadcast)
+	}
+
 	for _, val := range validators {
 		// Check if the current cluster manifest supports pre-generate validator registrations.
 		if len(val.GetBuilderRegistrationJson()) == 0 {
@@ -742,9 +987,31 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 	return nil
 }
 
+// schedulerOptions returns the scheduler options configuring slot offsets.
+func schedulerOptions(conf Config, forkVersion []byte) ([]scheduler.Option, error) {
+	var opts []scheduler.Option
+
+	// Custom testnets don't have an offset profile.
+	if network, err := eth2util.ForkVersionToNetwork(forkVersion); err == nil {
+		opts = append(opts, scheduler.WithNetworkOffsets(network))
+	}
+
+	offsets, err := scheduler.ParseOffsets(conf.SlotOffsets)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, scheduler.WithOffsets(offsets))
+
+	if conf.SlotOffsetsAdaptive {
+		opts = append(opts, scheduler.WithAdaptiveOffsets())
+	}
+
+	return opts, nil
+}
+
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	peers []p2p.Peer, eth2Cl eth2wrap.Client,
+	peers []p2p.Peer, eth2Cl eth2wrap.Client, vcs map[core.PubKey]string, clock clockwork.Clock, opts ...tracker.Option,
 ) (core.Tracker, error) {
 	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
 	if err != nil {
@@ -759,21 +1026,22 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 	// Add InclMissedLag slots and InclCheckLag delay to analyser to capture missed inclusion errors.
 	trackerDelay := tracker.InclMissedLag + tracker.InclCheckLag
 
-	analyser := core.NewDeadliner(ctx, "tracker_analyser", func(duty core.Duty) (time.Time, bool) {
+	analyser := core.NewDeadlinerWithClock(ctx, "tracker_analyser", func(duty core.Duty) (time.Time, bool) {
 		d, ok := deadlineFunc(duty)
 		return d.Add(time.Duration(trackerDelay) * slotDuration), ok
-	})
-	deleter := core.NewDeadliner(ctx, "tracker_deleter", func(duty core.Duty) (time.Time, bool) {
+	}, clock)
+	deleter := core.NewDeadlinerWithClock(ctx, "tracker_deleter", func(duty core.Duty) (time.Time, bool) {
 		d, ok := deadlineFunc(duty)
 		return d.Add(time.Duration(trackerDelay) * slotDuration).Add(time.Minute), ok // Delete duties after analyser_deadline+1min.
-	})
+	}, clock)
 
-	trackFrom, err := calculateTrackerDelay(ctx, eth2Cl, time.Now())
+	trackFrom, err := calculateTrackerDelay(ctx, eth2Cl, clock.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	track := tracker.New(analyser, deleter, peers, trackFrom)
+	opts = append(opts, tracker.WithValidatorClients(vcs))
+	track := tracker.New(analyser, deleter, peers, trackFrom, opts...)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartTracker, lifecycle.HookFunc(track.Run))
 
 	return track, nil
@@ -831,7 +1099,7 @@ func eth2PubKeys(cluster *manifestpb.Cluster) ([]eth2p0.BLSPubKey, error) {
 
 // newETH2Client returns a new eth2client for the configured timeouts; it is either a beaconmock for
 // simnet or a multi http client to a real beacon node.
-func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cluster *manifestpb.Cluster, forkVersion []byte, bnTimeout time.Duration, submissionBnTimeout time.Duration) (eth2wrap.Client, eth2wrap.Client, error) {
+func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cluster *manifestpb.Cluster, forkVersion []byte, bnTimeout time.Duration, submissionBnTimeout time.Duration, rec *recorder.Recorder) (eth2wrap.Client, eth2wrap.Client, error) {
 	pubkeys, err := eth2PubKeys(cluster)
 	if err != nil {
 		return nil, nil, err
@@ -887,9 +1155,8 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 			return nil, nil, err
 		}
 
-		if conf.SyntheticBlockProposals {
-			log.Info(ctx, "Synthetic block proposals enabled")
-			wrap = eth2wrap.WithSyntheticDuties(wrap)
+		if synthOpts := syntheticOptions(ctx, conf); len(synthOpts) > 0 {
+			wrap = eth2wrap.WithSyntheticDuties(wrap, synthOpts...)
 		}
 
 		life.RegisterStop(lifecycle.StopBeaconMock, lifecycle.HookFuncErr(bmock.Close))
@@ -901,16 +1168,23 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 		return nil, nil, errors.New("beacon node endpoints empty")
 	}
 
-	if conf.SyntheticBlockProposals {
-		log.Info(ctx, "Synthetic block proposals enabled")
+	addrs := conf.BeaconNodeAddrs
+	if rec != nil {
+		var err error
+		addrs, err = wireRecordingProxies(ctx, life, addrs, rec)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	eth2Cl, err := configureEth2Client(ctx, forkVersion, conf.BeaconNodeAddrs, bnTimeout, conf.SyntheticBlockProposals)
+	synthOpts := syntheticOptions(ctx, conf)
+
+	eth2Cl, err := configureEth2Client(ctx, forkVersion, addrs, bnTimeout, synthOpts)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new eth2 http client")
 	}
 
-	submissionEth2Cl, err := configureEth2Client(ctx, forkVersion, conf.BeaconNodeAddrs, submissionBnTimeout, conf.SyntheticBlockProposals)
+	submissionEth2Cl, err := configureEth2Client(ctx, forkVersion, addrs, submissionBnTimeout, synthOpts)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new submission eth2 http client")
 	}
@@ -918,15 +1192,30 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 	return eth2Cl, submissionEth2Cl, nil
 }
 
+// syntheticOptions returns the synthetic duty options enabled by the config.
+func syntheticOptions(ctx context.Context, conf Config) []eth2wrap.SyntheticOption {
+	var opts []eth2wrap.SyntheticOption
+	if conf.SyntheticBlockProposals {
+		log.Info(ctx, "Synthetic block proposals enabled")
+		opts = append(opts, eth2wrap.WithSyntheticProposals())
+	}
+	if conf.SyntheticSyncCommittee {
+		log.Info(ctx, "Synthetic sync committee duties enabled")
+		opts = append(opts, eth2wrap.WithSyntheticSyncCommittee())
+	}
+
+	return opts
+}
+
 // configureEth2Client configures a beacon node client with the provided settings.
-func configureEth2Client(ctx context.Context, forkVersion []byte, addrs []string, timeout time.Duration, syntheticBlockProposals bool) (eth2wrap.Client, error) {
+func configureEth2Client(ctx context.Context, forkVersion []byte, addrs []string, timeout time.Duration, synthOpts []eth2wrap.SyntheticOption) (eth2wrap.Client, error) {
 	eth2Cl, err := eth2wrap.NewMultiHTTP(timeout, [4]byte(forkVersion), addrs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "new eth2 http client")
 	}
 
-	if syntheticBlockProposals {
-		eth2Cl = eth2wrap.WithSyntheticDuties(eth2Cl)
+	if len(synthOpts) > 0 {
+		eth2Cl = eth2wrap.WithSyntheticDuties(eth2Cl, synthOpts...)
 	}
 
 	// Check BN chain/network.
@@ -987,25 +1276,104 @@ func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 	return resp
 }
 
+// loadVAPITokens returns the validator API bearer tokens if configured,
+// ensuring tokens are only scoped to validators in the cluster.
+func loadVAPITokens(conf Config, pubkeys []core.PubKey) ([]validatorapi.Token, error) {
+	if conf.ValidatorAPITokensFile == "" {
+		return nil, nil
+	}
+
+	tokens, err := validatorapi.LoadTokens(conf.ValidatorAPITokensFile)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		known[pubkey] = true
+	}
+
+	for _, token := range tokens {
+		for _, pubkey := range token.PubKeys {
+			if !known[pubkey] {
+				return nil, errors.New("validator api token pubkey not in cluster", z.Any("pubkey", pubkey))
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// tokenVCs returns the names of the validator clients authorised for each validator by scoped tokens.
+// Validators authorised for multiple validator clients have their names joined.
+func tokenVCs(tokens []validatorapi.Token) map[core.PubKey]string {
+	resp := make(map[core.PubKey]string)
+	for _, token := range tokens {
+		for _, pubkey := range token.PubKeys {
+			if prev, ok := resp[pubkey]; ok {
+				resp[pubkey] = prev + "," + token.Name
+				continue
+			}
+
+			resp[pubkey] = token.Name
+		}
+	}
+
+	return resp
+}
+
 // wireVAPIRouter constructs the validator API router and registers it with the life cycle manager.
-func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr string, eth2Cl eth2wrap.Client,
-	handler validatorapi.Handler, vapiCalls func(), builderEnabled bool,
+func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, conf Config, eth2Cl eth2wrap.Client,
+	handler validatorapi.Handler, vapiCalls func(), tokens []validatorapi.Token, rec *recorder.Recorder,
 ) error {
-	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl, builderEnabled)
+	var opts []validatorapi.RouterOption
+	if len(tokens) > 0 {
+		opts = append(opts, validatorapi.WithTokens(tokens))
+	}
+	if rec != nil {
+		opts = append(opts, validatorapi.WithRecorder(rec))
+	}
+
+	var tlsConf *tls.Config
+	if conf.ValidatorAPITLS.CertFile != "" {
+		tlsOpts := conf.ValidatorAPITLS
+		tlsOpts.ClientCertOptional = len(tokens) > 0
+
+		var err error
+		tlsConf, err = validatorapi.NewServerTLSConfig(ctx, tlsOpts)
+		if err != nil {
+			return err
+		}
+
+		if tlsOpts.ClientCAFile != "" {
+			opts = append(opts, validatorapi.WithClientCertAuth())
+		}
+	}
+
+	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl, conf.BuilderAPI, opts...)
 	if err != nil {
 		return errors.Wrap(err, "new monitoring server")
 	}
 
 	server := &http.Server{
-		Addr: vapiAddr,
+		Addr: conf.ValidatorAPIAddr,
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			vapiCalls()
 			vrouter.ServeHTTP(w, r)
 		}),
 		ReadHeaderTimeout: time.Second,
+		TLSConfig:         tlsConf,
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(server.ListenAndServe))
+	serve := server.ListenAndServe
+	if tlsConf != nil {
+		serve = func() error {
+			// Certificates are provided via the TLS config.
+			return server.ListenAndServeTLS("", "")
+		}
+	}
+
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(serve))
 	life.RegisterStop(lifecycle.StopValidatorAPI, lifecycle.HookFunc(server.Shutdown))
 
 	return nil
@@ -1112,6 +1480,14 @@ func Protocols() []protocol.ID {
 	resp = append(resp, peerinfo.Protocols()...)
 	resp = append(resp, priority.Protocols()...)
 
+	if featureset.Enabled(featureset.Gossip) {
+		resp = append(resp, parsigex.GossipProtocolID, protocols.QBFTv2GossipProtocolID)
+	}
+
+	if featureset.Enabled(featureset.StateSync) {
+		resp = append(resp, statesync.Protocols()...)
+	}
+
 	return resp
 }
 
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
@@ -18,6 +19,11 @@ func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.Cluster,
 		return manifest.NewClusterFromLockForT(nil, *conf.TestConfig.Lock)
 	}
 
+	var verifyOpts []cluster.VerifyOption
+	if conf.ExecutionClientRPC != "" {
+		verifyOpts = append(verifyOpts, cluster.WithContractSigVerifier(ctx, eth1wrap.NewClient(conf.ExecutionClientRPC)))
+	}
+
 	verifyLock := func(lock cluster.Lock) error {
 		if err := lock.VerifyHashes(); err != nil && !conf.NoVerify {
 			return errors.Wrap(err, "cluster lock hash verification failed. Run with --no-verify to bypass verification at own risk")
@@ -25,7 +31,7 @@ func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.Cluster,
 			log.Warn(ctx, "Ignoring failed cluster lock hash verification due to --no-verify flag", err)
 		}
 
-		if err := lock.VerifySignatures(); err != nil && !conf.NoVerify {
+		if err := lock.VerifySignatures(verifyOpts...); err != nil && !conf.NoVerify {
 			return errors.Wrap(err, "cluster lock signature verification failed. Run with --no-verify to bypass verification at own risk")
 		} else if err != nil && conf.NoVerify {
 			log.Warn(ctx, "Ignoring failed cluster lock signature verification due to --no-verify flag", err)
@@ -0,0 +1,252 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package eth1wrap provides a minimal execution layer JSON-RPC client used to verify
+// ERC-1271 smart contract signatures.
+package eth1wrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var (
+	// isValidSignatureSelector is the function selector of ERC-1271 isValidSignature(bytes32,bytes).
+	// It is also the magic value returned by the contract if the signature is valid.
+	isValidSignatureSelector = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+	// errExecutionReverted is returned by call if the JSON-RPC call reverted.
+	errExecutionReverted = errors.New("execution reverted")
+)
+
+// Client is a minimal execution layer JSON-RPC client.
+type Client struct {
+	address string
+	timeout time.Duration
+	httpCl  *http.Client
+
+	mu      sync.Mutex
+	chainID uint64 // Cached chain ID of the execution client, zero if not yet fetched.
+}
+
+// NewClient returns a new execution layer client for the provided JSON-RPC endpoint.
+func NewClient(address string, opts ...func(*Client)) *Client {
+	cl := &Client{
+		address: address,
+		timeout: defaultTimeout,
+		httpCl:  new(http.Client),
+	}
+
+	for _, opt := range opts {
+		opt(cl)
+	}
+
+	return cl
+}
+
+// WithTimeout returns an option that overrides the default JSON-RPC request timeout.
+func WithTimeout(timeout time.Duration) func(*Client) {
+	return func(cl *Client) {
+		cl.timeout = timeout
+	}
+}
+
+// VerifySmartContractBasedSignature returns true if the ERC-1271 contract at the provided address
+// considers sig a valid signature of hash. It returns false for externally owned accounts
+// and contracts that revert. It returns an error if the execution client isn't connected to the
+// chain with the provided chain ID.
+func (c *Client) VerifySmartContractBasedSignature(ctx context.Context, chainID uint64, contractAddress string, hash [32]byte, sig []byte) (bool, error) {
+	contractAddress, err := eth2util.ChecksumAddress(contractAddress)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.verifyChainID(ctx, chainID); err != nil {
+		return false, err
+	}
+
+	resp, err := c.call(ctx, "eth_call", map[string]string{
+		"to":   contractAddress,
+		"data": "0x" + hex.EncodeToString(PackIsValidSignature(hash, sig)),
+	}, "latest")
+	if errors.Is(err, errExecutionReverted) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var result string
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return false, errors.Wrap(err, "unmarshal eth_call result")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if err != nil {
+		return false, errors.Wrap(err, "decode eth_call result")
+	}
+
+	// Externally owned accounts return empty results.
+	if len(b) < len(isValidSignatureSelector) {
+		return false, nil
+	}
+
+	return bytes.Equal(b[:len(isValidSignatureSelector)], isValidSignatureSelector[:]), nil
+}
+
+// verifyChainID returns an error if the chain ID of the execution client doesn't match the expected chain ID.
+func (c *Client) verifyChainID(ctx context.Context, expected uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.chainID == 0 {
+		resp, err := c.call(ctx, "eth_chainId")
+		if err != nil {
+			return err
+		}
+
+		var result string
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return errors.Wrap(err, "unmarshal eth_chainId result")
+		}
+
+		chainID, err := strconv.ParseUint(strings.TrimPrefix(result, "0x"), 16, 64)
+		if err != nil {
+			return errors.Wrap(err, "parse eth_chainId result", z.Str("result", result))
+		}
+
+		c.chainID = chainID
+	}
+
+	if c.chainID != expected {
+		return errors.New("execution client chain id mismatch", z.U64("expected", expected), z.U64("actual", c.chainID))
+	}
+
+	return nil
+}
+
+// PackIsValidSignature returns the ABI encoded call data of isValidSignature(bytes32,bytes).
+func PackIsValidSignature(hash [32]byte, sig []byte) []byte {
+	resp := append([]byte(nil), isValidSignatureSelector[:]...)
+	resp = append(resp, hash[:]...)
+	resp = append(resp, abiUint(64)...) // Offset of the dynamic bytes argument.
+	resp = append(resp, abiUint(uint64(len(sig)))...)
+	resp = append(resp, sig...)
+
+	if rem := len(sig) % 32; rem != 0 {
+		resp = append(resp, make([]byte, 32-rem)...)
+	}
+
+	return resp
+}
+
+// UnpackIsValidSignature returns the hash and signature of ABI encoded isValidSignature(bytes32,bytes) call data.
+func UnpackIsValidSignature(data []byte) ([32]byte, []byte, error) {
+	const headLen = 4 + 32 + 32 + 32
+	if len(data) < headLen || !bytes.Equal(data[:4], isValidSignatureSelector[:]) {
+		return [32]byte{}, nil, errors.New("invalid isValidSignature call data")
+	}
+
+	lenWord := data[4+64 : headLen]
+	sigLen := binary.BigEndian.Uint64(lenWord[24:])
+	if !bytes.Equal(lenWord[:24], make([]byte, 24)) || sigLen > uint64(len(data)-headLen) {
+		return [32]byte{}, nil, errors.New("invalid isValidSignature signature length")
+	}
+
+	return [32]byte(data[4:36]), data[headLen : headLen+int(sigLen)], nil
+}
+
+// IsValidSignatureResult returns the ABI encoded isValidSignature return value.
+func IsValidSignatureResult(valid bool) []byte {
+	resp := make([]byte, 32)
+	if valid {
+		copy(resp, isValidSignatureSelector[:])
+	}
+
+	return resp
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// call executes a JSON-RPC request and returns the raw result.
+func (c *Client) call(ctx context.Context, method string, params ...any) (json.RawMessage, error) {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal rpc request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "create rpc request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpCl.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "execution client rpc unavailable", z.Str("method", method))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read rpc response", z.Str("method", method))
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.New("execution client rpc unavailable", z.Int("status_code", resp.StatusCode), z.Str("method", method))
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal rpc response", z.Str("method", method))
+	}
+
+	if rpcResp.Error != nil {
+		if strings.Contains(rpcResp.Error.Message, "revert") {
+			return nil, errExecutionReverted
+		}
+
+		return nil, errors.New("rpc error response", z.Int("code", rpcResp.Error.Code), z.Str("message", rpcResp.Error.Message), z.Str("method", method))
+	}
+
+	return rpcResp.Result, nil
+}
+
+// abiUint returns the ABI encoded uint256 of the provided value.
+func abiUint(v uint64) []byte {
+	resp := make([]byte, 32)
+	binary.BigEndian.PutUint64(resp[24:], v)
+
+	return resp
+}
@@ -0,0 +1,72 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth1wrap_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/eth1mock"
+)
+
+func TestPackIsValidSignature(t *testing.T) {
+	hash := testutil.RandomRoot()
+
+	for _, sigLen := range []int{0, 32, 65, 130} {
+		sig := bytes.Repeat([]byte{0xab}, sigLen)
+
+		data := eth1wrap.PackIsValidSignature(hash, sig)
+		require.Zero(t, (len(data)-4)%32)
+
+		actualHash, actualSig, err := eth1wrap.UnpackIsValidSignature(data)
+		require.NoError(t, err)
+		require.EqualValues(t, hash, actualHash)
+		require.Equal(t, sig, actualSig)
+	}
+
+	_, _, err := eth1wrap.UnpackIsValidSignature([]byte{1, 2, 3, 4})
+	require.ErrorContains(t, err, "invalid isValidSignature call data")
+}
+
+func TestVerifySmartContractBasedSignature(t *testing.T) {
+	ctx := context.Background()
+	eth1 := eth1mock.New(eth2util.Holesky.ChainID)
+	defer eth1.Close()
+
+	contract := testutil.RandomETHAddress()
+	validSig := []byte("valid")
+	eth1.AddContract(contract, func(_ [32]byte, sig []byte) bool {
+		return bytes.Equal(sig, validSig)
+	})
+
+	cl := eth1wrap.NewClient(eth1.Address())
+	hash := testutil.RandomRoot()
+
+	ok, err := cl.VerifySmartContractBasedSignature(ctx, eth2util.Holesky.ChainID, contract, hash, validSig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = cl.VerifySmartContractBasedSignature(ctx, eth2util.Holesky.ChainID, contract, hash, []byte("invalid"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Externally owned accounts never have valid contract signatures.
+	ok, err = cl.VerifySmartContractBasedSignature(ctx, eth2util.Holesky.ChainID, testutil.RandomETHAddress(), hash, validSig)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Execution clients of other chains are rejected.
+	_, err = cl.VerifySmartContractBasedSignature(ctx, eth2util.Mainnet.ChainID, contract, hash, validSig)
+	require.ErrorContains(t, err, "execution client chain id mismatch")
+
+	eth1.Close()
+
+	_, err = cl.VerifySmartContractBasedSignature(ctx, eth2util.Holesky.ChainID, contract, hash, validSig)
+	require.ErrorContains(t, err, "execution client rpc unavailable")
+}
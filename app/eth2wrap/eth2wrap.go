@@ -45,6 +45,13 @@ var (
 		Help:      "Total number of errors returned by eth2 beacon node requests",
 	}, []string{"endpoint"})
 
+	syntheticCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "synthetic_submissions_total",
+		Help:      "Total number of synthetic duty submissions swallowed by type",
+	}, []string{"type"})
+
 	// Interface assertions.
 	_ Client = (*httpAdapter)(nil)
 	_ Client = multi{}
@@ -60,15 +67,37 @@ func Instrument(clients ...Client) (Client, error) {
 	return newMulti(clients), nil
 }
 
-// WithSyntheticDuties wraps the provided client adding synthetic duties.
-func WithSyntheticDuties(cl Client) Client {
-	return &synthWrapper{
-		Client:             cl,
-		synthProposerCache: newSynthProposerCache(),
-		feeRecipients:      make(map[eth2p0.ValidatorIndex]bellatrix.ExecutionAddress),
+// SyntheticOption configures the synthetic duties added by WithSyntheticDuties.
+type SyntheticOption func(*synthWrapper)
+
+// WithSyntheticProposals enables synthetic block proposal duties.
+func WithSyntheticProposals() SyntheticOption {
+	return func(w *synthWrapper) {
+		w.synthProposerCache = newSynthProposerCache()
+	}
+}
+
+// WithSyntheticSyncCommittee enables synthetic sync committee duties, including contribution aggregation.
+func WithSyntheticSyncCommittee() SyntheticOption {
+	return func(w *synthWrapper) {
+		w.synthSyncCommCache = newSynthSyncCommCache()
 	}
 }
 
+// WithSyntheticDuties wraps the provided client adding the synthetic duties enabled by the options.
+func WithSyntheticDuties(cl Client, opts ...SyntheticOption) Client {
+	w := &synthWrapper{
+		Client:        cl,
+		feeRecipients: make(map[eth2p0.ValidatorIndex]bellatrix.ExecutionAddress),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
 // NewMultiHTTP returns a new instrumented multi eth2 http client.
 func NewMultiHTTP(timeout time.Duration, forkVersion [4]byte, addresses ...string) (Client, error) {
 	var clients []Client
@@ -25,6 +25,7 @@ type Client interface {
 	eth2exp.ProposerConfigProvider
 	BlockAttestationsProvider
 	NodePeerCountProvider
+	ValidatorLivenessProvider
 
 	CachedValidatorsProvider
 	SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error))
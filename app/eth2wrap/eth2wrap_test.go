@@ -293,6 +293,28 @@ func TestBlockAttestations(t *testing.T) {
 	require.Empty(t, resp)
 }
 
+func TestValidatorLiveness(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/validator/liveness/7", r.URL.Path)
+
+		var indices []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+		require.Equal(t, []string{"1", "2"}, indices)
+
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+	}))
+	defer srv.Close()
+
+	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, time.Hour)
+	resp, err := cl.ValidatorLiveness(context.Background(), 7, []eth2p0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []*eth2wrap.ValidatorLiveness{
+		{Index: 1, IsLive: true},
+		{Index: 2, IsLive: false},
+	}, resp)
+}
+
 // TestOneError tests the case where one of the servers returns errors.
 func TestOneError(t *testing.T) {
 	// Start an erroring server.
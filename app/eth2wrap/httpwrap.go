@@ -41,6 +41,20 @@ type NodePeerCountProvider interface {
 	NodePeerCount(ctx context.Context) (int, error)
 }
 
+// ValidatorLivenessProvider is the interface for providing validator liveness.
+// It is a standard beacon API endpoint not implemented by eth2client.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness.
+type ValidatorLivenessProvider interface {
+	// ValidatorLiveness returns the liveness of the provided validator indices in the epoch.
+	ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error)
+}
+
+// ValidatorLiveness indicates whether a validator was observed to be live in an epoch.
+type ValidatorLiveness struct {
+	Index  eth2p0.ValidatorIndex `json:"index,string"`
+	IsLive bool                  `json:"is_live"`
+}
+
 // NewHTTPAdapterForT returns a http adapter for testing non-eth2service methods as it is nil.
 func NewHTTPAdapterForT(_ *testing.T, address string, timeout time.Duration) Client {
 	return newHTTPAdapter(nil, address, timeout)
@@ -232,6 +246,33 @@ func (h *httpAdapter) NodePeerCount(ctx context.Context) (int, error) {
 	return resp.Data.Connected, nil
 }
 
+// ValidatorLiveness returns the liveness of the provided validator indices in the epoch.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness.
+func (h *httpAdapter) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	strIndices := make([]string, 0, len(indices))
+	for _, index := range indices {
+		strIndices = append(strIndices, fmt.Sprint(index))
+	}
+
+	reqBody, err := json.Marshal(strIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator liveness indices")
+	}
+
+	path := fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch)
+	respBody, err := httpPost(ctx, h.address, path, bytes.NewReader(reqBody), h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request validator liveness")
+	}
+
+	var resp livenessJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse validator liveness response")
+	}
+
+	return resp.Data, nil
+}
+
 // Domain returns the signing domain for a given domain type.
 // After EIP-7044, the VOLUNTARY_EXIT domain must always return a domain relative to the Capella hardfork.
 // This method returns just that for that domain type, otherwise follows the standard go-eth2-client flow.
@@ -260,6 +301,10 @@ type attestationsJSON struct {
 	Data []*eth2p0.Attestation `json:"data"`
 }
 
+type livenessJSON struct {
+	Data []*ValidatorLiveness `json:"data"`
+}
+
 type peerCountJSON struct {
 	Data struct {
 		Connected int `json:"connected,string"`
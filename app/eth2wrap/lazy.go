@@ -201,6 +201,15 @@ func (l *lazy) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	return cl.BlockAttestations(ctx, stateID)
 }
 
+func (l *lazy) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ValidatorLiveness(ctx, epoch, indices)
+}
+
 func (l *lazy) NodePeerCount(ctx context.Context) (int, error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
@@ -608,6 +608,36 @@ func (_m *Client) NodePeerCount(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
+// ValidatorLiveness provides a mock function with given fields: ctx, epoch, indices
+func (_m *Client) ValidatorLiveness(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+	ret := _m.Called(ctx, epoch, indices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatorLiveness")
+	}
+
+	var r0 []*eth2wrap.ValidatorLiveness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error)); ok {
+		return rf(ctx, epoch, indices)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) []*eth2wrap.ValidatorLiveness); ok {
+		r0 = rf(ctx, epoch, indices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*eth2wrap.ValidatorLiveness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) error); ok {
+		r1 = rf(ctx, epoch, indices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NodeSyncing provides a mock function with given fields: ctx, opts
 func (_m *Client) NodeSyncing(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*v1.SyncState], error) {
 	ret := _m.Called(ctx, opts)
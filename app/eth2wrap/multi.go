@@ -187,6 +187,24 @@ func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	return res, err
 }
 
+func (m multi) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	const label = "validator_liveness"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) ([]*ValidatorLiveness, error) {
+			return cl.ValidatorLiveness(ctx, epoch, indices)
+		},
+		nil, m.selector,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
 func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 	const label = "node_peer_count"
 	defer latency(label)()
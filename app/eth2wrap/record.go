@@ -0,0 +1,39 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"io"
+	stdlog "log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// NewRecordingProxy returns a http handler that reverse proxies requests to the beacon node address
+// and records the responses. Serving it locally and using its address instead of the beacon node's
+// records all beacon node responses for later replay.
+func NewRecordingProxy(address string, rec *recorder.Recorder) (http.Handler, error) {
+	targetURL, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse beacon node address", z.Str("address", address))
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(targetURL)
+	defaultDirector := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		if targetURL.User != nil {
+			password, _ := targetURL.User.Password()
+			req.SetBasicAuth(targetURL.User.Username(), password)
+		}
+		req.Host = targetURL.Host
+		defaultDirector(req)
+	}
+	proxy.ErrorLog = stdlog.New(io.Discard, "", 0)
+
+	return recorder.NewHTTPHandler(rec, recorder.SourceBN, proxy), nil
+}
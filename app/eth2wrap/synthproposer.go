@@ -43,10 +43,12 @@ type synthProposerEth2Provider interface {
 
 var _ Client = &synthWrapper{}
 
-// synthWrapper wraps an eth2 client and provides synthetic proposer duties.
+// synthWrapper wraps an eth2 client and provides synthetic proposer and sync committee duties.
+// Synthetic duties of a type are only provided if its cache is not nil.
 type synthWrapper struct {
 	Client
 	synthProposerCache *synthProposerCache
+	synthSyncCommCache *synthSyncCommCache
 
 	mu            sync.RWMutex
 	feeRecipients map[eth2p0.ValidatorIndex]bellatrix.ExecutionAddress
@@ -73,6 +75,10 @@ func (h *synthWrapper) getFeeRecipient(vIdx eth2p0.ValidatorIndex) bellatrix.Exe
 // ProposerDuties returns upstream proposer duties for the provided validator indexes or
 // upstream proposer duties and synthetic duties for all cluster validators if enabled.
 func (h *synthWrapper) ProposerDuties(ctx context.Context, opts *eth2api.ProposerDutiesOpts) (*eth2api.Response[[]*eth2v1.ProposerDuty], error) {
+	if h.synthProposerCache == nil {
+		return h.Client.ProposerDuties(ctx, opts)
+	}
+
 	duties, err := h.synthProposerCache.Duties(ctx, h.Client, opts.Epoch)
 	if err != nil {
 		return nil, err
@@ -89,6 +95,10 @@ func (h *synthWrapper) SubmitProposalPreparations(ctx context.Context, preparati
 
 // Proposal returns an unsigned beacon block proposal, possibly marked as synthetic.
 func (h *synthWrapper) Proposal(ctx context.Context, opts *eth2api.ProposalOpts) (*eth2api.Response[*eth2api.VersionedProposal], error) {
+	if h.synthProposerCache == nil {
+		return h.Client.Proposal(ctx, opts)
+	}
+
 	vIdx, ok, err := h.synthProposerCache.SyntheticVIdx(ctx, h.Client, opts.Slot)
 	if err != nil {
 		return nil, err
@@ -191,6 +201,8 @@ func fraction(transactions []bellatrix.Transaction) []bellatrix.Transaction {
 func (h *synthWrapper) SubmitBlindedProposal(ctx context.Context, opts *eth2api.SubmitBlindedProposalOpts) error {
 	if IsSyntheticBlindedBlock(opts.Proposal) {
 		log.Debug(ctx, "Synthetic blinded beacon proposal swallowed")
+		syntheticCounter.WithLabelValues("blinded_proposal").Inc()
+
 		return nil
 	}
 
@@ -201,6 +213,8 @@ func (h *synthWrapper) SubmitBlindedProposal(ctx context.Context, opts *eth2api.
 func (h *synthWrapper) SubmitProposal(ctx context.Context, opts *eth2api.SubmitProposalOpts) error {
 	if IsSyntheticProposal(opts.Proposal) {
 		log.Debug(ctx, "Synthetic beacon block swallowed")
+		syntheticCounter.WithLabelValues("proposal").Inc()
+
 		return nil
 	}
 
@@ -73,7 +73,7 @@ func TestSynthProposer(t *testing.T) {
 		return resp, nil
 	}
 
-	eth2Cl := eth2wrap.WithSyntheticDuties(bmock)
+	eth2Cl := eth2wrap.WithSyntheticDuties(bmock, eth2wrap.WithSyntheticProposals())
 
 	var preps []*eth2v1.ProposalPreparation
 	for vIdx := range set {
@@ -212,7 +212,7 @@ func TestSynthProposerBlockNotFound(t *testing.T) {
 	eth2Cl, err := eth2wrap.Instrument(bmock)
 	require.NoError(t, err)
 
-	eth2Cl = eth2wrap.WithSyntheticDuties(eth2Cl)
+	eth2Cl = eth2wrap.WithSyntheticDuties(eth2Cl, eth2wrap.WithSyntheticProposals())
 
 	var preps []*eth2v1.ProposalPreparation
 	for vIdx := range set {
@@ -0,0 +1,365 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// syntheticSyncCommMembers is the number of synthetic sync committee members per sync committee period.
+// Each member is selected as sync committee contribution aggregator for roughly one in eight slots.
+const syntheticSyncCommMembers = 1
+
+type synthSyncCommEth2Provider interface {
+	CachedValidatorsProvider
+	eth2client.SpecProvider
+	eth2client.SyncCommitteeDutiesProvider
+}
+
+// SyncCommitteeDuties returns upstream sync committee duties and synthetic sync committee duties
+// for the provided validator indexes if enabled.
+func (h *synthWrapper) SyncCommitteeDuties(ctx context.Context, opts *eth2api.SyncCommitteeDutiesOpts) (*eth2api.Response[[]*eth2v1.SyncCommitteeDuty], error) {
+	resp, err := h.Client.SyncCommitteeDuties(ctx, opts)
+	if err != nil || h.synthSyncCommCache == nil {
+		return resp, err
+	}
+
+	synths, err := h.synthSyncCommCache.Duties(ctx, h.Client, opts.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[eth2p0.ValidatorIndex]bool)
+	for _, vIdx := range opts.Indices {
+		requested[vIdx] = true
+	}
+
+	duties := append([]*eth2v1.SyncCommitteeDuty(nil), resp.Data...)
+	for _, duty := range synths {
+		if len(requested) > 0 && !requested[duty.ValidatorIndex] {
+			continue
+		}
+
+		duties = append(duties, duty)
+	}
+
+	return wrapResponse(duties), nil
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages, swallowing those of synthetic sync committee members.
+func (h *synthWrapper) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	if h.synthSyncCommCache == nil {
+		return h.Client.SubmitSyncCommitteeMessages(ctx, messages)
+	}
+
+	var filtered []*altair.SyncCommitteeMessage
+	for _, msg := range messages {
+		synthetic, err := h.synthSyncCommCache.IsSynthetic(ctx, h.Client, msg.Slot, msg.ValidatorIndex)
+		if err != nil {
+			return err
+		} else if !synthetic {
+			filtered = append(filtered, msg)
+		}
+	}
+
+	if swallowed := len(messages) - len(filtered); swallowed > 0 {
+		log.Debug(ctx, "Synthetic sync committee messages swallowed", z.Int("count", swallowed))
+		syntheticCounter.WithLabelValues("sync_message").Add(float64(swallowed))
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return h.Client.SubmitSyncCommitteeMessages(ctx, filtered)
+}
+
+// SubmitSyncCommitteeSubscriptions subscribes to sync committees, swallowing subscriptions of synthetic sync committee members.
+func (h *synthWrapper) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*eth2v1.SyncCommitteeSubscription) error {
+	if h.synthSyncCommCache == nil {
+		return h.Client.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+	}
+
+	slotsPerEpoch, err := h.synthSyncCommCache.SlotsPerEpoch(ctx, h.Client)
+	if err != nil {
+		return err
+	}
+
+	var filtered []*eth2v1.SyncCommitteeSubscription
+	for _, sub := range subscriptions {
+		// Subscriptions apply until the end of the sync committee period.
+		lastSlot := eth2p0.Slot(max(sub.UntilEpoch, 1)-1) * eth2p0.Slot(slotsPerEpoch)
+
+		synthetic, err := h.synthSyncCommCache.IsSynthetic(ctx, h.Client, lastSlot, sub.ValidatorIndex)
+		if err != nil {
+			return err
+		} else if !synthetic {
+			filtered = append(filtered, sub)
+		}
+	}
+
+	if swallowed := len(subscriptions) - len(filtered); swallowed > 0 {
+		log.Debug(ctx, "Synthetic sync committee subscriptions swallowed", z.Int("count", swallowed))
+		syntheticCounter.WithLabelValues("sync_subscription").Add(float64(swallowed))
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return h.Client.SubmitSyncCommitteeSubscriptions(ctx, filtered)
+}
+
+// SyncCommitteeContribution returns the upstream sync committee contribution, or an empty synthetic contribution
+// if the upstream contribution isn't available and a synthetic sync committee member is part of the subcommittee.
+func (h *synthWrapper) SyncCommitteeContribution(ctx context.Context, opts *eth2api.SyncCommitteeContributionOpts) (*eth2api.Response[*altair.SyncCommitteeContribution], error) {
+	resp, err := h.Client.SyncCommitteeContribution(ctx, opts)
+	if err == nil || h.synthSyncCommCache == nil {
+		return resp, err
+	}
+
+	synthetic, synthErr := h.synthSyncCommCache.IsSyntheticSubcommittee(ctx, h.Client, opts.Slot, opts.SubcommitteeIndex)
+	if synthErr != nil {
+		return nil, synthErr
+	} else if !synthetic {
+		return nil, err
+	}
+
+	return wrapResponse(&altair.SyncCommitteeContribution{
+		Slot:              opts.Slot,
+		BeaconBlockRoot:   opts.BeaconBlockRoot,
+		SubcommitteeIndex: opts.SubcommitteeIndex,
+		AggregationBits:   bitfield.NewBitvector128(),
+		Signature:         eth2p0.BLSSignature{0xc0}, // Infinity signature of the empty aggregate.
+	}), nil
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions, swallowing those of synthetic sync committee members.
+func (h *synthWrapper) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	if h.synthSyncCommCache == nil {
+		return h.Client.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+	}
+
+	var filtered []*altair.SignedContributionAndProof
+	for _, contrib := range contributionAndProofs {
+		synthetic, err := h.synthSyncCommCache.IsSynthetic(ctx, h.Client,
+			contrib.Message.Contribution.Slot, contrib.Message.AggregatorIndex)
+		if err != nil {
+			return err
+		} else if !synthetic {
+			filtered = append(filtered, contrib)
+		}
+	}
+
+	if swallowed := len(contributionAndProofs) - len(filtered); swallowed > 0 {
+		log.Debug(ctx, "Synthetic sync committee contributions swallowed", z.Int("count", swallowed))
+		syntheticCounter.WithLabelValues("sync_contribution").Add(float64(swallowed))
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return h.Client.SubmitSyncCommitteeContributions(ctx, filtered)
+}
+
+// newSynthSyncCommCache returns a new cache for synthetic sync committee duties.
+func newSynthSyncCommCache() *synthSyncCommCache {
+	return &synthSyncCommCache{
+		synths:      make(map[uint64][]*eth2v1.SyncCommitteeDuty),
+		shuffleFunc: eth2Shuffle,
+	}
+}
+
+// synthSyncCommCache caches synthetic sync committee duties by sync committee period.
+//
+// Since sync committee membership is constant for a period, synthetic members are
+// selected deterministically per period from all validators not already members.
+type synthSyncCommCache struct {
+	// shuffleFunc deterministically shuffles the validator indices for the epoch.
+	shuffleFunc func(eth2p0.Epoch, []eth2p0.ValidatorIndex) []eth2p0.ValidatorIndex
+
+	mu     sync.RWMutex
+	fifo   []uint64
+	synths map[uint64][]*eth2v1.SyncCommitteeDuty
+}
+
+// syncCommSpec returns the sync committee related spec values.
+type syncCommSpec struct {
+	SlotsPerEpoch   uint64
+	EpochsPerPeriod uint64
+	CommitteeSize   uint64
+	SubnetCount     uint64
+}
+
+// Spec returns the sync committee related spec values.
+func (*synthSyncCommCache) Spec(ctx context.Context, eth2Cl synthSyncCommEth2Provider) (syncCommSpec, error) {
+	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return syncCommSpec{}, err
+	}
+
+	var resp syncCommSpec
+	for name, field := range map[string]*uint64{
+		"SLOTS_PER_EPOCH":                  &resp.SlotsPerEpoch,
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": &resp.EpochsPerPeriod,
+		"SYNC_COMMITTEE_SIZE":              &resp.CommitteeSize,
+		"SYNC_COMMITTEE_SUBNET_COUNT":      &resp.SubnetCount,
+	} {
+		val, ok := eth2Resp.Data[name].(uint64)
+		if !ok || val == 0 {
+			return syncCommSpec{}, errors.New("invalid spec value", z.Str("name", name))
+		}
+		*field = val
+	}
+
+	return resp, nil
+}
+
+// SlotsPerEpoch returns the number of slots per epoch.
+func (c *synthSyncCommCache) SlotsPerEpoch(ctx context.Context, eth2Cl synthSyncCommEth2Provider) (uint64, error) {
+	spec, err := c.Spec(ctx, eth2Cl)
+	if err != nil {
+		return 0, err
+	}
+
+	return spec.SlotsPerEpoch, nil
+}
+
+// Duties returns the synthetic sync committee duties of the sync committee period of the provided epoch.
+func (c *synthSyncCommCache) Duties(ctx context.Context, eth2Cl synthSyncCommEth2Provider, epoch eth2p0.Epoch) ([]*eth2v1.SyncCommitteeDuty, error) {
+	spec, err := c.Spec(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	period := uint64(epoch) / spec.EpochsPerPeriod
+
+	// Check if cache already populated for this period using read lock.
+	c.mu.RLock()
+	duties, ok := c.synths[period]
+	c.mu.RUnlock()
+	if ok {
+		return duties, nil
+	}
+
+	vals, err := eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := vals.Indices()
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	// Get actual duties for all validators for the period.
+	opts := &eth2api.SyncCommitteeDutiesOpts{
+		Epoch:   epoch,
+		Indices: indices,
+	}
+	resp, err := eth2Cl.SyncCommitteeDuties(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark those not requiring synthetic duties.
+	noSynth := make(map[eth2p0.ValidatorIndex]bool)
+	for _, duty := range resp.Data {
+		noSynth[duty.ValidatorIndex] = true
+	}
+
+	// Deterministic synthetic duties for the rest.
+	duties = []*eth2v1.SyncCommitteeDuty{} // Cache empty duties as well.
+	for _, vIdx := range c.shuffleFunc(eth2p0.Epoch(period), indices) {
+		if len(duties) == syntheticSyncCommMembers {
+			break
+		} else if noSynth[vIdx] {
+			continue
+		}
+
+		duties = append(duties, &eth2v1.SyncCommitteeDuty{
+			PubKey:                        vals[vIdx],
+			ValidatorIndex:                vIdx,
+			ValidatorSyncCommitteeIndices: []eth2p0.CommitteeIndex{eth2p0.CommitteeIndex(uint64(vIdx) % spec.CommitteeSize)},
+		})
+	}
+
+	// Cache the values for the period
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fifo = append(c.fifo, period)
+	c.synths[period] = duties
+
+	// Trim the cache
+	if len(c.fifo) > maxCachedEpochs {
+		delete(c.synths, c.fifo[0])
+		c.fifo = c.fifo[1:]
+	}
+
+	return duties, nil
+}
+
+// IsSynthetic returns true if the validator is a synthetic sync committee member in the slot.
+func (c *synthSyncCommCache) IsSynthetic(ctx context.Context, eth2Cl synthSyncCommEth2Provider, slot eth2p0.Slot, vIdx eth2p0.ValidatorIndex) (bool, error) {
+	duties, err := c.slotDuties(ctx, eth2Cl, slot)
+	if err != nil {
+		return false, err
+	}
+
+	for _, duty := range duties {
+		if duty.ValidatorIndex == vIdx {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsSyntheticSubcommittee returns true if a synthetic sync committee member is part of the subcommittee in the slot.
+func (c *synthSyncCommCache) IsSyntheticSubcommittee(ctx context.Context, eth2Cl synthSyncCommEth2Provider, slot eth2p0.Slot, subcommIdx uint64) (bool, error) {
+	spec, err := c.Spec(ctx, eth2Cl)
+	if err != nil {
+		return false, err
+	}
+
+	duties, err := c.slotDuties(ctx, eth2Cl, slot)
+	if err != nil {
+		return false, err
+	}
+
+	subcommSize := spec.CommitteeSize / spec.SubnetCount
+	for _, duty := range duties {
+		for _, commIdx := range duty.ValidatorSyncCommitteeIndices {
+			if uint64(commIdx)/subcommSize == subcommIdx {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// slotDuties returns the synthetic sync committee duties of the sync committee period of the slot.
+func (c *synthSyncCommCache) slotDuties(ctx context.Context, eth2Cl synthSyncCommEth2Provider, slot eth2p0.Slot) ([]*eth2v1.SyncCommitteeDuty, error) {
+	slotsPerEpoch, err := c.SlotsPerEpoch(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Duties(ctx, eth2Cl, eth2p0.Epoch(uint64(slot)/slotsPerEpoch))
+}
@@ -0,0 +1,128 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap_test
+
+import (
+	"context"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestSynthSyncCommittee(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		set                        = beaconmock.ValidatorSetA
+		slotsPerEpoch              = 16
+		epoch         eth2p0.Epoch = 100
+		slot                       = eth2p0.Slot(slotsPerEpoch) * eth2p0.Slot(epoch)
+		realVIdx                   = set[1].Index
+		indices       []eth2p0.ValidatorIndex
+	)
+	for vIdx := range set {
+		indices = append(indices, vIdx)
+	}
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(set), beaconmock.WithSlotsPerEpoch(slotsPerEpoch))
+	require.NoError(t, err)
+
+	bmock.SyncCommitteeDutiesFunc = func(_ context.Context, _ eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2v1.SyncCommitteeDuty, error) {
+		for _, vIdx := range indices {
+			if vIdx != realVIdx {
+				continue
+			}
+
+			return []*eth2v1.SyncCommitteeDuty{{
+				PubKey:                        set[1].Validator.PublicKey,
+				ValidatorIndex:                realVIdx,
+				ValidatorSyncCommitteeIndices: []eth2p0.CommitteeIndex{0},
+			}}, nil
+		}
+
+		return nil, nil
+	}
+
+	var submitted []eth2p0.ValidatorIndex
+	bmock.SubmitSyncCommitteeMessagesFunc = func(_ context.Context, messages []*altair.SyncCommitteeMessage) error {
+		for _, msg := range messages {
+			submitted = append(submitted, msg.ValidatorIndex)
+		}
+
+		return nil
+	}
+	bmock.SubmitSyncCommitteeSubscriptionsFunc = func(_ context.Context, subs []*eth2v1.SyncCommitteeSubscription) error {
+		for _, sub := range subs {
+			submitted = append(submitted, sub.ValidatorIndex)
+		}
+
+		return nil
+	}
+	bmock.SubmitSyncCommitteeContributionsFunc = func(_ context.Context, contribs []*altair.SignedContributionAndProof) error {
+		for _, contrib := range contribs {
+			submitted = append(submitted, contrib.Message.AggregatorIndex)
+		}
+
+		return nil
+	}
+	bmock.SyncCommitteeContributionFunc = func(context.Context, eth2p0.Slot, uint64, eth2p0.Root) (*altair.SyncCommitteeContribution, error) {
+		return nil, errors.New("no contribution")
+	}
+
+	eth2Cl := eth2wrap.WithSyntheticDuties(bmock, eth2wrap.WithSyntheticSyncCommittee())
+
+	// Proposer duties are not synthetic.
+	proposerResp, err := eth2Cl.ProposerDuties(ctx, &eth2api.ProposerDutiesOpts{Epoch: epoch, Indices: indices})
+	require.NoError(t, err)
+	require.Empty(t, proposerResp.Data)
+
+	// Get synthetic duties
+	resp, err := eth2Cl.SyncCommitteeDuties(ctx, &eth2api.SyncCommitteeDutiesOpts{Epoch: epoch, Indices: indices})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, realVIdx, resp.Data[0].ValidatorIndex)
+
+	synthVIdx := resp.Data[1].ValidatorIndex
+	require.NotEqual(t, realVIdx, synthVIdx)
+
+	// Synthetic duties are deterministic for the sync committee period.
+	resp, err = eth2Cl.SyncCommitteeDuties(ctx, &eth2api.SyncCommitteeDutiesOpts{Epoch: epoch + 1, Indices: []eth2p0.ValidatorIndex{synthVIdx}})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, synthVIdx, resp.Data[0].ValidatorIndex)
+
+	// Synthetic submissions are swallowed.
+	require.NoError(t, eth2Cl.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{
+		{Slot: slot, ValidatorIndex: realVIdx},
+		{Slot: slot, ValidatorIndex: synthVIdx},
+	}))
+	require.NoError(t, eth2Cl.SubmitSyncCommitteeSubscriptions(ctx, []*eth2v1.SyncCommitteeSubscription{
+		{ValidatorIndex: realVIdx, UntilEpoch: epoch + 1},
+		{ValidatorIndex: synthVIdx, UntilEpoch: epoch + 1},
+	}))
+	require.NoError(t, eth2Cl.SubmitSyncCommitteeContributions(ctx, []*altair.SignedContributionAndProof{
+		{Message: &altair.ContributionAndProof{AggregatorIndex: realVIdx, Contribution: &altair.SyncCommitteeContribution{Slot: slot}}},
+		{Message: &altair.ContributionAndProof{AggregatorIndex: synthVIdx, Contribution: &altair.SyncCommitteeContribution{Slot: slot}}},
+	}))
+	require.NoError(t, eth2Cl.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{
+		{Slot: slot, ValidatorIndex: synthVIdx},
+	}))
+	require.Equal(t, []eth2p0.ValidatorIndex{realVIdx, realVIdx, realVIdx}, submitted)
+
+	// Empty synthetic contributions are returned for the subcommittee of synthetic members only.
+	contribResp, err := eth2Cl.SyncCommitteeContribution(ctx, &eth2api.SyncCommitteeContributionOpts{Slot: slot, SubcommitteeIndex: 0})
+	require.NoError(t, err)
+	require.Equal(t, slot, contribResp.Data.Slot)
+	require.Zero(t, contribResp.Data.AggregationBits.Count())
+
+	_, err = eth2Cl.SyncCommitteeContribution(ctx, &eth2api.SyncCommitteeContributionOpts{Slot: slot, SubcommitteeIndex: 1})
+	require.ErrorContains(t, err, "no contribution")
+}
@@ -45,17 +45,38 @@ const (
 	// The feature gets automatically enabled when the current network is gnosis|chiado,
 	// unless the user disabled this feature explicitly.
 	GnosisBlockHotfix Feature = "gnosis_block_hotfix"
+
+	// Gossip enables relay based gossip dissemination of partial signatures and consensus messages
+	// if supported by the cluster, reducing bandwidth and latency of clusters with many operators.
+	Gossip Feature = "gossip"
+
+	// StateSync enables syncing recent aggregate signatures and decided consensus values from peers on startup,
+	// so a node restarted mid-epoch resumes participating in all duties immediately.
+	StateSync Feature = "state_sync"
+
+	// ReliableLeaders enables deprioritising cluster wide agreed unreliable peers as QBFT consensus leaders,
+	// reducing the latency of duties otherwise led by chronically slow or offline peers.
+	// All peers of the cluster should enable it, since peers disabling it elect different leaders.
+	ReliableLeaders Feature = "reliable_leaders"
+
+	// ValidateConsensusValues enables validating values proposed by consensus leaders before accepting them.
+	// The fee recipient of local proposals is only enforced if the cluster agrees on the cluster manifest.
+	ValidateConsensusValues Feature = "validate_consensus_values"
 )
 
 var (
 	// state defines the current rollout status of each feature.
 	state = map[Feature]status{
-		EagerDoubleLinear:    statusStable,
-		ConsensusParticipate: statusStable,
-		MockAlpha:            statusAlpha,
-		AggSigDBV2:           statusAlpha,
-		JSONRequests:         statusAlpha,
-		GnosisBlockHotfix:    statusAlpha,
+		EagerDoubleLinear:       statusStable,
+		ConsensusParticipate:    statusStable,
+		MockAlpha:               statusAlpha,
+		AggSigDBV2:              statusAlpha,
+		JSONRequests:            statusAlpha,
+		GnosisBlockHotfix:       statusAlpha,
+		Gossip:                  statusAlpha,
+		StateSync:               statusAlpha,
+		ReliableLeaders:         statusAlpha,
+		ValidateConsensusValues: statusAlpha,
 		// Add all features and there status here.
 	}
 
@@ -78,6 +78,19 @@ var checks = []check{
 			return maxVal == 1, nil
 		},
 	},
+	{
+		Name:        "doppelganger_detected",
+		Description: "Validator activity not produced by this cluster detected, duties are disabled. Ensure no other instance of this node is running and restart.",
+		Severity:    severityCritical,
+		Func: func(q query, _ Metadata) (bool, error) {
+			maxVal, err := q("core_scheduler_doppelganger_detected", noLabels, gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal == 1, nil
+		},
+	},
 	{
 		Name:        "insufficient_connected_peers",
 		Description: "Not connected to at least quorum peers. Check logs for networking issue or coordinate with peers.",
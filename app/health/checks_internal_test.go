@@ -215,6 +215,28 @@ func TestBNSyncingCheck(t *testing.T) {
 	})
 }
 
+func TestDoppelgangerCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "doppelganger_detected"
+	metricName := "core_scheduler_doppelganger_detected"
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("not detected", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName, genGauge(nil, 0, 0, 0)),
+		)
+	})
+
+	t.Run("detected", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName, genGauge(nil, 0, 1, 1)),
+		)
+	})
+}
+
 func TestErrorLogsCheck(t *testing.T) {
 	m := Metadata{
 		NumValidators: 10,
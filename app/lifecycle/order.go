@@ -16,6 +16,7 @@ const (
 	StartTracker OrderStart = iota
 	StartPrivkeyLock
 	StartAggSigDB
+	StartStateSync
 	StartRelay
 	StartMonitoringAPI
 	StartDebugAPI
@@ -30,6 +31,7 @@ const (
 	StartPeerInfo
 	StartParSigDB
 	StartStackSnipe
+	StartReplay
 )
 
 // Global ordering of stop hooks; follows dependency tree from root to leaves.
@@ -38,6 +40,7 @@ const (
 	StopPrivkeyLock
 	StopRetryer
 	StopDutyDB
+	StopSignatureDBs
 	StopBeaconMock // Close this before validator API, since it can hold long-lived connections.
 	StopValidatorAPI
 	StopTracing // Low level services...
@@ -46,4 +49,5 @@ const (
 	StopP2PUDPNode
 	StopDebugAPI
 	StopMonitoringAPI
+	StopRecorder
 )
@@ -13,25 +13,27 @@ func _() {
 	_ = x[StartTracker-0]
 	_ = x[StartPrivkeyLock-1]
 	_ = x[StartAggSigDB-2]
-	_ = x[StartRelay-3]
-	_ = x[StartMonitoringAPI-4]
-	_ = x[StartDebugAPI-5]
-	_ = x[StartValidatorAPI-6]
-	_ = x[StartP2PPing-7]
-	_ = x[StartP2PRouters-8]
-	_ = x[StartForceDirectConns-9]
-	_ = x[StartP2PConsensus-10]
-	_ = x[StartSimulator-11]
-	_ = x[StartScheduler-12]
-	_ = x[StartP2PEventCollector-13]
-	_ = x[StartPeerInfo-14]
-	_ = x[StartParSigDB-15]
-	_ = x[StartStackSnipe-16]
+	_ = x[StartStateSync-3]
+	_ = x[StartRelay-4]
+	_ = x[StartMonitoringAPI-5]
+	_ = x[StartDebugAPI-6]
+	_ = x[StartValidatorAPI-7]
+	_ = x[StartP2PPing-8]
+	_ = x[StartP2PRouters-9]
+	_ = x[StartForceDirectConns-10]
+	_ = x[StartP2PConsensus-11]
+	_ = x[StartSimulator-12]
+	_ = x[StartScheduler-13]
+	_ = x[StartP2PEventCollector-14]
+	_ = x[StartPeerInfo-15]
+	_ = x[StartParSigDB-16]
+	_ = x[StartStackSnipe-17]
+	_ = x[StartReplay-18]
 }
 
-const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipe"
+const _OrderStart_name = "TrackerPrivkeyLockAggSigDBStateSyncRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipeReplay"
 
-var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 71, 81, 97, 109, 118, 127, 144, 152, 160, 170}
+var _OrderStart_index = [...]uint8{0, 7, 18, 26, 35, 40, 53, 61, 73, 80, 90, 106, 118, 127, 136, 153, 161, 169, 179, 185}
 
 func (i OrderStart) String() string {
 	if i < 0 || i >= OrderStart(len(_OrderStart_index)-1) {
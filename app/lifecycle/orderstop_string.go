@@ -14,19 +14,21 @@ func _() {
 	_ = x[StopPrivkeyLock-1]
 	_ = x[StopRetryer-2]
 	_ = x[StopDutyDB-3]
-	_ = x[StopBeaconMock-4]
-	_ = x[StopValidatorAPI-5]
-	_ = x[StopTracing-6]
-	_ = x[StopP2PPeerDB-7]
-	_ = x[StopP2PTCPNode-8]
-	_ = x[StopP2PUDPNode-9]
-	_ = x[StopDebugAPI-10]
-	_ = x[StopMonitoringAPI-11]
+	_ = x[StopSignatureDBs-4]
+	_ = x[StopBeaconMock-5]
+	_ = x[StopValidatorAPI-6]
+	_ = x[StopTracing-7]
+	_ = x[StopP2PPeerDB-8]
+	_ = x[StopP2PTCPNode-9]
+	_ = x[StopP2PUDPNode-10]
+	_ = x[StopDebugAPI-11]
+	_ = x[StopMonitoringAPI-12]
+	_ = x[StopRecorder-13]
 }
 
-const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPI"
+const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBSignatureDBsBeaconMockValidatorAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPIRecorder"
 
-var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 62, 71, 81, 91, 99, 112}
+var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 45, 55, 67, 74, 83, 93, 103, 111, 124, 132}
 
 func (i OrderStop) String() string {
 	if i < 0 || i >= OrderStop(len(_OrderStop_index)-1) {
@@ -0,0 +1,214 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapi
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+const (
+	fullDepositBaseTmpl = "/exp/deposit"
+	fullDepositEndTmp   = "/" + lockHashPath + "/" + shareIndexPath + "/" + valPubkeyPath
+
+	partialDepositTmpl = "/exp/partial_deposits/" + lockHashPath
+	fullDepositTmpl    = fullDepositBaseTmpl + fullDepositEndTmp
+)
+
+// partialDepositURL returns the partial deposit Obol API URL for a given lock hash.
+func partialDepositURL(lockHash string) string {
+	return strings.NewReplacer(
+		lockHashPath,
+		lockHash,
+	).Replace(partialDepositTmpl)
+}
+
+// fullDepositURL returns the full deposit Obol API URL for a given validator public key.
+func fullDepositURL(valPubkey, lockHash string, shareIndex uint64) string {
+	return strings.NewReplacer(
+		valPubkeyPath,
+		valPubkey,
+		lockHashPath,
+		lockHash,
+		shareIndexPath,
+		strconv.FormatUint(shareIndex, 10),
+	).Replace(fullDepositTmpl)
+}
+
+// PostPartialDeposits POSTs the set of partially signed deposit data to the Obol API, for a given lock hash.
+// It respects the timeout specified in the Client instance.
+func (c Client) PostPartialDeposits(ctx context.Context, lockHash []byte, shareIndex uint64, identityKey *k1.PrivateKey, depositDatas ...eth2p0.DepositData) error {
+	lockHashStr := "0x" + hex.EncodeToString(lockHash)
+
+	path := partialDepositURL(lockHashStr)
+
+	u, err := url.ParseRequestURI(c.baseURL)
+	if err != nil {
+		return errors.Wrap(err, "bad Obol API url")
+	}
+
+	u.Path = path
+
+	// sort by validator public key and amount ascending
+	sort.Slice(depositDatas, func(i, j int) bool {
+		if depositDatas[i].PublicKey != depositDatas[j].PublicKey {
+			return depositDatas[i].PublicKey.String() < depositDatas[j].PublicKey.String()
+		}
+
+		return depositDatas[i].Amount < depositDatas[j].Amount
+	})
+
+	msg := UnsignedPartialDepositRequest{
+		ShareIdx:           shareIndex,
+		PartialDepositData: depositDatas,
+	}
+
+	msgRoot, err := msg.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "partial deposits hash tree root")
+	}
+
+	signature, err := k1util.Sign(identityKey, msgRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "k1 sign")
+	}
+
+	data, err := json.Marshal(PartialDepositRequest{
+		UnsignedPartialDepositRequest: msg,
+		Signature:                     signature,
+	})
+	if err != nil {
+		return errors.Wrap(err, "json marshal error")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	err = httpPost(ctx, u, data, nil)
+	if err != nil {
+		return errors.Wrap(err, "http Obol API POST request")
+	}
+
+	return nil
+}
+
+// GetFullDeposit gets the threshold aggregated deposit data of all amounts for a given validator public key, lock hash and share index.
+// It respects the timeout specified in the Client instance.
+func (c Client) GetFullDeposit(ctx context.Context, valPubkey string, lockHash []byte, shareIndex uint64, identityKey *k1.PrivateKey) ([]eth2p0.DepositData, error) {
+	valPubkeyBytes, err := from0x(valPubkey, 48) // public key is 48 bytes long
+	if err != nil {
+		return nil, errors.Wrap(err, "validator pubkey to bytes")
+	}
+
+	path := fullDepositURL(valPubkey, "0x"+hex.EncodeToString(lockHash), shareIndex)
+
+	u, err := url.ParseRequestURI(c.baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad Obol API url")
+	}
+
+	u.Path = path
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	depositAuthData := FullDepositAuthBlob{
+		LockHash:        lockHash,
+		ValidatorPubkey: valPubkeyBytes,
+		ShareIndex:      shareIndex,
+	}
+
+	depositAuthDataRoot, err := depositAuthData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "deposit auth data root")
+	}
+
+	authSignature, err := k1util.Sign(identityKey, depositAuthDataRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "k1 sign")
+	}
+
+	respBody, err := httpGet(ctx, u, map[string]string{"Authorization": bearerString(authSignature)})
+	if err != nil {
+		return nil, errors.Wrap(err, "http Obol API GET request")
+	}
+
+	defer respBody.Close()
+
+	var dr FullDepositResponse
+	if err := json.NewDecoder(respBody).Decode(&dr); err != nil {
+		return nil, errors.Wrap(err, "json unmarshal error")
+	}
+
+	creds, err := from0x(dr.WithdrawalCredentials, 32) // withdrawal credentials are 32 bytes long
+	if err != nil {
+		return nil, errors.Wrap(err, "withdrawal credentials unmarshal")
+	}
+
+	var resp []eth2p0.DepositData
+	for _, amount := range dr.Amounts {
+		amountUint64, err := strconv.ParseUint(amount.Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "amount parsing")
+		}
+
+		fullSig, err := aggregatePartialSignatures(amount.Signatures)
+		if err != nil {
+			return nil, errors.Wrap(err, "aggregate deposit signatures", z.U64("amount", amountUint64))
+		}
+
+		resp = append(resp, eth2p0.DepositData{
+			PublicKey:             eth2p0.BLSPubKey(valPubkeyBytes),
+			WithdrawalCredentials: creds,
+			Amount:                eth2p0.Gwei(amountUint64),
+			Signature:             eth2p0.BLSSignature(fullSig),
+		})
+	}
+
+	return resp, nil
+}
+
+// aggregatePartialSignatures threshold aggregates the hex-encoded partial signatures ordered by share index.
+// Empty signatures indicate the associated share index didn't submit a partial signature.
+func aggregatePartialSignatures(signatures []string) (tbls.Signature, error) {
+	rawSignatures := make(map[int]tbls.Signature)
+
+	for sigIdx, sigStr := range signatures {
+		if len(sigStr) == 0 {
+			continue
+		}
+
+		sigBytes, err := from0x(sigStr, 96) // a signature is 96 bytes long
+		if err != nil {
+			return tbls.Signature{}, errors.Wrap(err, "partial signature unmarshal")
+		}
+
+		sig, err := tblsconv.SignatureFromBytes(sigBytes)
+		if err != nil {
+			return tbls.Signature{}, errors.Wrap(err, "invalid partial signature")
+		}
+
+		rawSignatures[sigIdx+1] = sig
+	}
+
+	fullSig, err := tbls.ThresholdAggregate(rawSignatures)
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "partial signatures threshold aggregate")
+	}
+
+	return fullSig, nil
+}
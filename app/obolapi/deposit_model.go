@@ -0,0 +1,188 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// sszMaxDeposits is the maximum amount of deposit data in an array.
+const sszMaxDeposits = 65536
+
+// PartialDepositRequest represents the blob of data sent to the Obol API server, which is stored in the backend awaiting
+// aggregation.
+// Signature is the EC signature of PartialDepositData's hash tree root done with the Charon node identity key.
+type PartialDepositRequest struct {
+	UnsignedPartialDepositRequest
+	Signature []byte `json:"signature"`
+}
+
+// partialDepositRequestDTO is PartialDepositRequest, but for serialization on the wire.
+type partialDepositRequestDTO struct {
+	UnsignedPartialDepositRequest
+	Signature string `json:"signature"`
+}
+
+func (p *PartialDepositRequest) UnmarshalJSON(bytes []byte) error {
+	var dto partialDepositRequestDTO
+
+	if err := json.Unmarshal(bytes, &dto); err != nil {
+		//nolint: wrapcheck // caller will wrap this error
+		return err
+	}
+
+	// an identity key signature is 65 bytes long
+	sigBytes, err := from0x(dto.Signature, 65)
+	if err != nil {
+		return err
+	}
+
+	p.UnsignedPartialDepositRequest = dto.UnsignedPartialDepositRequest
+	p.Signature = sigBytes
+
+	return nil
+}
+
+func (p PartialDepositRequest) MarshalJSON() ([]byte, error) {
+	dto := partialDepositRequestDTO{
+		UnsignedPartialDepositRequest: p.UnsignedPartialDepositRequest,
+		Signature:                     fmt.Sprintf("%#x", p.Signature),
+	}
+
+	//nolint: wrapcheck // caller will wrap this error
+	return json.Marshal(dto)
+}
+
+// UnsignedPartialDepositRequest represents an unsigned blob of data sent to the Obol API server, which is stored in the backend awaiting
+// aggregation.
+type UnsignedPartialDepositRequest struct {
+	PartialDepositData PartialDeposits `json:"partial_deposit_data"`
+	ShareIdx           uint64          `json:"share_idx,omitempty"`
+}
+
+func (p UnsignedPartialDepositRequest) GetTree() (*ssz.Node, error) {
+	node, err := ssz.ProofTree(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "proof tree")
+	}
+
+	return node, nil
+}
+
+func (p UnsignedPartialDepositRequest) HashTreeRoot() ([32]byte, error) {
+	hash, err := ssz.HashWithDefaultHasher(p)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash with default hasher")
+	}
+
+	return hash, nil
+}
+
+func (p UnsignedPartialDepositRequest) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+
+	if err := p.PartialDepositData.HashTreeRootWith(hh); err != nil {
+		return errors.Wrap(err, "hash tree root with")
+	}
+
+	hh.PutUint64(p.ShareIdx)
+
+	hh.Merkleize(indx)
+
+	return nil
+}
+
+// PartialDeposits is an array of deposit data that have been signed with a partial key.
+type PartialDeposits []eth2p0.DepositData
+
+func (p PartialDeposits) GetTree() (*ssz.Node, error) {
+	node, err := ssz.ProofTree(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "proof tree")
+	}
+
+	return node, nil
+}
+
+func (p PartialDeposits) HashTreeRoot() ([32]byte, error) {
+	hash, err := ssz.HashWithDefaultHasher(p)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash with default hasher")
+	}
+
+	return hash, nil
+}
+
+func (p PartialDeposits) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+
+	num := uint64(len(p))
+	for _, dd := range p {
+		if err := dd.HashTreeRootWith(hh); err != nil {
+			return errors.Wrap(err, "deposit data hash tree root")
+		}
+	}
+
+	hh.MerkleizeWithMixin(indx, num, sszMaxDeposits)
+
+	return nil
+}
+
+// FullDepositResponse contains the partial signatures of all deposit amounts of a validator.
+type FullDepositResponse struct {
+	PublicKey             string              `json:"public_key"`
+	WithdrawalCredentials string              `json:"withdrawal_credentials"`
+	Amounts               []FullDepositAmount `json:"amounts"`
+}
+
+// FullDepositAmount contains all partial signatures for a deposit amount.
+// Signatures are ordered by share index.
+type FullDepositAmount struct {
+	Amount     string   `json:"amount"`
+	Signatures []string `json:"signatures"`
+}
+
+// FullDepositAuthBlob represents the data required by Obol API to download the full deposit blobs.
+type FullDepositAuthBlob struct {
+	LockHash        []byte
+	ValidatorPubkey []byte
+	ShareIndex      uint64
+}
+
+func (f FullDepositAuthBlob) GetTree() (*ssz.Node, error) {
+	node, err := ssz.ProofTree(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "proof tree")
+	}
+
+	return node, nil
+}
+
+func (f FullDepositAuthBlob) HashTreeRoot() ([32]byte, error) {
+	hash, err := ssz.HashWithDefaultHasher(f)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash with default hasher")
+	}
+
+	return hash, nil
+}
+
+func (f FullDepositAuthBlob) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+
+	hh.PutBytes(f.LockHash)
+	if err := putBytesN(hh, f.ValidatorPubkey, sszLenPubKey); err != nil {
+		return errors.Wrap(err, "validator pubkey ssz")
+	}
+	hh.PutUint64(f.ShareIndex)
+
+	hh.Merkleize(indx)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapi_test
+
+import (
+	"context"
+	"math/rand"
+	"net/http/httptest"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil/obolapimock"
+)
+
+func TestDepositAPIFlow(t *testing.T) {
+	const kn = 4
+
+	handler, addLockFiles := obolapimock.MockServer(false, nil)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	lock, identityKeys, shares := cluster.NewForT(t, 1, kn-1, kn, 0, rand.New(rand.NewSource(0)))
+	addLockFiles(lock)
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	require.NoError(t, err)
+
+	pubkey := eth2p0.BLSPubKey(lock.Validators[0].PubKey)
+	msg, err := deposit.NewMessage(pubkey, lock.ValidatorAddresses[0].WithdrawalAddress, deposit.MinDepositAmount, false)
+	require.NoError(t, err)
+
+	sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+	require.NoError(t, err)
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Fetching fails before threshold partial deposits are submitted.
+	_, err = cl.GetFullDeposit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, 1, identityKeys[0])
+	require.ErrorContains(t, err, "http GET failed")
+
+	// Skip the first share index.
+	for idx := 1; idx < kn; idx++ {
+		sig, err := tbls.Sign(shares[0][idx], sigRoot[:])
+		require.NoError(t, err)
+
+		dd := eth2p0.DepositData{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                msg.Amount,
+			Signature:             eth2p0.BLSSignature(sig),
+		}
+
+		require.NoError(t, cl.PostPartialDeposits(ctx, lock.LockHash, uint64(idx+1), identityKeys[idx], dd), "share index: %d", idx+1)
+	}
+
+	valPubk, err := lock.Validators[0].PublicKey()
+	require.NoError(t, err)
+
+	for idx := range kn {
+		depositDatas, err := cl.GetFullDeposit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, uint64(idx+1), identityKeys[idx])
+		require.NoError(t, err, "share index: %d", idx+1)
+		require.Len(t, depositDatas, 1)
+		require.Equal(t, msg.WithdrawalCredentials, depositDatas[0].WithdrawalCredentials)
+		require.Equal(t, msg.Amount, depositDatas[0].Amount)
+
+		// verify that the aggregated signature works
+		require.NoError(t, tbls.Verify(valPubk, sigRoot[:], tbls.Signature(depositDatas[0].Signature)), "share index: %d", idx+1)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// wireRecorder returns a recorder of all external inputs if enabled, closing it on shutdown, or nil otherwise.
+func wireRecorder(ctx context.Context, life *lifecycle.Manager, conf Config) (*recorder.Recorder, error) {
+	if conf.RecordFile == "" {
+		return nil, nil //nolint:nilnil // Recording disabled.
+	}
+
+	rec, err := recorder.New(conf.RecordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "Recording external inputs for replay", z.Str("path", conf.RecordFile))
+
+	life.RegisterStop(lifecycle.StopRecorder, lifecycle.HookFuncErr(rec.Close))
+
+	return rec, nil
+}
+
+// wireRecordingProxies serves local proxies recording the responses of the beacon nodes
+// and returns their addresses to use instead.
+func wireRecordingProxies(ctx context.Context, life *lifecycle.Manager, addrs []string, rec *recorder.Recorder) ([]string, error) {
+	var resp []string
+	for _, addr := range addrs {
+		proxy, err := eth2wrap.NewRecordingProxy(addr, rec)
+		if err != nil {
+			return nil, err
+		}
+
+		localAddr, err := serveLocal(ctx, life, proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, localAddr)
+	}
+
+	return resp, nil
+}
+
+// serveLocal serves the handler on an available localhost port immediately, since beacon node
+// clients are used during wiring, and returns its address. The server is shut down with the beacon mock.
+func serveLocal(ctx context.Context, life *lifecycle.Manager, handler http.Handler) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Wrap(err, "listen local address")
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(ctx, "Local http server failed", err)
+		}
+	}()
+
+	life.RegisterStop(lifecycle.StopBeaconMock, lifecycle.HookFunc(server.Shutdown))
+
+	return "http://" + ln.Addr().String(), nil
+}
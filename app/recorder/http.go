@@ -0,0 +1,108 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+var (
+	// reqHeaders are the request headers affecting responses that are recorded.
+	// Note that authorization headers are never recorded.
+	reqHeaders = []string{"Content-Type", "Accept", "Eth-Consensus-Version"}
+	// respHeaders are the response headers that are recorded.
+	respHeaders = []string{
+		"Content-Type",
+		"Eth-Consensus-Version",
+		"Eth-Execution-Payload-Blinded",
+		"Eth-Execution-Payload-Value",
+		"Eth-Consensus-Block-Value",
+	}
+)
+
+// NewHTTPHandler returns a http handler that records the requests served by the next handler and their responses.
+// Records are timestamped when requests are received, since replayed requests may block like the recorded ones.
+// Streamed event responses are not recorded.
+func NewHTTPHandler(rec *Recorder, source Source, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received := rec.clock.Now()
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+			return
+		}
+
+		err := rec.Record(Record{
+			Time:       received,
+			Source:     source,
+			Method:     r.Method,
+			Target:     r.URL.RequestURI(),
+			Header:     subset(r.Header, reqHeaders),
+			Body:       body,
+			Status:     rw.status,
+			RespHeader: subset(w.Header(), respHeaders),
+			RespBody:   rw.body.Bytes(),
+		})
+		if err != nil {
+			log.Warn(r.Context(), "Failed recording http request", err, z.Any("source", source))
+		}
+	})
+}
+
+// subset returns the subset of non-empty headers.
+func subset(header http.Header, keys []string) map[string]string {
+	resp := make(map[string]string)
+	for _, key := range keys {
+		if val := header.Get(key); val != "" {
+			resp[key] = val
+		}
+	}
+
+	return resp
+}
+
+// responseWriter wraps a http.ResponseWriter capturing the status code and body.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		_, _ = w.body.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, required for streamed responses.
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
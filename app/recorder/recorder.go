@@ -0,0 +1,161 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package recorder provides an append-only recording of the external inputs of a node,
+// i.e. beacon node responses, validator client requests and inbound p2p messages,
+// for deterministic replay when debugging failed duties post-mortem.
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-msgio"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// maxRecordSize is the maximum uncompressed size of a record, large enough for full blocks.
+const maxRecordSize = 64 << 20
+
+// Source identifies the external source of a recorded input.
+type Source string
+
+const (
+	// SourceBN identifies beacon node http requests and their responses.
+	SourceBN Source = "bn"
+	// SourceVC identifies validator client http requests and their responses.
+	SourceVC Source = "vc"
+	// SourceP2P identifies inbound p2p messages.
+	SourceP2P Source = "p2p"
+)
+
+// Record is a single recorded external input.
+type Record struct {
+	Time   time.Time         `json:"time"`
+	Source Source            `json:"source"`
+	Method string            `json:"method,omitempty"` // HTTP method, empty for p2p.
+	Target string            `json:"target"`           // HTTP request URI or p2p protocol ID.
+	Peer   string            `json:"peer,omitempty"`   // Remote p2p peer ID, empty for http.
+	Header map[string]string `json:"header,omitempty"` // Subset of HTTP request headers.
+	Body   []byte            `json:"body,omitempty"`   // HTTP request body or p2p protobuf message.
+
+	Status     int               `json:"status,omitempty"`      // HTTP response status code.
+	RespHeader map[string]string `json:"resp_header,omitempty"` // Subset of HTTP response headers.
+	RespBody   []byte            `json:"resp_body,omitempty"`   // HTTP response body.
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithClock returns an option that timestamps records using the provided clock.
+func WithClock(clock clockwork.Clock) Option {
+	return func(r *Recorder) {
+		r.clock = clock
+	}
+}
+
+// New returns a new recorder appending records to the file at the provided path, creating it if it doesn't exist.
+func New(path string, opts ...Option) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "open record file", z.Str("path", path))
+	}
+
+	r := &Recorder{
+		file:  f,
+		w:     msgio.NewVarintWriter(f),
+		clock: clockwork.NewRealClock(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r, nil
+}
+
+// Recorder appends timestamped records to a file as length delimited snappy compressed JSON.
+// It is safe for concurrent use.
+type Recorder struct {
+	clock clockwork.Clock
+
+	mu   sync.Mutex
+	file *os.File
+	w    msgio.Writer
+}
+
+// Record timestamps the record if not already timestamped and appends it to the file.
+// Records are written immediately, so recordings survive crashes.
+func (r *Recorder) Record(rec Record) error {
+	if rec.Time.IsZero() {
+		rec.Time = r.clock.Now()
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "marshal record")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.w.WriteMsg(snappy.Encode(nil, b)); err != nil {
+		return errors.Wrap(err, "write record")
+	}
+
+	return nil
+}
+
+// Close closes the record file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Close(); err != nil {
+		return errors.Wrap(err, "close record file")
+	}
+
+	return nil
+}
+
+// ReadFile returns all records in the file at the provided path in the order they were recorded.
+// A truncated last record, e.g. due to a crash while recording, is ignored.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open record file", z.Str("path", path))
+	}
+	defer f.Close()
+
+	reader := msgio.NewVarintReaderSize(bufio.NewReader(f), snappy.MaxEncodedLen(maxRecordSize))
+
+	var resp []Record
+	for {
+		compressed, err := reader.ReadMsg()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return resp, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read record", z.Int("index", len(resp)))
+		}
+
+		b, err := snappy.Decode(nil, compressed)
+		reader.ReleaseMsg(compressed)
+		if err != nil {
+			return nil, errors.Wrap(err, "decompress record", z.Int("index", len(resp)))
+		}
+
+		var rec Record
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, errors.Wrap(err, "unmarshal record", z.Int("index", len(resp)))
+		}
+
+		resp = append(resp, rec)
+	}
+}
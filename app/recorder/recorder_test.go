@@ -0,0 +1,147 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package recorder_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/recorder"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "record")
+	clock := clockwork.NewFakeClockAt(time.Unix(1700000000, 0))
+
+	rec, err := recorder.New(path, recorder.WithClock(clock))
+	require.NoError(t, err)
+
+	require.NoError(t, rec.Record(recorder.Record{Source: recorder.SourceP2P, Target: "/charon/test", Peer: "peer", Body: []byte{1}}))
+	clock.Advance(time.Second)
+	require.NoError(t, rec.Record(recorder.Record{Source: recorder.SourceVC, Method: http.MethodGet, Target: "/eth/v1/node/version", Status: http.StatusOK}))
+	require.NoError(t, rec.Close())
+
+	// Records are appended to existing files.
+	rec, err = recorder.New(path, recorder.WithClock(clock))
+	require.NoError(t, err)
+	require.NoError(t, rec.Record(recorder.Record{Source: recorder.SourceBN, Method: http.MethodGet, Target: "/eth/v1/beacon/genesis"}))
+	require.NoError(t, rec.Close())
+
+	records, err := recorder.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, recorder.SourceP2P, records[0].Source)
+	require.Equal(t, []byte{1}, records[0].Body)
+	require.Equal(t, time.Second, records[1].Time.Sub(records[0].Time))
+	require.Equal(t, recorder.SourceBN, records[2].Source)
+
+	// A truncated last record is ignored.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	records, err = recorder.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+}
+
+func TestHTTPRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "record")
+
+	clock := clockwork.NewFakeClock()
+	rec, err := recorder.New(path, recorder.WithClock(clock))
+	require.NoError(t, err)
+
+	// Record responses of a beacon node returning an incrementing count.
+	var count int
+	bn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":` + strconv.Itoa(count) + `,"body":"` + string(body) + `"}`))
+	})
+
+	srv := httptest.NewServer(recorder.NewHTTPHandler(rec, recorder.SourceBN, bn))
+	defer srv.Close()
+
+	do := func(t *testing.T, url string, method string, body string) (int, string) {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+		require.NoError(t, err)
+
+		resp, err := new(http.Client).Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(b)
+	}
+
+	_, resp := do(t, srv.URL+"/eth/v1/node/syncing", http.MethodGet, "")
+	require.JSONEq(t, `{"count":1,"body":""}`, resp)
+	_, resp = do(t, srv.URL+"/eth/v1/node/syncing", http.MethodGet, "")
+	require.JSONEq(t, `{"count":2,"body":""}`, resp)
+	_, resp = do(t, srv.URL+"/eth/v1/beacon/pool/attestations", http.MethodPost, "att")
+	require.JSONEq(t, `{"count":3,"body":"att"}`, resp)
+	require.NoError(t, rec.Close())
+
+	records, err := recorder.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, http.MethodPost, records[2].Method)
+	require.Equal(t, []byte("att"), records[2].Body)
+	require.Equal(t, "application/json", records[2].RespHeader["Content-Type"])
+
+	// Replay the recorded responses in order, repeating the last.
+	replay := recorder.NewBeaconServer(records, clock)
+	replaySrv := httptest.NewServer(replay)
+	defer replaySrv.Close()
+
+	for _, expect := range []int{1, 2, 2} {
+		_, resp = do(t, replaySrv.URL+"/eth/v1/node/syncing", http.MethodGet, "")
+		require.JSONEq(t, `{"count":`+strconv.Itoa(expect)+`,"body":""}`, resp)
+	}
+
+	matched, missing, unexpected := replay.Outcome()
+	require.Zero(t, matched)
+	require.Equal(t, []string{"/eth/v1/beacon/pool/attestations"}, missing)
+	require.Empty(t, unexpected)
+
+	status, _ := do(t, replaySrv.URL+"/eth/v1/beacon/pool/attestations", http.MethodPost, "other")
+	require.Equal(t, http.StatusNotFound, status)
+	_, resp = do(t, replaySrv.URL+"/eth/v1/beacon/pool/attestations", http.MethodPost, "att")
+	require.JSONEq(t, `{"count":3,"body":"att"}`, resp)
+
+	matched, missing, unexpected = replay.Outcome()
+	require.Equal(t, 1, matched)
+	require.Empty(t, missing)
+	require.Equal(t, []string{"/eth/v1/beacon/pool/attestations"}, unexpected)
+
+	// Unmatched queries aren't submissions.
+	status, _ = do(t, replaySrv.URL+"/eth/v1/validator/duties/attester/1", http.MethodPost, "[]")
+	require.Equal(t, http.StatusNotFound, status)
+
+	// Unmatched submissions after the last record are ignored.
+	clock.Advance(time.Second)
+	status, _ = do(t, replaySrv.URL+"/eth/v1/beacon/pool/attestations", http.MethodPost, "later")
+	require.Equal(t, http.StatusNotFound, status)
+
+	_, _, unexpected = replay.Outcome()
+	require.Len(t, unexpected, 1)
+}
@@ -0,0 +1,186 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// queryPrefixes are the targets of POST requests that query the beacon node instead of submitting to it.
+var queryPrefixes = []string{
+	"/eth/v1/beacon/states/",
+	"/eth/v1/validator/duties/",
+	"/eth/v1/validator/liveness/",
+	"/eth/v1/validator/beacon_committee_selections",
+	"/eth/v1/validator/sync_committee_selections",
+}
+
+// NewBeaconServer returns a http handler that replays the recorded beacon node responses.
+// The clock must be the clock the replayed node runs on.
+func NewBeaconServer(records []Record, clock clockwork.Clock) *BeaconServer {
+	s := &BeaconServer{
+		clock:     clock,
+		responses: make(map[string][]Record),
+		served:    make(map[string]int),
+		posts:     make(map[string]int),
+	}
+
+	for _, rec := range records {
+		if rec.Time.After(s.end) {
+			s.end = rec.Time
+		}
+
+		if rec.Source != SourceBN {
+			continue
+		}
+
+		key := requestKey(rec.Method, rec.Target, rec.Body)
+		s.responses[key] = append(s.responses[key], rec)
+	}
+
+	return s
+}
+
+// BeaconServer replays recorded beacon node responses. Requests are matched by method, target and body,
+// with identical requests served the recorded responses in order, repeating the last once exhausted.
+// It tracks the submissions, i.e. POST requests not querying the beacon node, received to compare them
+// with the recorded ones. Unmatched submissions received after the last record are ignored, since the
+// inputs causing them weren't recorded.
+type BeaconServer struct {
+	clock clockwork.Clock
+	end   time.Time // Time of the last record.
+
+	mu        sync.Mutex
+	responses map[string][]Record
+	served    map[string]int
+	posts     map[string]int // Unmatched POST requests by target.
+}
+
+// ServeHTTP implements http.Handler.
+func (s *BeaconServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "read request body", http.StatusBadRequest)
+		return
+	}
+
+	rec, ok := s.next(r.Method, r.URL.RequestURI(), body)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"message":"request not recorded"}`))
+
+		return
+	}
+
+	for key, val := range rec.RespHeader {
+		w.Header().Set(key, val)
+	}
+	w.WriteHeader(rec.Status)
+	_, _ = w.Write(rec.RespBody)
+}
+
+// next returns the next recorded response for the request.
+func (s *BeaconServer) next(method, target string, body []byte) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := requestKey(method, target, body)
+
+	recs, ok := s.responses[key]
+	if !ok {
+		if isSubmission(method, target) && !s.clock.Now().After(s.end) {
+			s.posts[target]++
+		}
+
+		return Record{}, false
+	}
+
+	idx := min(s.served[key], len(recs)-1)
+	s.served[key]++
+
+	return recs[idx], true
+}
+
+// Outcome compares the submissions received by the server with the recorded ones.
+// It returns the number of matching submissions, and the targets of missing and unexpected submissions.
+func (s *BeaconServer) Outcome() (int, []string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		matched    int
+		missing    []string
+		unexpected []string
+	)
+	for key, recs := range s.responses {
+		if !isSubmission(recs[0].Method, recs[0].Target) {
+			continue
+		}
+
+		matched += min(s.served[key], len(recs))
+		for range len(recs) - s.served[key] {
+			missing = append(missing, recs[0].Target)
+		}
+	}
+
+	for target, count := range s.posts {
+		for range count {
+			unexpected = append(unexpected, target)
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(unexpected)
+
+	return matched, missing, unexpected
+}
+
+// NewRequest returns a http request to the base URL replaying the recorded http request.
+func NewRequest(ctx context.Context, baseURL string, rec Record) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, rec.Method, baseURL+rec.Target, bytes.NewReader(rec.Body))
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+
+	for key, val := range rec.Header {
+		req.Header.Set(key, val)
+	}
+
+	return req, nil
+}
+
+// isSubmission returns true if the http request submits data to the beacon node.
+func isSubmission(method, target string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+
+	for _, prefix := range queryPrefixes {
+		if strings.HasPrefix(target, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestKey returns the key identifying a http request by method, target and body.
+func requestKey(method, target string, body []byte) string {
+	hash := sha256.Sum256(body)
+
+	return method + " " + target + " " + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,260 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-libp2p/core/host"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus"
+	"github.com/obolnetwork/charon/core/validatorapi"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// replayGracePeriod is the period after the last record allowing duties in progress to complete.
+	replayGracePeriod = 30 * time.Second
+	// replayStep is the period the fake clock is advanced by at once.
+	replayStep = 100 * time.Millisecond
+	// replaySettle is the real time the node is given to process each step of the fake clock and each delivered record.
+	replaySettle = 5 * time.Millisecond
+)
+
+// Replay re-runs the core workflow of the node against the external inputs recorded in the file.
+// Recorded beacon node responses are served by a local mock beacon node, recorded validator client
+// requests are sent to the validator API and recorded inbound p2p messages are delivered to the
+// registered p2p handlers, all in recorded order at their recorded time.
+//
+// The scheduler, deadliners, consensus round timers and retryer run on a fake clock starting at the
+// time of the first record, which is advanced in steps faster than real time, so the recorded slots
+// are reproduced without waiting for them. It returns an error if the submissions to the beacon node
+// differ from the recorded ones.
+func Replay(ctx context.Context, conf Config, recordFile string) error {
+	ctx = log.WithTopic(ctx, "replay")
+
+	if err := featureset.Init(ctx, conf.Feature); err != nil {
+		return err
+	}
+
+	records, err := recorder.ReadFile(recordFile)
+	if err != nil {
+		return err
+	} else if len(records) == 0 {
+		return errors.New("no records in file", z.Str("path", recordFile))
+	}
+
+	// Records are appended once complete, so sort them by the time they were received, retaining the recorded order.
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	clock := clockwork.NewFakeClockAt(records[0].Time)
+
+	cluster, err := loadClusterManifest(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	p2pKey := conf.TestConfig.P2PKey
+	if p2pKey == nil {
+		p2pKey, err = k1util.Load(conf.PrivKeyFile)
+		if err != nil {
+			return errors.Wrap(err, "load priv key")
+		}
+	}
+
+	peerIDs, err := manifest.ClusterPeerIDs(cluster)
+	if err != nil {
+		return err
+	}
+
+	// The node doesn't connect to peers, recorded inbound messages are delivered directly to the p2p handlers.
+	connGater, err := p2p.NewConnGater(peerIDs, nil)
+	if err != nil {
+		return err
+	}
+
+	tcpNode, err := p2p.NewTCPNode(ctx, p2p.Config{}, p2pKey, connGater, false)
+	if err != nil {
+		return err
+	}
+	p2p.EnableReplay(tcpNode)
+
+	nodeIdx, err := manifest.ClusterNodeIdx(cluster, tcpNode.ID())
+	if err != nil {
+		return errors.Wrap(err, "private key not matching cluster manifest file")
+	}
+
+	life := new(lifecycle.Manager)
+	life.RegisterStop(lifecycle.StopP2PTCPNode, lifecycle.HookFuncErr(tcpNode.Close))
+
+	bmock := recorder.NewBeaconServer(records, clock)
+	bnAddr, err := serveLocal(ctx, life, bmock)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := configureEth2Client(ctx, cluster.GetForkVersion(), []string{bnAddr}, conf.BeaconNodeTimeout, nil)
+	if err != nil {
+		return err
+	}
+
+	// Replay validator client requests on an available localhost port without authentication,
+	// since authorization headers are not recorded.
+	conf.ValidatorAPIAddr, err = availableLocalAddr()
+	if err != nil {
+		return err
+	}
+	conf.ValidatorAPITLS = validatorapi.TLSConfig{}
+	conf.ValidatorAPITokensFile = ""
+
+	// Replay must not alter the state persisted by the node, nor create its own inputs.
+	conf.SignatureDBSnapshotDir = ""
+	conf.BuilderRegistrationFile = ""
+	conf.SimnetVMock = false
+
+	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl, eth2Cl,
+		peerIDs, new(p2p.Sender), consensus.NewDebugger(), func(core.PubKey) {}, func() {}, nil, clock)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartReplay, lifecycle.HookFuncCtx(func(ctx context.Context) {
+		defer cancel()
+
+		deliver := func(ctx context.Context, rec recorder.Record) {
+			if err := deliverRecord(ctx, tcpNode, "http://"+conf.ValidatorAPIAddr, rec); err != nil {
+				log.Warn(ctx, "Failed replaying record", err, z.Any("source", rec.Source), z.Str("target", rec.Target))
+			}
+		}
+
+		replayRecords(ctx, clock, records, deliver)
+
+		log.Info(ctx, "All records replayed, waiting for duties in progress", z.Int("records", len(records)))
+
+		advanceReplayClock(ctx, clock, clock.Now().Add(replayGracePeriod))
+	}))
+
+	log.Info(ctx, "Replaying recorded inputs",
+		z.Int("records", len(records)),
+		z.Any("recorded_at", records[0].Time))
+
+	if err := life.Run(ctx); err != nil {
+		return err
+	}
+
+	matched, missing, unexpected := bmock.Outcome()
+	log.Info(ctx, "Replay completed", z.Int("matched_submissions", matched),
+		z.Int("missing_submissions", len(missing)), z.Int("unexpected_submissions", len(unexpected)))
+
+	if len(missing) > 0 || len(unexpected) > 0 {
+		return errors.New("replayed submissions differ from recording",
+			z.Any("missing", missing), z.Any("unexpected", unexpected))
+	}
+
+	return nil
+}
+
+// replayRecords calls the deliver function with each non-beacon node record in order, once the fake clock
+// is advanced to its recorded time. Beacon node records are served on request instead.
+// Records are delivered asynchronously, since validator client requests block until duty data is available,
+// but the next record is only delivered once the previous completed or was given time to settle.
+func replayRecords(ctx context.Context, clock clockwork.FakeClock, records []recorder.Record,
+	deliver func(context.Context, recorder.Record),
+) {
+	for _, rec := range records {
+		if rec.Source == recorder.SourceBN {
+			continue
+		}
+
+		if !advanceReplayClock(ctx, clock, rec.Time) {
+			return
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			deliver(ctx, rec)
+		}()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+		case <-time.After(replaySettle):
+		}
+	}
+}
+
+// advanceReplayClock advances the fake clock in steps to the provided time, giving the node time to process each step.
+// It returns false if the context is closed.
+func advanceReplayClock(ctx context.Context, clock clockwork.FakeClock, to time.Time) bool {
+	for clock.Now().Before(to) {
+		clock.Advance(min(replayStep, to.Sub(clock.Now())))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(replaySettle):
+		}
+	}
+
+	return ctx.Err() == nil
+}
+
+// deliverRecord replays the recorded validator client request or inbound p2p message.
+func deliverRecord(ctx context.Context, tcpNode host.Host, vapiURL string, rec recorder.Record) error {
+	switch rec.Source {
+	case recorder.SourceP2P:
+		return p2p.Replay(ctx, tcpNode, rec)
+	case recorder.SourceVC:
+		req, err := recorder.NewRequest(ctx, vapiURL, rec)
+		if err != nil {
+			return err
+		}
+
+		resp, err := new(http.Client).Do(req)
+		if err != nil {
+			return errors.Wrap(err, "replay validator client request")
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != rec.Status {
+			log.Debug(ctx, "Replayed validator client response status differs from recording",
+				z.Str("target", rec.Target), z.Int("recorded", rec.Status), z.Int("replayed", resp.StatusCode))
+		}
+
+		return nil
+	default:
+		return errors.New("unsupported record source", z.Any("source", rec.Source))
+	}
+}
+
+// availableLocalAddr returns an available localhost address.
+func availableLocalAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Wrap(err, "listen local address")
+	}
+	defer ln.Close()
+
+	return ln.Addr().String(), nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/recorder"
+)
+
+func TestReplayRecords(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recorded := time.Unix(1700000000, 0)
+	clock := clockwork.NewFakeClockAt(recorded)
+
+	records := []recorder.Record{
+		{Time: recorded.Add(time.Second), Source: recorder.SourceVC, Target: "vc1"},
+		{Time: recorded.Add(2 * time.Second), Source: recorder.SourceBN, Target: "bn"},
+		{Time: recorded.Add(3 * time.Second), Source: recorder.SourceP2P, Target: "p2p"},
+		{Time: recorded.Add(3 * time.Second), Source: recorder.SourceVC, Target: "vc2"},
+	}
+
+	type delivery struct {
+		Target string
+		Time   time.Time
+	}
+
+	var (
+		mu        sync.Mutex
+		delivered []delivery
+	)
+	replayRecords(ctx, clock, records, func(ctx context.Context, rec recorder.Record) {
+		mu.Lock()
+		delivered = append(delivered, delivery{Target: rec.Target, Time: clock.Now()})
+		mu.Unlock()
+
+		if rec.Target == "vc1" {
+			<-ctx.Done() // Blocking requests don't block subsequent records.
+		}
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Beacon node records are not delivered, others are delivered in order at their recorded time.
+	require.Equal(t, []delivery{
+		{Target: "vc1", Time: records[0].Time},
+		{Target: "p2p", Time: records[2].Time},
+		{Target: "vc2", Time: records[3].Time},
+	}, delivered)
+}
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
@@ -47,6 +48,36 @@ func New[T any](timeoutFunc func(T) (time.Time, bool)) *Retryer[T] {
 	return newInternal(ctxTimeoutFunc, backoffProvider)
 }
 
+// NewWithClock returns a new Retryer instance using the provided clock for deadlines and backoff,
+// e.g. to replay recordings. Note that contexts are cancelled when deadlines elapse.
+func NewWithClock[T any](timeoutFunc func(T) (time.Time, bool), clock clockwork.Clock) *Retryer[T] {
+	ctxTimeoutFunc := func(ctx context.Context, t T) (context.Context, context.CancelFunc) {
+		timeout, ok := timeoutFunc(t)
+		if !ok {
+			return ctx, func() {}
+		}
+
+		ctx, cancel := context.WithCancelCause(ctx)
+		timer := clock.AfterFunc(timeout.Sub(clock.Now()), func() {
+			cancel(context.DeadlineExceeded)
+		})
+
+		return ctx, func() {
+			timer.Stop()
+			cancel(context.Canceled)
+		}
+	}
+
+	backoffProvider := func() func() <-chan time.Time {
+		return func() <-chan time.Time {
+			const backoff = time.Second
+			return clock.After(backoff)
+		}
+	}
+
+	return newInternal(ctxTimeoutFunc, backoffProvider)
+}
+
 // NewForT returns a new Retryer instance for testing supporting a custom clock.
 func NewForT[T any](
 	_ *testing.T,
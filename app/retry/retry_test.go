@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -149,3 +150,41 @@ func TestShutdown(t *testing.T) {
 	close(stop)
 	<-done
 }
+
+func TestRetryerWithClock(t *testing.T) {
+	ctx := context.Background()
+	clock := clockwork.NewFakeClock()
+	deadline := clock.Now().Add(1500 * time.Millisecond)
+
+	retryer := retry.NewWithClock(func(core.Duty) (time.Time, bool) {
+		return deadline, true
+	}, clock)
+
+	var (
+		attempts = make(chan struct{}, 3)
+		done     = make(chan struct{})
+		asyncCtx context.Context
+	)
+	go func() {
+		defer close(done)
+		retryer.DoAsync(ctx, core.NewAttesterDuty(999), "test", "test", func(ctx context.Context) error {
+			asyncCtx = ctx
+			attempts <- struct{}{}
+
+			return context.Canceled
+		})
+	}()
+
+	// Backoff and deadline are driven by the fake clock.
+	<-attempts
+	clock.BlockUntil(2) // Deadline and backoff timers.
+	clock.Advance(time.Second)
+	<-attempts
+	clock.BlockUntil(2)
+	clock.Advance(500 * time.Millisecond)
+
+	// The deadline elapsed during backoff, so no more attempts.
+	<-done
+	require.Empty(t, attempts)
+	require.ErrorIs(t, context.Cause(asyncCtx), context.DeadlineExceeded)
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// snapshotDB is a signature database that can be snapshotted and restored.
+type snapshotDB interface {
+	Snapshot(ctx context.Context) (*pbv1.SignedDataSnapshot, error)
+	Restore(snapshot *pbv1.SignedDataSnapshot) error
+}
+
+// wireSnapshots restores the signature databases from the snapshots in the directory and
+// registers stop hooks that snapshot them to the directory on graceful shutdown.
+// This ensures duties in progress, e.g. randao reveals and selection proofs, survive restarts mid-epoch.
+func wireSnapshots(ctx context.Context, life *lifecycle.Manager, dir string, dbs map[string]snapshotDB) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "create snapshot dir")
+	}
+
+	for name, db := range dbs {
+		path := filepath.Join(dir, name+".pb")
+
+		snapshot, err := loadSnapshot(path)
+		if err != nil {
+			return err
+		}
+
+		if err := db.Restore(snapshot); err != nil {
+			return errors.Wrap(err, "restore snapshot", z.Str("path", path))
+		}
+
+		if len(snapshot.GetEntries()) > 0 {
+			log.Info(ctx, "Restored signature database snapshot", z.Str("db", name), z.Int("entries", len(snapshot.GetEntries())))
+		}
+
+		life.RegisterStop(lifecycle.StopSignatureDBs, lifecycle.HookFunc(func(ctx context.Context) error {
+			snapshot, err := db.Snapshot(ctx)
+			if err != nil {
+				return errors.Wrap(err, "snapshot signature database", z.Str("db", name))
+			}
+
+			return storeSnapshot(path, snapshot)
+		}))
+	}
+
+	return nil
+}
+
+// loadSnapshot returns the snapshot stored in the file or an empty snapshot if the file doesn't exist.
+func loadSnapshot(path string) (*pbv1.SignedDataSnapshot, error) {
+	resp := new(pbv1.SignedDataSnapshot)
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return resp, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read snapshot file", z.Str("path", path))
+	}
+
+	if err := proto.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal snapshot file", z.Str("path", path))
+	}
+
+	return resp, nil
+}
+
+// storeSnapshot atomically persists the snapshot to the file.
+func storeSnapshot(path string, snapshot *pbv1.SignedDataSnapshot) error {
+	b, err := proto.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "marshal snapshot")
+	}
+
+	// Write to a temporary file first and then rename it, so a crash never leaves a corrupt file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return errors.Wrap(err, "write snapshot file")
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(err, "rename snapshot file")
+	}
+
+	return nil
+}
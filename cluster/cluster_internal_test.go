@@ -3,15 +3,22 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/eth1mock"
 )
 
 func TestDefinitionVerify(t *testing.T) {
@@ -204,6 +211,71 @@ func TestDefinitionVerify(t *testing.T) {
 	})
 }
 
+func TestDefinitionVerifyContractSigs(t *testing.T) {
+	secret0, op0 := randomOperator(t)
+	secret1, op1 := randomOperator(t)
+	secret2, creator := randomCreator(t)
+
+	ctx := context.Background()
+	eth1 := eth1mock.New(eth2util.Sepolia.ChainID)
+	defer eth1.Close()
+
+	// Operator 0 is a 2-of-2 multisig wallet owned by secret0 and secretOwner.
+	secretOwner, owner := randomOperator(t)
+	multisig := testutil.RandomETHAddress()
+	eth1.AddMultisig(multisig, 2, op0.Address, owner.Address)
+	op0.Address = multisig
+
+	def := randomDefinition(t, creator, op0, op1)
+
+	def, err := signCreator(secret2, def)
+	require.NoError(t, err)
+
+	sig0, err := signOperator(secret0, def, op0)
+	require.NoError(t, err)
+	sigOwner, err := signOperator(secretOwner, def, op0)
+	require.NoError(t, err)
+
+	def.Operators[0].ConfigSignature = append(sig0.ConfigSignature, sigOwner.ConfigSignature...)
+	def.Operators[0].ENRSignature = append(sig0.ENRSignature, sigOwner.ENRSignature...)
+
+	def.Operators[1], err = signOperator(secret1, def, op1)
+	require.NoError(t, err)
+
+	t.Run("valid contract signatures", func(t *testing.T) {
+		require.NoError(t, def.VerifySignatures(WithContractSigVerifier(ctx, eth1wrap.NewClient(eth1.Address()))))
+	})
+
+	t.Run("no contract verifier", func(t *testing.T) {
+		require.ErrorContains(t, def.VerifySignatures(), "verifying ERC-1271 smart contract signatures requires --execution-client-rpc-endpoint")
+	})
+
+	t.Run("below multisig threshold", func(t *testing.T) {
+		def := def
+		def.Operators = slices.Clone(def.Operators)
+		def.Operators[0].ConfigSignature = sig0.ConfigSignature
+
+		err := def.VerifySignatures(WithContractSigVerifier(ctx, eth1wrap.NewClient(eth1.Address())))
+		require.ErrorContains(t, err, "invalid operator config signature")
+	})
+
+	t.Run("rpc unavailable", func(t *testing.T) {
+		unavailable := eth1mock.New(eth2util.Sepolia.ChainID)
+		unavailable.Close()
+
+		err := def.VerifySignatures(WithContractSigVerifier(ctx, eth1wrap.NewClient(unavailable.Address())))
+		require.ErrorContains(t, err, "execution client rpc unavailable")
+	})
+
+	t.Run("chain id mismatch", func(t *testing.T) {
+		mainnet := eth1mock.New(eth2util.Mainnet.ChainID)
+		defer mainnet.Close()
+
+		err := def.VerifySignatures(WithContractSigVerifier(ctx, eth1wrap.NewClient(mainnet.Address())))
+		require.ErrorContains(t, err, "execution client chain id mismatch")
+	})
+}
+
 // randomOperator returns a random ETH1 private key and populated creator struct (excluding config signature).
 func randomCreator(t *testing.T) (*k1.PrivateKey, Creator) {
 	t.Helper()
@@ -282,3 +354,53 @@ func TestSupportPartialDeposits(t *testing.T) {
 	require.True(t, supportPartialDeposits(MinVersionForPartialDeposits))
 	require.False(t, supportPartialDeposits(v1_7))
 }
+
+func TestSupportCompounding(t *testing.T) {
+	require.True(t, supportCompounding(MinVersionForCompounding))
+	require.False(t, supportCompounding(v1_9))
+}
+
+func TestVerifyDepositData(t *testing.T) {
+	seed := 0
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, shares := NewForT(t, 1, 3, 4, seed, random, WithVersion(v1_10), WithCompounding([]bool{true}))
+	require.True(t, lock.ValidatorAddresses[0].Compounding)
+	require.NoError(t, lock.VerifySignatures())
+
+	// Deposit data with '0x01' credentials doesn't match compounding validators.
+	creds, err := deposit.WithdrawalCredsFromAddr(lock.ValidatorAddresses[0].WithdrawalAddress, false)
+	require.NoError(t, err)
+
+	lock.Validators[0].PartialDepositData = []DepositData{{
+		PubKey:                lock.Validators[0].PubKey,
+		WithdrawalCredentials: creds[:],
+		Amount:                int(deposit.MaxCompoundingDepositAmount),
+		Signature:             testutil.RandomBytes96(),
+	}}
+	require.ErrorContains(t, lock.verifyDepositData(), "deposit data withdrawal credentials mismatch")
+
+	creds, err = deposit.WithdrawalCredsFromAddr(lock.ValidatorAddresses[0].WithdrawalAddress, true)
+	require.NoError(t, err)
+
+	lock.Validators[0].PartialDepositData[0].WithdrawalCredentials = creds[:]
+	require.ErrorContains(t, lock.verifyDepositData(), "verify deposit data signature")
+
+	// Deposit data of custom networks is verified using the lock's fork version.
+	lock.ForkVersion = []byte{0x10, 0x00, 0x00, 0x38}
+
+	secret, err := tbls.RecoverSecret(map[int]tbls.PrivateKey{1: shares[0][0], 2: shares[0][1], 3: shares[0][2]}, 4, 3)
+	require.NoError(t, err)
+
+	msg, err := deposit.NewMessage(eth2p0.BLSPubKey(lock.Validators[0].PubKey),
+		lock.ValidatorAddresses[0].WithdrawalAddress, deposit.MaxCompoundingDepositAmount, true)
+	require.NoError(t, err)
+
+	sigRoot, err := deposit.GetMessageSigningRootByForkVersion(msg, eth2p0.Version(lock.ForkVersion))
+	require.NoError(t, err)
+
+	sig, err := tbls.Sign(secret, sigRoot[:])
+	require.NoError(t, err)
+
+	lock.Validators[0].PartialDepositData[0].Signature = sig[:]
+	require.NoError(t, lock.verifyDepositData())
+}
@@ -4,6 +4,7 @@ package cluster
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"time"
@@ -60,6 +61,18 @@ func WithLegacyVAddrs(feeRecipientAddress, withdrawalAddress string) func(*Defin
 	}
 }
 
+// WithCompounding returns an option to enable compounding ('0x02') withdrawal credentials per validator.
+// This requires definition version v1.10 or later.
+func WithCompounding(compounding []bool) func(*Definition) {
+	return func(d *Definition) {
+		for i := range d.ValidatorAddresses {
+			if i < len(compounding) {
+				d.ValidatorAddresses[i].Compounding = compounding[i]
+			}
+		}
+	}
+}
+
 // NewDefinition returns a new definition populated with the latest version, timestamp and UUID.
 // The hashes are also populated accordingly. Note that the hashes need to be recalculated when any field is modified.
 func NewDefinition(name string, numVals int, threshold int, feeRecipientAddresses []string, withdrawalAddresses []string,
@@ -109,6 +122,10 @@ func NewDefinition(name string, numVals int, threshold int, feeRecipientAddresse
 		return Definition{}, errors.New("the version does not support partial deposits", z.Str("version", def.Version))
 	}
 
+	if def.Compounding() && !supportCompounding(def.Version) {
+		return Definition{}, errors.New("the version does not support compounding validators", z.Str("version", def.Version))
+	}
+
 	return def.SetDefinitionHashes()
 }
 
@@ -188,8 +205,38 @@ func (d Definition) NodeIdx(pID peer.ID) (NodeIdx, error) {
 	return NodeIdx{}, errors.New("peer not in definition")
 }
 
+// ContractSigVerifier verifies ERC-1271 smart contract signatures, e.g., of multisig wallet operators.
+type ContractSigVerifier interface {
+	// VerifySmartContractBasedSignature returns true if the contract at the address on the chain with the
+	// provided chain ID considers sig a valid signature of hash.
+	VerifySmartContractBasedSignature(ctx context.Context, chainID uint64, contractAddress string, hash [32]byte, sig []byte) (bool, error)
+}
+
+// VerifyOption configures signature verification.
+type VerifyOption func(*verifyConfig)
+
+type verifyConfig struct {
+	ctx              context.Context
+	contractVerifier ContractSigVerifier
+}
+
+// WithContractSigVerifier returns an option enabling verification of ERC-1271 smart contract
+// signatures of creators and operators using the provided verifier and context.
+func WithContractSigVerifier(ctx context.Context, verifier ContractSigVerifier) VerifyOption {
+	return func(conf *verifyConfig) {
+		conf.ctx = ctx
+		conf.contractVerifier = verifier
+	}
+}
+
 // VerifySignatures returns nil if all config signatures are fully populated and valid. A verified definition is ready for use in DKG.
-func (d Definition) VerifySignatures() error {
+// Only secp256k1 signatures of externally owned accounts are supported unless a contract signature verifier is provided.
+func (d Definition) VerifySignatures(opts ...VerifyOption) error {
+	var conf verifyConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
 	// Skip signature verification for definition versions earlier than v1.3 since there are no EIP712 signatures before v1.3.0.
 	if !supportEIP712Sigs(d.Version) && !eip712SigsPresent(d.Operators) {
 		return nil
@@ -222,7 +269,7 @@ func (d Definition) VerifySignatures() error {
 			return errors.New("empty operator config signature", z.Any("operator_address", o.Address))
 		}
 
-		if ok, err := verifySig(o.Address, operatorConfigHashDigest, o.ConfigSignature); err != nil {
+		if ok, err := verifySig(conf, d.ForkVersion, o.Address, operatorConfigHashDigest, o.ConfigSignature); err != nil {
 			return err
 		} else if !ok {
 			return errors.New("invalid operator config signature", z.Any("operator_address", o.Address))
@@ -234,7 +281,7 @@ func (d Definition) VerifySignatures() error {
 			return err
 		}
 
-		if ok, err := verifySig(o.Address, enrDigest, o.ENRSignature); err != nil {
+		if ok, err := verifySig(conf, d.ForkVersion, o.Address, enrDigest, o.ENRSignature); err != nil {
 			return err
 		} else if !ok {
 			return errors.New("invalid operator enr signature", z.Any("operator_address", o.Address))
@@ -266,7 +313,7 @@ func (d Definition) VerifySignatures() error {
 			return err
 		}
 
-		if ok, err := verifySig(d.Creator.Address, creatorConfigHashDigest, d.Creator.ConfigSignature); err != nil {
+		if ok, err := verifySig(conf, d.ForkVersion, d.Creator.Address, creatorConfigHashDigest, d.Creator.ConfigSignature); err != nil {
 			return err
 		} else if !ok {
 			return errors.New("invalid creator config signature")
@@ -331,6 +378,33 @@ func (d Definition) LegacyValidatorAddresses() (ValidatorAddresses, error) {
 	return resp, nil
 }
 
+// Compounding returns true if any validator uses compounding ('0x02') withdrawal credentials.
+func (d Definition) Compounding() bool {
+	for _, vaddrs := range d.ValidatorAddresses {
+		if vaddrs.Compounding {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VerifyDepositAmounts returns an error if the deposit amounts are invalid for any of the validators.
+// Compounding validators allow deposit amounts summing up to 2048ETH.
+func (d Definition) VerifyDepositAmounts() error {
+	if len(d.ValidatorAddresses) == 0 {
+		return deposit.VerifyDepositAmounts(d.DepositAmounts, false)
+	}
+
+	for _, vaddrs := range d.ValidatorAddresses {
+		if err := deposit.VerifyDepositAmounts(d.DepositAmounts, vaddrs.Compounding); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // WithdrawalAddresses is a convenience function to return all withdrawal address from the validator addresses slice.
 func (d Definition) WithdrawalAddresses() []string {
 	var resp []string
@@ -392,6 +466,8 @@ func (d Definition) MarshalJSON() ([]byte, error) {
 		return marshalDefinitionV1x8(d2)
 	case isAnyVersion(d2.Version, v1_9):
 		return marshalDefinitionV1x9(d2)
+	case isAnyVersion(d2.Version, v1_10):
+		return marshalDefinitionV1x10(d2)
 	default:
 		return nil, errors.New("unsupported version")
 	}
@@ -446,6 +522,11 @@ func (d *Definition) UnmarshalJSON(data []byte) error {
 		if err != nil {
 			return err
 		}
+	case isAnyVersion(version.Version, v1_10):
+		def, err = unmarshalDefinitionV1x10(data)
+		if err != nil {
+			return err
+		}
 	default:
 		return errors.New("unsupported version")
 	}
@@ -648,6 +729,34 @@ func marshalDefinitionV1x9(def Definition) ([]byte, error) {
 	return resp, nil
 }
 
+func marshalDefinitionV1x10(def Definition) ([]byte, error) {
+	resp, err := json.Marshal(definitionJSONv1x10{
+		Name:               def.Name,
+		UUID:               def.UUID,
+		Version:            def.Version,
+		Timestamp:          def.Timestamp,
+		NumValidators:      def.NumValidators,
+		Threshold:          def.Threshold,
+		DKGAlgorithm:       def.DKGAlgorithm,
+		ValidatorAddresses: validatorAddressesToJSONv1x10(def.ValidatorAddresses),
+		ForkVersion:        def.ForkVersion,
+		ConfigHash:         def.ConfigHash,
+		DefinitionHash:     def.DefinitionHash,
+		Operators:          operatorsToV1x2orLater(def.Operators),
+		Creator: creatorJSON{
+			Address:         def.Creator.Address,
+			ConfigSignature: def.Creator.ConfigSignature,
+		},
+		DepositAmounts:    def.DepositAmounts,
+		ConsensusProtocol: def.ConsensusProtocol,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal definition", z.Str("version", def.Version))
+	}
+
+	return resp, nil
+}
+
 func unmarshalDefinitionV1x0or1(data []byte) (def Definition, err error) {
 	var defJSON definitionJSONv1x0or1
 	if err := json.Unmarshal(data, &defJSON); err != nil {
@@ -786,7 +895,7 @@ func unmarshalDefinitionV1x8(data []byte) (def Definition, err error) {
 		return Definition{}, errors.New("num_validators not matching validators length")
 	}
 
-	if err := deposit.VerifyDepositAmounts(def.DepositAmounts); err != nil {
+	if err := deposit.VerifyDepositAmounts(def.DepositAmounts, false); err != nil {
 		return Definition{}, errors.Wrap(err, "invalid deposit amounts")
 	}
 
@@ -821,7 +930,7 @@ func unmarshalDefinitionV1x9(data []byte) (def Definition, err error) {
 		return Definition{}, errors.New("num_validators not matching validators length")
 	}
 
-	if err := deposit.VerifyDepositAmounts(def.DepositAmounts); err != nil {
+	if err := deposit.VerifyDepositAmounts(def.DepositAmounts, false); err != nil {
 		return Definition{}, errors.Wrap(err, "invalid deposit amounts")
 	}
 
@@ -847,6 +956,44 @@ func unmarshalDefinitionV1x9(data []byte) (def Definition, err error) {
 	}, nil
 }
 
+func unmarshalDefinitionV1x10(data []byte) (def Definition, err error) {
+	var defJSON definitionJSONv1x10
+	if err := json.Unmarshal(data, &defJSON); err != nil {
+		return Definition{}, errors.Wrap(err, "unmarshal definition v1_10")
+	}
+
+	if len(defJSON.ValidatorAddresses) != defJSON.NumValidators {
+		return Definition{}, errors.New("num_validators not matching validators length")
+	}
+
+	def = Definition{
+		Name:               defJSON.Name,
+		UUID:               defJSON.UUID,
+		Version:            defJSON.Version,
+		Timestamp:          defJSON.Timestamp,
+		NumValidators:      defJSON.NumValidators,
+		Threshold:          defJSON.Threshold,
+		DKGAlgorithm:       defJSON.DKGAlgorithm,
+		ForkVersion:        defJSON.ForkVersion,
+		ConfigHash:         defJSON.ConfigHash,
+		DefinitionHash:     defJSON.DefinitionHash,
+		Operators:          operatorsFromV1x2orLater(defJSON.Operators),
+		ValidatorAddresses: validatorAddressesFromJSONv1x10(defJSON.ValidatorAddresses),
+		Creator: Creator{
+			Address:         defJSON.Creator.Address,
+			ConfigSignature: defJSON.Creator.ConfigSignature,
+		},
+		DepositAmounts:    defJSON.DepositAmounts,
+		ConsensusProtocol: defJSON.ConsensusProtocol,
+	}
+
+	if err := def.VerifyDepositAmounts(); err != nil {
+		return Definition{}, errors.Wrap(err, "invalid deposit amounts")
+	}
+
+	return def, nil
+}
+
 // supportEIP712Sigs returns true if the provided definition version supports EIP712 signatures.
 // Note that Definition versions prior to v1.3.0 don't support EIP712 signatures.
 func supportEIP712Sigs(version string) bool {
@@ -858,6 +1005,11 @@ func supportPartialDeposits(version string) bool {
 	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5, v1_6, v1_7)
 }
 
+// supportCompounding returns true if the provided definition version supports compounding validators.
+func supportCompounding(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5, v1_6, v1_7, v1_8, v1_9)
+}
+
 func eip712SigsPresent(operators []Operator) bool {
 	for _, o := range operators {
 		if len(o.ENRSignature) > 0 || len(o.ConfigSignature) > 0 {
@@ -955,7 +1107,7 @@ type definitionJSONv1x8 struct {
 	DefinitionHash     ethHex                    `json:"definition_hash"`
 }
 
-// definitionJSONv1x9 is the json formatter of Definition for versions v1.9 or later.
+// definitionJSONv1x9 is the json formatter of Definition for versions v1.9.
 type definitionJSONv1x9 struct {
 	Name               string                    `json:"name,omitempty"`
 	Creator            creatorJSON               `json:"creator"`
@@ -974,6 +1126,25 @@ type definitionJSONv1x9 struct {
 	DefinitionHash     ethHex                    `json:"definition_hash"`
 }
 
+// definitionJSONv1x10 is the json formatter of Definition for versions v1.10 or later.
+type definitionJSONv1x10 struct {
+	Name               string                        `json:"name,omitempty"`
+	Creator            creatorJSON                   `json:"creator"`
+	Operators          []operatorJSONv1x2orLater     `json:"operators"`
+	UUID               string                        `json:"uuid"`
+	Version            string                        `json:"version"`
+	Timestamp          string                        `json:"timestamp,omitempty"`
+	NumValidators      int                           `json:"num_validators"`
+	Threshold          int                           `json:"threshold"`
+	ValidatorAddresses []validatorAddressesJSONv1x10 `json:"validators"`
+	DKGAlgorithm       string                        `json:"dkg_algorithm"`
+	ForkVersion        ethHex                        `json:"fork_version"`
+	DepositAmounts     []eth2p0.Gwei                 `json:"deposit_amounts"`
+	ConsensusProtocol  string                        `json:"consensus_protocol"`
+	ConfigHash         ethHex                        `json:"config_hash"`
+	DefinitionHash     ethHex                        `json:"definition_hash"`
+}
+
 // Creator identifies the creator of a cluster definition.
 // Note the following struct tag meanings:
 //   - json: json field name. Suffix 0xhex indicates bytes are formatted as 0x prefixed hex strings.
@@ -1002,19 +1173,32 @@ type ValidatorAddresses struct {
 
 	// WithdrawalAddress 20 byte Ethereum address.
 	WithdrawalAddress string `json:"withdrawal_address,0xhex" ssz:"Bytes20" config_hash:"1" definition_hash:"1"`
+
+	// Compounding indicates '0x02' compounding withdrawal credentials, supported from v1.10.
+	Compounding bool `json:"compounding" ssz:"bool" config_hash:"2" definition_hash:"2"`
 }
 
-// validatorAddressesJSON is the json formatter of ValidatorAddresses.
+// validatorAddressesJSON is the json formatter of ValidatorAddresses for versions v1.5 to v1.9.
 type validatorAddressesJSON struct {
 	FeeRecipientAddress string `json:"fee_recipient_address"`
 	WithdrawalAddress   string `json:"withdrawal_address"`
 }
 
+// validatorAddressesJSONv1x10 is the json formatter of ValidatorAddresses for versions v1.10 or later.
+type validatorAddressesJSONv1x10 struct {
+	FeeRecipientAddress string `json:"fee_recipient_address"`
+	WithdrawalAddress   string `json:"withdrawal_address"`
+	Compounding         bool   `json:"compounding"`
+}
+
 // validatorAddressesToJSON returns the json formatters for the slice of ValidatorAddresses.
 func validatorAddressesToJSON(vaddrs []ValidatorAddresses) []validatorAddressesJSON {
 	var resp []validatorAddressesJSON
 	for _, vaddr := range vaddrs {
-		resp = append(resp, validatorAddressesJSON(vaddr))
+		resp = append(resp, validatorAddressesJSON{
+			FeeRecipientAddress: vaddr.FeeRecipientAddress,
+			WithdrawalAddress:   vaddr.WithdrawalAddress,
+		})
 	}
 
 	return resp
@@ -1022,6 +1206,29 @@ func validatorAddressesToJSON(vaddrs []ValidatorAddresses) []validatorAddressesJ
 
 // validatorAddressesFromJSON returns a slice of ValidatorAddresses from the json formatters.
 func validatorAddressesFromJSON(vaddrs []validatorAddressesJSON) []ValidatorAddresses {
+	var resp []ValidatorAddresses
+	for _, vaddr := range vaddrs {
+		resp = append(resp, ValidatorAddresses{
+			FeeRecipientAddress: vaddr.FeeRecipientAddress,
+			WithdrawalAddress:   vaddr.WithdrawalAddress,
+		})
+	}
+
+	return resp
+}
+
+// validatorAddressesToJSONv1x10 returns the v1.10 json formatters for the slice of ValidatorAddresses.
+func validatorAddressesToJSONv1x10(vaddrs []ValidatorAddresses) []validatorAddressesJSONv1x10 {
+	var resp []validatorAddressesJSONv1x10
+	for _, vaddr := range vaddrs {
+		resp = append(resp, validatorAddressesJSONv1x10(vaddr))
+	}
+
+	return resp
+}
+
+// validatorAddressesFromJSONv1x10 returns a slice of ValidatorAddresses from the v1.10 json formatters.
+func validatorAddressesFromJSONv1x10(vaddrs []validatorAddressesJSONv1x10) []ValidatorAddresses {
 	var resp []ValidatorAddresses
 	for _, vaddr := range vaddrs {
 		resp = append(resp, ValidatorAddresses(vaddr))
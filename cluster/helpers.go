@@ -91,20 +91,43 @@ func uuid(random io.Reader) string {
 }
 
 // verifySig returns true if the signature matches the digest and address.
-func verifySig(expectedAddr string, digest []byte, sig []byte) (bool, error) {
+// If the signature isn't a valid secp256k1 signature of the address and a contract verifier is provided,
+// it falls back to verifying it as an ERC-1271 smart contract signature.
+func verifySig(conf verifyConfig, forkVersion []byte, expectedAddr string, digest []byte, sig []byte) (bool, error) {
 	expectedAddr, err := eth2util.ChecksumAddress(expectedAddr)
 	if err != nil {
 		return false, err
 	}
 
 	pubkey, err := k1util.Recover(digest, sig)
+	if err == nil && eth2util.PublicKeyToAddress(pubkey) == expectedAddr {
+		return true, nil
+	}
+
+	if conf.contractVerifier == nil {
+		if err != nil {
+			return false, errors.Wrap(err, "not a secp256k1 signature of an externally owned account; "+
+				"verifying ERC-1271 smart contract signatures requires --execution-client-rpc-endpoint", z.Str("address", expectedAddr))
+		}
+
+		return false, nil
+	}
+
+	if len(digest) != 32 {
+		return false, errors.New("invalid digest length", z.Int("length", len(digest)))
+	}
+
+	chainID, err := eth2util.ForkVersionToChainID(forkVersion)
 	if err != nil {
-		return false, errors.Wrap(err, "pubkey from signature")
+		return false, err
 	}
 
-	actualAddr := eth2util.PublicKeyToAddress(pubkey)
+	ok, err := conf.contractVerifier.VerifySmartContractBasedSignature(conf.ctx, chainID, expectedAddr, [32]byte(digest), sig)
+	if err != nil {
+		return false, errors.Wrap(err, "verify contract signature", z.Str("address", expectedAddr))
+	}
 
-	return expectedAddr == actualAddr, nil
+	return ok, nil
 }
 
 // signCreator returns the definition with signed creator config hash.
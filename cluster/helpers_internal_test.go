@@ -39,14 +39,14 @@ func TestVerifySig(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("valid signature", func(t *testing.T) {
-		ok, err := verifySig(addr, digest[:], sig)
+		ok, err := verifySig(verifyConfig{}, nil, addr, digest[:], sig)
 		require.NoError(t, err)
 		require.True(t, ok)
 	})
 
 	t.Run("invalid signature length", func(t *testing.T) {
 		var invalidSig [70]byte
-		ok, err := verifySig(addr, digest[:], invalidSig[:])
+		ok, err := verifySig(verifyConfig{}, nil, addr, digest[:], invalidSig[:])
 		require.Error(t, err)
 		require.ErrorContains(t, err, "signature not 65 bytes")
 		require.False(t, ok)
@@ -57,7 +57,7 @@ func TestVerifySig(t *testing.T) {
 		copy(newSig[:], sig)
 		newSig[64] = byte(165) // Make the last byte invalid.
 
-		ok, err := verifySig(addr, digest[:], newSig[:])
+		ok, err := verifySig(verifyConfig{}, nil, addr, digest[:], newSig[:])
 		require.Error(t, err)
 		require.ErrorContains(t, err, "invalid recovery id")
 		require.False(t, ok)
@@ -68,7 +68,7 @@ func TestVerifySig(t *testing.T) {
 		copy(newSig[:], sig)
 		newSig[64] += 27 // Make last byte 27/28.
 
-		ok, err := verifySig(addr, digest[:], newSig[:])
+		ok, err := verifySig(verifyConfig{}, nil, addr, digest[:], newSig[:])
 		require.NoError(t, err)
 		require.True(t, ok)
 	})
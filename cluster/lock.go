@@ -11,6 +11,7 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/tbls"
@@ -54,7 +55,7 @@ func (l Lock) MarshalJSON() ([]byte, error) {
 		return marshalLockV1x6(l, lockHash)
 	case isAnyVersion(l.Version, v1_7):
 		return marshalLockV1x7(l, lockHash)
-	case isAnyVersion(l.Version, v1_8, v1_9):
+	case isAnyVersion(l.Version, v1_8, v1_9, v1_10):
 		return marshalLockV1x8OrLater(l, lockHash)
 	default:
 		return nil, errors.New("unsupported version")
@@ -102,7 +103,7 @@ func (l *Lock) UnmarshalJSON(data []byte) error {
 		if err != nil {
 			return err
 		}
-	case isAnyVersion(version.Definition.Version, v1_8, v1_9):
+	case isAnyVersion(version.Definition.Version, v1_8, v1_9, v1_10):
 		lock, err = unmarshalLockV1x8OrLater(data)
 		if err != nil {
 			return err
@@ -148,8 +149,8 @@ func (l Lock) VerifyHashes() error {
 
 // VerifySignatures returns true if all config signatures are fully populated and valid.
 // A verified lock is ready for use in charon run.
-func (l Lock) VerifySignatures() error {
-	if err := l.Definition.VerifySignatures(); err != nil {
+func (l Lock) VerifySignatures(opts ...VerifyOption) error {
+	if err := l.Definition.VerifySignatures(opts...); err != nil {
 		return errors.Wrap(err, "invalid definition")
 	}
 
@@ -192,9 +193,68 @@ func (l Lock) VerifySignatures() error {
 		return errors.Wrap(err, "verify pre-generated builder registrations")
 	}
 
+	err = l.verifyDepositData()
+	if err != nil {
+		return errors.Wrap(err, "verify deposit data")
+	}
+
 	return l.verifyNodeSignatures()
 }
 
+// verifyDepositData returns an error if the populated deposit data doesn't match the validator
+// addresses or is otherwise invalid. Only versions supporting compounding validators are verified.
+func (l Lock) verifyDepositData() error {
+	if !supportCompounding(l.Version) {
+		return nil
+	}
+
+	// Compute the deposit domain directly from the fork version, supporting custom networks.
+	if len(l.ForkVersion) != len(eth2p0.Version{}) {
+		return errors.New("invalid fork version length", z.Int("length", len(l.ForkVersion)))
+	}
+	forkVersion := eth2p0.Version(l.ForkVersion)
+
+	for i, val := range l.Validators {
+		vaddrs := l.ValidatorAddresses[i]
+		for _, dd := range val.PartialDepositData {
+			if !bytes.Equal(dd.PubKey, val.PubKey) {
+				return errors.New("deposit data pubkey mismatch", z.Int("validator_index", i))
+			}
+
+			msg, err := deposit.NewMessage(eth2p0.BLSPubKey(val.PubKey), vaddrs.WithdrawalAddress, eth2p0.Gwei(dd.Amount), vaddrs.Compounding)
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(msg.WithdrawalCredentials, dd.WithdrawalCredentials) {
+				return errors.New("deposit data withdrawal credentials mismatch",
+					z.Int("validator_index", i), z.Bool("compounding", vaddrs.Compounding))
+			}
+
+			sigRoot, err := deposit.GetMessageSigningRootByForkVersion(msg, forkVersion)
+			if err != nil {
+				return err
+			}
+
+			pubkey, err := tblsconv.PubkeyFromBytes(val.PubKey)
+			if err != nil {
+				return errors.Wrap(err, "core pubkey from bytes")
+			}
+
+			sig, err := tblsconv.SignatureFromBytes(dd.Signature)
+			if err != nil {
+				return errors.Wrap(err, "tbls signature from bytes")
+			}
+
+			if err := tbls.Verify(pubkey, sigRoot[:], sig); err != nil {
+				return errors.Wrap(err, "verify deposit data signature")
+			}
+		}
+	}
+
+	return nil
+}
+
 // verifyNodeSignatures returns true an error if the node signatures field is not correctly
 // populated or otherwise invalid.
 func (l Lock) verifyNodeSignatures() error {
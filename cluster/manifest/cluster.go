@@ -3,6 +3,8 @@
 package manifest
 
 import (
+	"bytes"
+
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -94,3 +96,21 @@ func ValidatorPublicKeyHex(v *manifestpb.Validator) string {
 func ValidatorPublicShare(v *manifestpb.Validator, peerIdx int) (tbls.PublicKey, error) {
 	return tblsconv.PubkeyFromBytes(v.GetPubShares()[peerIdx])
 }
+
+// ClusterValidator returns the validator with the provided group public key, resolving both the active
+// validators and the archived validators removed from the cluster. It returns true if the validator is archived.
+func ClusterValidator(c *manifestpb.Cluster, pubkey []byte) (*manifestpb.Validator, bool, error) {
+	for _, val := range c.GetValidators() {
+		if bytes.Equal(val.GetPublicKey(), pubkey) {
+			return val, false, nil
+		}
+	}
+
+	for _, val := range c.GetArchivedValidators() {
+		if bytes.Equal(val.GetPublicKey(), pubkey) {
+			return val, true, nil
+		}
+	}
+
+	return nil, false, errors.New("validator not in cluster", z.Str("pubkey", to0xHex(pubkey)))
+}
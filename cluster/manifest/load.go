@@ -115,6 +115,10 @@ func loadDAGFromLegacyLock(filename string, lockCallback func(cluster.Lock) erro
 
 // clusterHashesMatch returns an error if the cluster hashes of the provided DAGs don't match.
 func clusterHashesMatch(dagManifest, dagLegacy *manifestpb.SignedMutationList) error {
+	if len(dagManifest.GetMutations()) == 0 {
+		return errors.New("empty cluster manifest")
+	}
+
 	hashManifest, err := Hash(dagManifest.GetMutations()[0])
 	if err != nil {
 		return errors.Wrap(err, "materialise dag")
@@ -3,6 +3,8 @@
 package manifest
 
 import (
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
 )
 
@@ -32,6 +34,11 @@ const (
 	TypeNodeApprovals MutationType = "dv/node_approvals/v0.0.1"
 	TypeGenValidators MutationType = "dv/gen_validators/v0.0.1"
 	TypeAddValidators MutationType = "dv/add_validators/v0.0.1"
+
+	TypeValidatorAddresses       MutationType = "dv/validator_addresses/v0.0.1"
+	TypeUpdateValidatorAddresses MutationType = "dv/update_validator_addresses/v0.0.1"
+	TypeValidatorRemovals        MutationType = "dv/validator_removals/v0.0.1"
+	TypeRemoveValidators         MutationType = "dv/remove_validators/v0.0.1"
 )
 
 type mutationDef struct {
@@ -66,4 +73,26 @@ func init() {
 	mutationDefs[TypeAddValidators] = mutationDef{
 		TransformFunc: transformAddValidators,
 	}
+
+	mutationDefs[TypeValidatorAddresses] = mutationDef{
+		TransformFunc: transformUnapproved,
+	}
+
+	mutationDefs[TypeUpdateValidatorAddresses] = mutationDef{
+		TransformFunc: transformUpdateValidatorAddresses,
+	}
+
+	mutationDefs[TypeValidatorRemovals] = mutationDef{
+		TransformFunc: transformUnapproved,
+	}
+
+	mutationDefs[TypeRemoveValidators] = mutationDef{
+		TransformFunc: transformRemoveValidators,
+	}
+}
+
+// transformUnapproved returns an error since proposals may only be applied
+// with threshold node approvals as part of their composite mutation.
+func transformUnapproved(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	return c, errors.New("proposal mutation without node approvals", z.Str("type", signed.GetMutation().GetType()))
 }
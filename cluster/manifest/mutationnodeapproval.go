@@ -128,3 +128,146 @@ func transformNodeApprovals(c *manifestpb.Cluster, signed *manifestpb.SignedMuta
 
 	return c, nil
 }
+
+// newApprovedComposite creates a new composite mutation of the provided type from the proposal and its node approvals.
+func newApprovedComposite(typ, proposalType MutationType, proposal, nodeApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	if MutationType(proposal.GetMutation().GetType()) != proposalType {
+		return nil, errors.New("invalid proposal mutation type")
+	}
+
+	if MutationType(nodeApprovals.GetMutation().GetType()) != TypeNodeApprovals {
+		return nil, errors.New("invalid node approvals mutation type")
+	}
+
+	dataAny, err := anypb.New(&manifestpb.SignedMutationList{
+		Mutations: []*manifestpb.SignedMutation{proposal, nodeApprovals},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal signed mutation list")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: proposal.GetMutation().GetParent(),
+			Type:   string(typ),
+			Data:   dataAny,
+		},
+		// Composite mutations have no signer or signature.
+	}, nil
+}
+
+// transformApprovedComposite transforms the cluster manifest by applying the proposal of the composite mutation
+// if it is approved by at least threshold nodes.
+func transformApprovedComposite(c *manifestpb.Cluster, signed *manifestpb.SignedMutation, typ, proposalType MutationType,
+	apply func(*manifestpb.Cluster, *manifestpb.SignedMutation) (*manifestpb.Cluster, error),
+) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != typ {
+		return c, errors.New("invalid mutation type")
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return c, errors.Wrap(err, "unmarshal signed mutation list")
+	} else if len(list.GetMutations()) != 2 {
+		return c, errors.New("invalid mutation list length")
+	}
+
+	proposal := list.GetMutations()[0]
+	nodeApprovals := list.GetMutations()[1]
+
+	if MutationType(proposal.GetMutation().GetType()) != proposalType {
+		return c, errors.New("invalid proposal mutation type")
+	}
+	if !bytes.Equal(signed.GetMutation().GetParent(), proposal.GetMutation().GetParent()) {
+		return c, errors.New("invalid proposal parent")
+	}
+
+	if MutationType(nodeApprovals.GetMutation().GetType()) != TypeNodeApprovals {
+		return c, errors.New("invalid node approvals mutation type")
+	}
+
+	proposalHash, err := Hash(proposal)
+	if err != nil {
+		return c, errors.Wrap(err, "hash proposal")
+	}
+
+	if err := verifyThresholdNodeApprovals(c, proposalHash, nodeApprovals); err != nil {
+		return c, errors.Wrap(err, "verify node approvals")
+	}
+
+	if err := verifyEmptySig(proposal); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	c, err = apply(c, proposal)
+	if err != nil {
+		return c, errors.Wrap(err, "apply proposal", z.Str("type", proposal.GetMutation().GetType()))
+	}
+
+	return c, nil
+}
+
+// verifyThresholdNodeApprovals returns an error if the node approvals composite doesn't contain
+// approvals of the parent hash by at least threshold distinct nodes in the cluster.
+// Unlike node approvals of new validators, not all nodes need to approve.
+func verifyThresholdNodeApprovals(c *manifestpb.Cluster, parent []byte, nodeApprovals *manifestpb.SignedMutation) error {
+	if err := verifyEmptySig(nodeApprovals); err != nil {
+		return errors.Wrap(err, "verify empty sig")
+	}
+
+	if !bytes.Equal(parent, nodeApprovals.GetMutation().GetParent()) {
+		return errors.New("invalid node approvals parent")
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := nodeApprovals.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return errors.New("invalid node approval data")
+	}
+
+	peers, err := ClusterPeers(c)
+	if err != nil {
+		return errors.Wrap(err, "get peers")
+	}
+
+	approved := make(map[int]bool)
+	for _, approval := range list.GetMutations() {
+		if !bytes.Equal(parent, approval.GetMutation().GetParent()) {
+			return errors.New("mismatching node approvals parent")
+		}
+
+		if err := verifyNodeApproval(approval); err != nil {
+			return err
+		}
+
+		peerIdx := -1
+		for i, p := range peers {
+			pubkey, err := p.PublicKey()
+			if err != nil {
+				return errors.Wrap(err, "get peer public key")
+			}
+
+			if bytes.Equal(pubkey.SerializeCompressed(), approval.GetSigner()) {
+				peerIdx = i
+				break
+			}
+		}
+
+		if peerIdx < 0 {
+			return errors.New("node approval signer not in cluster")
+		} else if approved[peerIdx] {
+			return errors.New("duplicate node approval", z.Int("peer_index", peerIdx))
+		}
+
+		approved[peerIdx] = true
+	}
+
+	if len(approved) < int(c.GetThreshold()) {
+		return errors.New("insufficient node approvals", z.Int("approvals", len(approved)), z.I64("threshold", int64(c.GetThreshold())))
+	}
+
+	return nil
+}
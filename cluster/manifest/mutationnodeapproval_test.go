@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -34,6 +35,43 @@ func setIncrementingTime(t *testing.T) {
 	})
 }
 
+// approvedComposite returns the composite of the mutation approved by the approvers, created by newComposite.
+func approvedComposite(t *testing.T, mutation *manifestpb.SignedMutation,
+	newComposite func(mutation, approvals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error),
+	approvers ...*k1.PrivateKey,
+) *manifestpb.SignedMutation {
+	t.Helper()
+
+	hash, err := manifest.Hash(mutation)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, secret := range approvers {
+		approval, err := manifest.SignNodeApproval(hash, secret)
+		require.NoError(t, err)
+
+		approvals = append(approvals, approval)
+	}
+
+	nodeApprovals, err := manifest.NewNodeApprovalsComposite(approvals)
+	require.NoError(t, err)
+
+	resp, err := newComposite(mutation, nodeApprovals)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// clusterFromLock returns a new cluster manifest of the lock.
+func clusterFromLock(t *testing.T, lock cluster.Lock) *manifestpb.Cluster {
+	t.Helper()
+
+	c, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	return c
+}
+
 func TestNodeApprovals(t *testing.T) {
 	setIncrementingTime(t)
 
@@ -0,0 +1,119 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+// NewValidatorRemovals creates a new validator removals mutation proposing to remove existing, typically exited, validators.
+// It must be approved by at least threshold nodes, see NewRemoveValidators.
+func NewValidatorRemovals(parent []byte, pubkeys [][]byte) (*manifestpb.SignedMutation, error) {
+	if err := verifyValidatorRemovals(pubkeys); err != nil {
+		return nil, errors.Wrap(err, "verify validator removals")
+	}
+
+	if len(parent) != hashLen {
+		return nil, errors.New("invalid parent hash")
+	}
+
+	pubkeysAny, err := anypb.New(&manifestpb.ValidatorPublicKeyList{PublicKeys: pubkeys})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator public keys")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: parent,
+			Type:   string(TypeValidatorRemovals),
+			Data:   pubkeysAny,
+		},
+		// No signer or signature.
+	}, nil
+}
+
+// verifyValidatorRemovals validates the validator public keys to remove, ensuring they are unique.
+func verifyValidatorRemovals(pubkeys [][]byte) error {
+	if len(pubkeys) == 0 {
+		return errors.New("no validators to remove")
+	}
+
+	dedup := make(map[string]bool)
+	for _, pubkey := range pubkeys {
+		if len(pubkey) == 0 {
+			return errors.New("empty validator public key")
+		} else if dedup[string(pubkey)] {
+			return errors.New("duplicate validator", z.Str("pubkey", to0xHex(pubkey)))
+		}
+		dedup[string(pubkey)] = true
+	}
+
+	return nil
+}
+
+// NewRemoveValidators creates a new composite remove validators mutation from the provided
+// validator removals and node approvals.
+func NewRemoveValidators(validatorRemovals, nodeApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	return newApprovedComposite(TypeRemoveValidators, TypeValidatorRemovals, validatorRemovals, nodeApprovals)
+}
+
+func transformRemoveValidators(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	return transformApprovedComposite(c, signed, TypeRemoveValidators, TypeValidatorRemovals, applyValidatorRemovals)
+}
+
+// ValidatorRemovalPublicKeys returns the public keys of the validators removed by the validator removals mutation.
+func ValidatorRemovalPublicKeys(signed *manifestpb.SignedMutation) ([][]byte, error) {
+	if MutationType(signed.GetMutation().GetType()) != TypeValidatorRemovals {
+		return nil, errors.New("invalid mutation type")
+	}
+
+	list := new(manifestpb.ValidatorPublicKeyList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return nil, errors.Wrap(err, "unmarshal validator public keys")
+	}
+
+	return list.GetPublicKeys(), nil
+}
+
+// applyValidatorRemovals returns the cluster with the removed validators moved to the archived validators,
+// preserving the order of the remaining validators.
+func applyValidatorRemovals(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	pubkeys, err := ValidatorRemovalPublicKeys(signed)
+	if err != nil {
+		return c, err
+	}
+
+	if err := verifyValidatorRemovals(pubkeys); err != nil {
+		return c, err
+	}
+
+	remove := make(map[string]bool)
+	for _, pubkey := range pubkeys {
+		remove[string(pubkey)] = true
+	}
+
+	var remaining []*manifestpb.Validator
+	for _, val := range c.GetValidators() {
+		if !remove[string(val.GetPublicKey())] {
+			remaining = append(remaining, val)
+			continue
+		}
+
+		c.ArchivedValidators = append(c.ArchivedValidators, val)
+		delete(remove, string(val.GetPublicKey()))
+	}
+
+	for _, pubkey := range pubkeys {
+		if remove[string(pubkey)] {
+			return c, errors.New("validator not in cluster", z.Str("pubkey", to0xHex(pubkey)))
+		}
+	}
+
+	c.Validators = remaining
+
+	return c, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRemoveValidators(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 3, 3, 4, seed, random)
+
+	parent := testutil.RandomBytes32Seed(random)
+
+	remove := func(t *testing.T, pubkeys [][]byte, approvers ...*k1.PrivateKey) *manifestpb.SignedMutation {
+		t.Helper()
+
+		removals, err := manifest.NewValidatorRemovals(parent, pubkeys)
+		require.NoError(t, err)
+
+		return approvedComposite(t, removals, manifest.NewRemoveValidators, approvers...)
+	}
+
+	t.Run("unmarshal", func(t *testing.T) {
+		signed := remove(t, [][]byte{lock.Validators[0].PubKey}, secrets[0], secrets[1], secrets[2])
+
+		b, err := proto.Marshal(signed)
+		require.NoError(t, err)
+
+		signed2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, signed2))
+
+		testutil.RequireProtoEqual(t, signed, signed2)
+	})
+
+	t.Run("threshold approvals", func(t *testing.T) {
+		c := clusterFromLock(t, lock)
+		val0 := proto.Clone(c.GetValidators()[0])
+		val1 := proto.Clone(c.GetValidators()[1])
+		val2 := proto.Clone(c.GetValidators()[2])
+
+		signed := remove(t, [][]byte{lock.Validators[1].PubKey, lock.Validators[0].PubKey}, secrets[3], secrets[1], secrets[0])
+
+		c, err := manifest.Transform(c, signed)
+		require.NoError(t, err)
+
+		require.Len(t, c.GetValidators(), 1)
+		testutil.RequireProtoEqual(t, val2, c.GetValidators()[0])
+
+		// Archived validators keep the cluster order.
+		require.Len(t, c.GetArchivedValidators(), 2)
+		testutil.RequireProtoEqual(t, val0, c.GetArchivedValidators()[0])
+		testutil.RequireProtoEqual(t, val1, c.GetArchivedValidators()[1])
+
+		// Archived validators are still resolved by public key.
+		val, archived, err := manifest.ClusterValidator(c, lock.Validators[0].PubKey)
+		require.NoError(t, err)
+		require.True(t, archived)
+		testutil.RequireProtoEqual(t, val0, val)
+
+		val, archived, err = manifest.ClusterValidator(c, lock.Validators[2].PubKey)
+		require.NoError(t, err)
+		require.False(t, archived)
+		testutil.RequireProtoEqual(t, val2, val)
+
+		_, _, err = manifest.ClusterValidator(c, testutil.RandomBytes48())
+		require.ErrorContains(t, err, "validator not in cluster")
+	})
+
+	t.Run("insufficient approvals", func(t *testing.T) {
+		signed := remove(t, [][]byte{lock.Validators[0].PubKey}, secrets[0], secrets[1])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "insufficient node approvals")
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		signed := remove(t, [][]byte{testutil.RandomBytes48()}, secrets[0], secrets[1], secrets[2])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "validator not in cluster")
+	})
+
+	t.Run("already removed", func(t *testing.T) {
+		signed := remove(t, [][]byte{lock.Validators[0].PubKey}, secrets[0], secrets[1], secrets[2])
+
+		c, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, signed)
+		require.ErrorContains(t, err, "validator not in cluster")
+	})
+
+	t.Run("without approvals", func(t *testing.T) {
+		removals, err := manifest.NewValidatorRemovals(parent, [][]byte{lock.Validators[0].PubKey})
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(clusterFromLock(t, lock), removals)
+		require.ErrorContains(t, err, "proposal mutation without node approvals")
+	})
+
+	t.Run("invalid removals", func(t *testing.T) {
+		_, err := manifest.NewValidatorRemovals(parent, nil)
+		require.ErrorContains(t, err, "no validators to remove")
+
+		_, err = manifest.NewValidatorRemovals(parent, [][]byte{lock.Validators[0].PubKey, lock.Validators[0].PubKey})
+		require.ErrorContains(t, err, "duplicate validator")
+	})
+}
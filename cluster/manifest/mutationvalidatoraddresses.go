@@ -0,0 +1,201 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// NewValidatorAddresses creates a new validator addresses mutation proposing to update the addresses of existing validators.
+// It must be approved by at least threshold nodes, see NewUpdateValidatorAddresses.
+func NewValidatorAddresses(parent []byte, addrs []*manifestpb.ValidatorAddresses) (*manifestpb.SignedMutation, error) {
+	if err := verifyValidatorAddresses(addrs); err != nil {
+		return nil, errors.Wrap(err, "verify validator addresses")
+	}
+
+	if len(parent) != hashLen {
+		return nil, errors.New("invalid parent hash")
+	}
+
+	addrsAny, err := anypb.New(&manifestpb.ValidatorAddressesList{Validators: addrs})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator addresses")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: parent,
+			Type:   string(TypeValidatorAddresses),
+			Data:   addrsAny,
+		},
+		// No signer or signature.
+	}, nil
+}
+
+// verifyValidatorAddresses validates the validator addresses list, ensuring it contains unique validators with valid addresses.
+func verifyValidatorAddresses(addrs []*manifestpb.ValidatorAddresses) error {
+	if len(addrs) == 0 {
+		return errors.New("no validator addresses")
+	}
+
+	dedup := make(map[string]bool)
+	for _, addr := range addrs {
+		if len(addr.GetPublicKey()) == 0 {
+			return errors.New("empty validator public key")
+		} else if dedup[string(addr.GetPublicKey())] {
+			return errors.New("duplicate validator", z.Str("pubkey", to0xHex(addr.GetPublicKey())))
+		}
+		dedup[string(addr.GetPublicKey())] = true
+
+		if addr.GetFeeRecipientAddress() == "" && addr.GetWithdrawalAddress() == "" && len(addr.GetBuilderRegistrationJson()) == 0 {
+			return errors.New("empty validator addresses", z.Str("pubkey", to0xHex(addr.GetPublicKey())))
+		}
+
+		if _, err := from0xHex(addr.GetFeeRecipientAddress(), 20); err != nil {
+			return errors.Wrap(err, "validate fee recipient address")
+		}
+		if _, err := from0xHex(addr.GetWithdrawalAddress(), 20); err != nil {
+			return errors.Wrap(err, "validate withdrawal address")
+		}
+	}
+
+	return nil
+}
+
+// VerifyValidatorAddresses returns an error if the validator addresses mutation doesn't apply to the cluster,
+// ignoring node approvals.
+func VerifyValidatorAddresses(c *manifestpb.Cluster, validatorAddresses *manifestpb.SignedMutation) error {
+	if MutationType(validatorAddresses.GetMutation().GetType()) != TypeValidatorAddresses {
+		return errors.New("invalid mutation type")
+	}
+
+	clone, ok := proto.Clone(c).(*manifestpb.Cluster)
+	if !ok {
+		return errors.New("clone cluster")
+	}
+
+	_, err := applyValidatorAddresses(clone, validatorAddresses)
+
+	return err
+}
+
+// NewUpdateValidatorAddresses creates a new composite update validator addresses mutation from the provided
+// validator addresses and node approvals.
+func NewUpdateValidatorAddresses(validatorAddresses, nodeApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	return newApprovedComposite(TypeUpdateValidatorAddresses, TypeValidatorAddresses, validatorAddresses, nodeApprovals)
+}
+
+func transformUpdateValidatorAddresses(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	return transformApprovedComposite(c, signed, TypeUpdateValidatorAddresses, TypeValidatorAddresses, applyValidatorAddresses)
+}
+
+// applyValidatorAddresses returns the cluster with the validator addresses applied.
+// Withdrawal addresses can't be changed since they are immutable on chain once deposited.
+// Pre-generated builder registrations are replaced by the provided ones, a new one must be provided
+// if the fee recipient of a validator with a pre-generated registration changes, since it would otherwise
+// register the old fee recipient.
+func applyValidatorAddresses(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	list := new(manifestpb.ValidatorAddressesList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return c, errors.Wrap(err, "unmarshal validator addresses")
+	}
+
+	if err := verifyValidatorAddresses(list.GetValidators()); err != nil {
+		return c, err
+	}
+
+	for _, addrs := range list.GetValidators() {
+		idx := -1
+		for i, val := range c.GetValidators() {
+			if bytes.Equal(val.GetPublicKey(), addrs.GetPublicKey()) {
+				idx = i
+				break
+			}
+		}
+
+		if idx < 0 {
+			return c, errors.New("validator not in cluster", z.Str("pubkey", to0xHex(addrs.GetPublicKey())))
+		}
+
+		val, ok := proto.Clone(c.GetValidators()[idx]).(*manifestpb.Validator)
+		if !ok {
+			return c, errors.New("clone validator")
+		}
+
+		if addrs.GetWithdrawalAddress() != "" && !strings.EqualFold(addrs.GetWithdrawalAddress(), val.GetWithdrawalAddress()) {
+			return c, errors.New("withdrawal address is immutable once deposited", z.Str("pubkey", to0xHex(val.GetPublicKey())))
+		}
+
+		feeRecipientChanged := addrs.GetFeeRecipientAddress() != "" &&
+			!strings.EqualFold(addrs.GetFeeRecipientAddress(), val.GetFeeRecipientAddress())
+
+		if addrs.GetFeeRecipientAddress() != "" {
+			val.FeeRecipientAddress = addrs.GetFeeRecipientAddress()
+		}
+
+		if len(addrs.GetBuilderRegistrationJson()) > 0 {
+			if err := verifyBuilderRegistration(c.GetForkVersion(), val, addrs.GetBuilderRegistrationJson()); err != nil {
+				return c, errors.Wrap(err, "verify builder registration", z.Str("pubkey", to0xHex(val.GetPublicKey())))
+			}
+
+			val.BuilderRegistrationJson = addrs.GetBuilderRegistrationJson()
+		} else if feeRecipientChanged && len(val.GetBuilderRegistrationJson()) > 0 {
+			return c, errors.New("fee recipient change requires a new builder registration", z.Str("pubkey", to0xHex(val.GetPublicKey())))
+		}
+
+		c.Validators[idx] = val
+	}
+
+	return c, nil
+}
+
+// verifyBuilderRegistration returns an error if the json-formatted builder registration isn't
+// a valid registration of the validator's public key and fee recipient.
+func verifyBuilderRegistration(forkVersion []byte, val *manifestpb.Validator, regJSON []byte) error {
+	reg := new(eth2api.VersionedSignedValidatorRegistration)
+	if err := json.Unmarshal(regJSON, reg); err != nil {
+		return errors.Wrap(err, "unmarshal builder registration")
+	}
+
+	if reg.V1 == nil || reg.V1.Message == nil {
+		return errors.New("invalid builder registration")
+	}
+
+	if !bytes.Equal(reg.V1.Message.Pubkey[:], val.GetPublicKey()) {
+		return errors.New("builder registration public key mismatch")
+	}
+
+	if !strings.EqualFold(reg.V1.Message.FeeRecipient.String(), val.GetFeeRecipientAddress()) {
+		return errors.New("builder registration fee recipient mismatch",
+			z.Str("expected", val.GetFeeRecipientAddress()), z.Str("actual", reg.V1.Message.FeeRecipient.String()))
+	}
+
+	sigRoot, err := registration.GetMessageSigningRoot(reg.V1.Message, eth2p0.Version(forkVersion))
+	if err != nil {
+		return err
+	}
+
+	pubkey, err := ValidatorPublicKey(val)
+	if err != nil {
+		return errors.Wrap(err, "validator public key")
+	}
+
+	if err := tbls.Verify(pubkey, sigRoot[:], tbls.Signature(reg.V1.Signature)); err != nil {
+		return errors.Wrap(err, "invalid builder registration signature")
+	}
+
+	return nil
+}
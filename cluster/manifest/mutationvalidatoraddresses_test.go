@@ -0,0 +1,233 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestUpdateValidatorAddresses(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	newFeeRecipient := testutil.RandomChecksummedETHAddress(t, seed)
+	parent := testutil.RandomBytes32Seed(random)
+
+	update := func(t *testing.T, addrs []*manifestpb.ValidatorAddresses, approvers ...*k1.PrivateKey) *manifestpb.SignedMutation {
+		t.Helper()
+
+		valAddrs, err := manifest.NewValidatorAddresses(parent, addrs)
+		require.NoError(t, err)
+
+		return approvedComposite(t, valAddrs, manifest.NewUpdateValidatorAddresses, approvers...)
+	}
+
+	t.Run("unmarshal", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1], secrets[2])
+
+		b, err := proto.Marshal(signed)
+		require.NoError(t, err)
+
+		signed2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, signed2))
+
+		testutil.RequireProtoEqual(t, signed, signed2)
+	})
+
+	t.Run("threshold approvals", func(t *testing.T) {
+		c := clusterFromLock(t, lock)
+		require.NotEmpty(t, c.GetValidators()[0].GetBuilderRegistrationJson())
+
+		expect := proto.Clone(c.GetValidators()[1])
+		regJSON := newRegistrationJSON(t, lock, shares[0], newFeeRecipient)
+
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:               lock.Validators[0].PubKey,
+			FeeRecipientAddress:     newFeeRecipient,
+			BuilderRegistrationJson: regJSON,
+		}}, secrets[3], secrets[1], secrets[0])
+
+		c, err := manifest.Transform(c, signed)
+		require.NoError(t, err)
+
+		require.Equal(t, newFeeRecipient, c.GetValidators()[0].GetFeeRecipientAddress())
+		require.Equal(t, lock.ValidatorAddresses[0].WithdrawalAddress, c.GetValidators()[0].GetWithdrawalAddress())
+		require.Equal(t, regJSON, c.GetValidators()[0].GetBuilderRegistrationJson())
+		// Other validators are unchanged.
+		testutil.RequireProtoEqual(t, expect, c.GetValidators()[1])
+	})
+
+	t.Run("fee recipient without builder registration", func(t *testing.T) {
+		c := clusterFromLock(t, lock)
+		require.NotEmpty(t, c.GetValidators()[0].GetBuilderRegistrationJson())
+
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1], secrets[2])
+
+		_, err := manifest.Transform(c, signed)
+		require.ErrorContains(t, err, "fee recipient change requires a new builder registration")
+	})
+
+	t.Run("withdrawal address", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:         lock.Validators[0].PubKey,
+			WithdrawalAddress: testutil.RandomChecksummedETHAddress(t, seed+1),
+		}}, secrets[0], secrets[1], secrets[2])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "withdrawal address is immutable once deposited")
+	})
+
+	t.Run("builder registration", func(t *testing.T) {
+		regJSON := newRegistrationJSON(t, lock, shares[0], newFeeRecipient)
+
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:               lock.Validators[0].PubKey,
+			FeeRecipientAddress:     newFeeRecipient,
+			BuilderRegistrationJson: regJSON,
+		}}, secrets[0], secrets[1], secrets[2])
+
+		c, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.NoError(t, err)
+		require.Equal(t, regJSON, c.GetValidators()[0].GetBuilderRegistrationJson())
+
+		// Registrations of another fee recipient are rejected.
+		signed = update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:               lock.Validators[0].PubKey,
+			BuilderRegistrationJson: regJSON,
+		}}, secrets[0], secrets[1], secrets[2])
+
+		_, err = manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "builder registration fee recipient mismatch")
+	})
+
+	t.Run("insufficient approvals", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "insufficient node approvals")
+	})
+
+	t.Run("duplicate approvals", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1], secrets[1])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "duplicate node approval")
+	})
+
+	t.Run("unknown approver", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1], testutil.GenerateInsecureK1Key(t, 99))
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "node approval signer not in cluster")
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		signed := update(t, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           testutil.RandomBytes48(),
+			FeeRecipientAddress: newFeeRecipient,
+		}}, secrets[0], secrets[1], secrets[2])
+
+		_, err := manifest.Transform(clusterFromLock(t, lock), signed)
+		require.ErrorContains(t, err, "validator not in cluster")
+	})
+
+	t.Run("without approvals", func(t *testing.T) {
+		valAddrs, err := manifest.NewValidatorAddresses(parent, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: newFeeRecipient,
+		}})
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(clusterFromLock(t, lock), valAddrs)
+		require.ErrorContains(t, err, "proposal mutation without node approvals")
+	})
+
+	t.Run("invalid addresses", func(t *testing.T) {
+		_, err := manifest.NewValidatorAddresses(parent, []*manifestpb.ValidatorAddresses{{
+			PublicKey:           lock.Validators[0].PubKey,
+			FeeRecipientAddress: "0x1234",
+		}})
+		require.ErrorContains(t, err, "validate fee recipient address")
+
+		_, err = manifest.NewValidatorAddresses(parent, []*manifestpb.ValidatorAddresses{{
+			PublicKey: lock.Validators[0].PubKey,
+		}})
+		require.ErrorContains(t, err, "empty validator addresses")
+	})
+}
+
+// newRegistrationJSON returns a json-formatted builder registration of the lock's validator with the provided shares.
+func newRegistrationJSON(t *testing.T, lock cluster.Lock, shares []tbls.PrivateKey, feeRecipient string) []byte {
+	t.Helper()
+
+	shareMap := make(map[int]tbls.PrivateKey)
+	for i, share := range shares {
+		shareMap[i+1] = share
+	}
+
+	secret, err := tbls.RecoverSecret(shareMap, uint(len(shares)), uint(lock.Threshold))
+	require.NoError(t, err)
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	timestamp, err := eth2util.ForkVersionToGenesisTime(lock.ForkVersion)
+	require.NoError(t, err)
+
+	msg, err := registration.NewMessage(eth2p0.BLSPubKey(pubkey), feeRecipient, registration.DefaultGasLimit, timestamp)
+	require.NoError(t, err)
+
+	sigRoot, err := registration.GetMessageSigningRoot(msg, eth2p0.Version(lock.ForkVersion))
+	require.NoError(t, err)
+
+	sig, err := tbls.Sign(secret, sigRoot[:])
+	require.NoError(t, err)
+
+	b, err := json.Marshal(&eth2api.VersionedSignedValidatorRegistration{
+		Version: eth2spec.BuilderVersionV1,
+		V1: &eth2v1.SignedValidatorRegistration{
+			Message:   msg,
+			Signature: tblsconv.SigToETH2(sig),
+		},
+	})
+	require.NoError(t, err)
+
+	return b
+}
@@ -37,7 +37,7 @@ func getDefinitionHashFunc(version string) (func(Definition, ssz.HashWalker, boo
 		return hashDefinitionV1x5to7, nil
 	} else if isAnyVersion(version, v1_8) {
 		return hashDefinitionV1x8, nil
-	} else if isAnyVersion(version, v1_9) {
+	} else if isAnyVersion(version, v1_9, v1_10) {
 		return hashDefinitionV1x9orLater, nil
 	}
 
@@ -277,8 +277,8 @@ func hashDefinitionV1x3or4(d Definition, hh ssz.HashWalker, configOnly bool) err
 // hashExtraFields is a function that hashes extra fields from index 11 onwards.
 type hashExtraFields func(d Definition, hh ssz.HashWalker) error
 
-// hashDefinitionV1x5to9 hashes the new definition.
-func hashDefinitionV1x5to9(d Definition, hh ssz.HashWalker, configOnly bool, extra []hashExtraFields) error {
+// hashDefinitionV1x5orLater hashes the new definition.
+func hashDefinitionV1x5orLater(d Definition, hh ssz.HashWalker, configOnly bool, extra []hashExtraFields) error {
 	indx := hh.Index()
 
 	// Field (0) 'UUID' ByteList[64]
@@ -386,6 +386,11 @@ func hashDefinitionV1x5to9(d Definition, hh ssz.HashWalker, configOnly bool, ext
 				return err
 			}
 
+			if supportCompounding(d.Version) {
+				// Field (2) 'Compounding' bool for v1.10 and later
+				hh.PutBool(v.Compounding)
+			}
+
 			hh.Merkleize(validatorIdx)
 		}
 		hh.MerkleizeWithMixin(validatorsIdx, num, sszMaxValidators)
@@ -412,12 +417,12 @@ func hashDefinitionV1x5to9(d Definition, hh ssz.HashWalker, configOnly bool, ext
 
 // hashDefinitionV1x5to7 hashes the new definition.
 func hashDefinitionV1x5to7(d Definition, hh ssz.HashWalker, configOnly bool) error {
-	return hashDefinitionV1x5to9(d, hh, configOnly, nil)
+	return hashDefinitionV1x5orLater(d, hh, configOnly, nil)
 }
 
-// hashDefinitionV1x8to9 hashes the new definition with extra fields.
-func hashDefinitionV1x8to9(d Definition, hh ssz.HashWalker, configOnly bool, extra []hashExtraFields) error {
-	return hashDefinitionV1x5to9(d, hh, configOnly, []hashExtraFields{
+// hashDefinitionV1x8orLater hashes the new definition with extra fields.
+func hashDefinitionV1x8orLater(d Definition, hh ssz.HashWalker, configOnly bool, extra []hashExtraFields) error {
+	return hashDefinitionV1x5orLater(d, hh, configOnly, []hashExtraFields{
 		func(d Definition, hh ssz.HashWalker) error {
 			// Field (11) 'DepositAmounts' uint64[256]
 			hasher, ok := hh.(*ssz.Hasher)
@@ -443,12 +448,12 @@ func hashDefinitionV1x8to9(d Definition, hh ssz.HashWalker, configOnly bool, ext
 
 // hashDefinitionV1x8 hashes the new definition.
 func hashDefinitionV1x8(d Definition, hh ssz.HashWalker, configOnly bool) error {
-	return hashDefinitionV1x8to9(d, hh, configOnly, nil)
+	return hashDefinitionV1x8orLater(d, hh, configOnly, nil)
 }
 
 // hashDefinitionV1x9OrLater hashes the new definition.
 func hashDefinitionV1x9orLater(d Definition, hh ssz.HashWalker, configOnly bool) error {
-	return hashDefinitionV1x8to9(d, hh, configOnly, []hashExtraFields{
+	return hashDefinitionV1x8orLater(d, hh, configOnly, []hashExtraFields{
 		func(d Definition, hh ssz.HashWalker) error {
 			// Field (12) 'ConsensusProtocol' ByteList[256]
 			return putByteList(hh, []byte(d.ConsensusProtocol), sszMaxName, "consensus_protocol")
@@ -461,7 +466,7 @@ func hashLock(l Lock) ([32]byte, error) {
 	var hashFunc func(Lock, ssz.HashWalker) error
 	if isAnyVersion(l.Version, v1_0, v1_1, v1_2) {
 		hashFunc = hashLockLegacy
-	} else if isAnyVersion(l.Version, v1_3, v1_4, v1_5, v1_6, v1_7, v1_8, v1_9) {
+	} else if isAnyVersion(l.Version, v1_3, v1_4, v1_5, v1_6, v1_7, v1_8, v1_9, v1_10) {
 		hashFunc = hashLockV1x3orLater
 	} else {
 		return [32]byte{}, errors.New("unknown version")
@@ -524,7 +529,7 @@ func getValidatorHashFunc(version string) (func(DistValidator, ssz.HashWalker, s
 		return hashValidatorV1x3Or4, nil
 	} else if isAnyVersion(version, v1_5, v1_6, v1_7) {
 		return hashValidatorV1x5to7, nil
-	} else if isAnyVersion(version, v1_8, v1_9) {
+	} else if isAnyVersion(version, v1_8, v1_9, v1_10) {
 		return hashValidatorV1x8OrLater, nil
 	}
 
@@ -716,7 +721,7 @@ func getDepositDataHashFunc(version string) (func(DepositData, ssz.HashWalker) e
 		return func(DepositData, ssz.HashWalker) error { return nil }, nil
 	} else if isAnyVersion(version, v1_6) {
 		return hashDepositDataV1x6, nil
-	} else if isAnyVersion(version, v1_7, v1_8, v1_9) {
+	} else if isAnyVersion(version, v1_7, v1_8, v1_9, v1_10) {
 		return hashDepositDataV1x7OrLater, nil
 	}
 
@@ -728,7 +733,7 @@ func getRegistrationHashFunc(version string) (func(BuilderRegistration, ssz.Hash
 	if isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5, v1_6) {
 		// Noop hash function for v1.0 to v1.6 that do not support builder registration.
 		return func(BuilderRegistration, ssz.HashWalker) error { return nil }, nil
-	} else if isAnyVersion(version, v1_7, v1_8, v1_9) {
+	} else if isAnyVersion(version, v1_7, v1_8, v1_9, v1_10) {
 		return hashBuilderRegistration, nil
 	}
 
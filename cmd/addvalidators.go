@@ -48,6 +48,8 @@ type addValidatorsConfig struct {
 	ManifestFile string // Path to the cluster manifest file
 	ClusterDir   string // Path to the cluster directory
 
+	LockVerify lockVerifyConfig // Verification of the legacy cluster lock file
+
 	TestConfig addValidatorTestConfig
 }
 
@@ -92,10 +94,11 @@ func bindAddValidatorsFlags(cmd *cobra.Command, config *addValidatorsConfig) {
 	cmd.Flags().StringVar(&config.Lockfile, "lock-file", "cluster-lock.json", "The path to the legacy cluster lock file defining distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", "cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringVar(&config.ClusterDir, "cluster-dir", ".charon/cluster", "The path to the charon cluster directory. This directory should contain directories for individual charon nodes, ie, node0, node1 and so on.")
+	bindLockVerifyFlags(cmd.Flags(), &config.LockVerify)
 }
 
 func runAddValidatorsSolo(ctx context.Context, conf addValidatorsConfig) (err error) {
-	rawDAG, err := loadDAG(conf)
+	rawDAG, err := loadDAG(ctx, conf)
 	if err != nil {
 		return err
 	}
@@ -244,7 +247,7 @@ func writeDepositDatas(ctx context.Context, clusterDir string, numOps int, secre
 
 	var depositDatas []eth2p0.DepositData
 	for i, val := range vals {
-		depositMsg, err := deposit.NewMessage(eth2p0.BLSPubKey(val.GetPublicKey()), val.GetWithdrawalAddress(), deposit.MaxDepositAmount)
+		depositMsg, err := deposit.NewMessage(eth2p0.BLSPubKey(val.GetPublicKey()), val.GetWithdrawalAddress(), deposit.MaxDepositAmount, false)
 		if err != nil {
 			return errors.Wrap(err, "new deposit message")
 		}
@@ -462,7 +465,7 @@ func genNewVals(numOps, threshold int, forkVersion []byte, conf addValidatorsCon
 }
 
 // loadDAG returns the raw DAG from the provided config.
-func loadDAG(conf addValidatorsConfig) (*manifestpb.SignedMutationList, error) {
+func loadDAG(ctx context.Context, conf addValidatorsConfig) (*manifestpb.SignedMutationList, error) {
 	var (
 		rawDAG *manifestpb.SignedMutationList
 		err    error
@@ -475,7 +478,7 @@ func loadDAG(conf addValidatorsConfig) (*manifestpb.SignedMutationList, error) {
 			return nil, err
 		}
 	} else {
-		rawDAG, err = loadDAGFromDisk(conf.ManifestFile, conf.Lockfile)
+		rawDAG, err = loadDAGFromDisk(ctx, conf.ManifestFile, conf.Lockfile, conf.LockVerify)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,44 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cmd/apiserver"
+)
+
+func newAPIServerCmd(runFunc func(context.Context, apiserver.Config) error) *cobra.Command {
+	var config apiserver.Config
+
+	cmd := &cobra.Command{
+		Use:   "api-server",
+		Short: "Start a self-hosted Obol API server",
+		Long:  "Starts an API server serving cluster lock publishing, cluster definition hosting and exit and deposit signature aggregation, backed by a local file store. It allows running the whole cluster coordination flow on premises.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.LogConfig); err != nil {
+				return err
+			}
+
+			printLicense(cmd.Context())
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.HTTPAddr, "http-address", "127.0.0.1:3650", "Listening address (ip and port) for the api server.")
+	cmd.Flags().StringVar(&config.DataDir, "data-dir", ".charon/api-server", "The directory where the api server persists cluster locks, definitions and partial signatures.")
+	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network of the clusters served. Options: mainnet, goerli, sepolia, holesky, gnosis, chiado.")
+	cmd.Flags().StringSliceVar(&config.BeaconNodeEndpoints, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs used to verify partial exit signatures. Exit aggregation is disabled if not specified.")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 30*time.Second, "Timeout for beacon node HTTP calls.")
+	bindExecutionClientRPCFlag(cmd.Flags(), &config.ExecutionClientRPC)
+	bindLogFlags(cmd.Flags(), &config.LogConfig)
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package apiserver implements a self-hosted subset of the Obol API, serving cluster lock publishing,
+// cluster definition hosting and partial exit and deposit aggregation backed by a local file store.
+package apiserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+// Config defines the config of the api server.
+type Config struct {
+	HTTPAddr            string
+	DataDir             string
+	Network             string
+	BeaconNodeEndpoints []string
+	BeaconNodeTimeout   time.Duration
+	ExecutionClientRPC  string
+	LogConfig           log.Config
+}
+
+// Run starts the api server and blocks until the context is cancelled.
+func Run(ctx context.Context, config Config) error {
+	ctx = log.WithTopic(ctx, "apiserver")
+
+	version.LogInfo(ctx, "Charon api server starting")
+
+	handler, err := newHandler(config)
+	if err != nil {
+		return err
+	}
+
+	server := http.Server{Addr: config.HTTPAddr, Handler: handler, ReadHeaderTimeout: time.Second}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	log.Info(ctx, "Api server started", z.Str("address", config.HTTPAddr), z.Str("data_dir", config.DataDir))
+
+	select {
+	case err := <-serverErr:
+		return errors.Wrap(err, "serve api")
+	case <-ctx.Done():
+		log.Info(ctx, "Shutting down")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil { //nolint:contextcheck // Parent context is cancelled.
+			return errors.Wrap(err, "shutdown api server")
+		}
+
+		return nil
+	}
+}
+
+// newHandler returns the api server http handler for the provided config.
+func newHandler(config Config) (http.Handler, error) {
+	store, err := newFileStore(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	forkVersion, err := eth2util.NetworkToForkVersionBytes(config.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &server{store: store, forkVersion: forkVersion}
+
+	if config.ExecutionClientRPC != "" {
+		s.contractVerifier = eth1wrap.NewClient(config.ExecutionClientRPC)
+	}
+
+	if len(config.BeaconNodeEndpoints) > 0 {
+		s.eth2Cl, err = eth2wrap.NewMultiHTTP(config.BeaconNodeTimeout, [4]byte(forkVersion), config.BeaconNodeEndpoints...)
+		if err != nil {
+			return nil, errors.Wrap(err, "create beacon node client")
+		}
+	}
+
+	return newRouter(s), nil
+}
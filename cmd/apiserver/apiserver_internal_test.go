@@ -0,0 +1,280 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestLockAndDefinition(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	srv := newTestServer(t, Config{DataDir: dataDir, Network: eth2util.Goerli.Name})
+
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, cl.PublishLock(ctx, lock))
+
+	t.Run("launchpad url", func(t *testing.T) {
+		resp, err := http.Get(cl.LaunchpadURLForLock(lock))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var actual cluster.Lock
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+		require.Equal(t, lock.LockHash, actual.LockHash)
+	})
+
+	t.Run("fetch definition", func(t *testing.T) {
+		def, err := cluster.FetchDefinition(ctx, fmt.Sprintf("%s/dv/0x%x", srv.URL, lock.ConfigHash))
+		require.NoError(t, err)
+		require.Equal(t, lock.Definition, def)
+	})
+
+	t.Run("publish definition", func(t *testing.T) {
+		other, _, _ := cluster.NewForT(t, 1, 3, 4, 1, rand.New(rand.NewSource(1)))
+
+		b, err := json.Marshal(other.Definition)
+		require.NoError(t, err)
+
+		resp, err := http.Post(srv.URL+"/dv", "application/json", bytes.NewReader(b))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		def, err := cluster.FetchDefinition(ctx, fmt.Sprintf("%s/dv/0x%x", srv.URL, other.ConfigHash))
+		require.NoError(t, err)
+		require.Equal(t, other.Definition, def)
+	})
+
+	t.Run("unknown definition", func(t *testing.T) {
+		_, err := cluster.FetchDefinition(ctx, srv.URL+"/dv/0x1234")
+		require.ErrorContains(t, err, "http error")
+	})
+
+	t.Run("invalid lock signatures", func(t *testing.T) {
+		invalid := lock
+		invalid.SignatureAggregate = bytes.Repeat([]byte{1}, 96)
+		invalid.LockHash = nil
+		invalid, err := invalid.SetLockHash()
+		require.NoError(t, err)
+
+		require.ErrorContains(t, cl.PublishLock(ctx, invalid), "http POST failed")
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		srv := newTestServer(t, Config{DataDir: dataDir, Network: eth2util.Goerli.Name})
+
+		def, err := cluster.FetchDefinition(ctx, fmt.Sprintf("%s/dv/0x%x", srv.URL, lock.ConfigHash))
+		require.NoError(t, err)
+		require.Equal(t, lock.Definition, def)
+	})
+}
+
+func TestExitFlow(t *testing.T) {
+	const kn = 4
+
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+
+	dataDir := t.TempDir()
+	srv := newTestServer(t, Config{
+		DataDir:             dataDir,
+		Network:             eth2util.Goerli.Name,
+		BeaconNodeEndpoints: []string{bmock.Address()},
+		BeaconNodeTimeout:   time.Second,
+	})
+
+	lock, identityKeys, shares := cluster.NewForT(t, 2, kn-1, kn, 0, rand.New(rand.NewSource(0)))
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, cl.PublishLock(ctx, lock))
+
+	eth2Cl, err := eth2wrap.NewMultiHTTP(time.Second, [4]byte(lock.ForkVersion), bmock.Address())
+	require.NoError(t, err)
+
+	msg := eth2p0.VoluntaryExit{Epoch: 42, ValidatorIndex: 42}
+	msgRoot, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	sigData, err := signing.GetDataRoot(ctx, eth2Cl, signing.DomainExit, msg.Epoch, msgRoot)
+	require.NoError(t, err)
+
+	partialExit := func(shareIdx int, msg eth2p0.VoluntaryExit) obolapi.ExitBlob {
+		sig, err := tbls.Sign(shares[0][shareIdx-1], sigData[:])
+		require.NoError(t, err)
+
+		return obolapi.ExitBlob{
+			PublicKey: lock.Validators[0].PublicKeyHex(),
+			SignedExitMessage: eth2p0.SignedVoluntaryExit{
+				Message:   &msg,
+				Signature: eth2p0.BLSSignature(sig),
+			},
+		}
+	}
+
+	// Invalid partial signature.
+	invalid := partialExit(1, msg)
+	invalid.SignedExitMessage.Signature = partialExit(2, msg).SignedExitMessage.Signature
+	require.ErrorContains(t, cl.PostPartialExits(ctx, lock.LockHash, 1, identityKeys[0], invalid), "http POST failed")
+
+	// Invalid identity signature.
+	require.ErrorContains(t, cl.PostPartialExits(ctx, lock.LockHash, 1, identityKeys[1], partialExit(1, msg)), "http POST failed")
+
+	// Requests with any invalid exit don't store any exits.
+	sig, err := tbls.Sign(shares[1][0], sigData[:])
+	require.NoError(t, err)
+	valid := obolapi.ExitBlob{
+		PublicKey: lock.Validators[1].PublicKeyHex(),
+		SignedExitMessage: eth2p0.SignedVoluntaryExit{
+			Message:   &msg,
+			Signature: eth2p0.BLSSignature(sig),
+		},
+	}
+	require.ErrorContains(t, cl.PostPartialExits(ctx, lock.LockHash, 1, identityKeys[0], valid, invalid), "http POST failed")
+	_, err = os.Stat(filepath.Join(dataDir, collExits))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	for shareIdx := 1; shareIdx < kn; shareIdx++ {
+		_, err := cl.GetFullExit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, uint64(shareIdx), identityKeys[shareIdx-1])
+		require.ErrorContains(t, err, "http GET failed")
+
+		require.NoError(t, cl.PostPartialExits(ctx, lock.LockHash, uint64(shareIdx), identityKeys[shareIdx-1], partialExit(shareIdx, msg)))
+	}
+
+	// Exit messages of all operators must be identical.
+	other := msg
+	other.Epoch++
+	require.ErrorContains(t, cl.PostPartialExits(ctx, lock.LockHash, kn, identityKeys[kn-1], partialExit(kn, other)), "http POST failed")
+
+	valPubkey, err := lock.Validators[0].PublicKey()
+	require.NoError(t, err)
+
+	for shareIdx := 1; shareIdx <= kn; shareIdx++ {
+		fullExit, err := cl.GetFullExit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, uint64(shareIdx), identityKeys[shareIdx-1])
+		require.NoError(t, err)
+		require.Equal(t, msg, *fullExit.SignedExitMessage.Message)
+		require.NoError(t, tbls.Verify(valPubkey, sigData[:], tbls.Signature(fullExit.SignedExitMessage.Signature)))
+	}
+
+	// Full exits require the operator's identity signature.
+	_, err = cl.GetFullExit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, 1, identityKeys[1])
+	require.ErrorContains(t, err, "http GET failed")
+}
+
+func TestExitWithoutBeaconNode(t *testing.T) {
+	ctx := context.Background()
+
+	srv := newTestServer(t, Config{DataDir: t.TempDir(), Network: eth2util.Goerli.Name})
+
+	lock, identityKeys, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, cl.PublishLock(ctx, lock))
+
+	exit := obolapi.ExitBlob{
+		PublicKey:         lock.Validators[0].PublicKeyHex(),
+		SignedExitMessage: eth2p0.SignedVoluntaryExit{Message: &eth2p0.VoluntaryExit{}},
+	}
+
+	require.ErrorContains(t, cl.PostPartialExits(ctx, lock.LockHash, 1, identityKeys[0], exit), "http POST failed")
+}
+
+func TestDepositFlow(t *testing.T) {
+	const kn = 4
+
+	ctx := context.Background()
+
+	srv := newTestServer(t, Config{DataDir: t.TempDir(), Network: eth2util.Goerli.Name})
+
+	lock, identityKeys, shares := cluster.NewForT(t, 1, kn-1, kn, 0, rand.New(rand.NewSource(0)))
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, cl.PublishLock(ctx, lock))
+
+	pubkey := eth2p0.BLSPubKey(lock.Validators[0].PubKey)
+	msg, err := deposit.NewMessage(pubkey, lock.ValidatorAddresses[0].WithdrawalAddress, deposit.MinDepositAmount, false)
+	require.NoError(t, err)
+
+	sigRoot, err := deposit.GetMessageSigningRoot(msg, eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	partialDeposit := func(shareIdx int, withdrawalCreds []byte) eth2p0.DepositData {
+		sig, err := tbls.Sign(shares[0][shareIdx-1], sigRoot[:])
+		require.NoError(t, err)
+
+		return eth2p0.DepositData{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: withdrawalCreds,
+			Amount:                msg.Amount,
+			Signature:             eth2p0.BLSSignature(sig),
+		}
+	}
+
+	// Withdrawal credentials must match the cluster lock.
+	require.ErrorContains(t, cl.PostPartialDeposits(ctx, lock.LockHash, 1, identityKeys[0], partialDeposit(1, make([]byte, 32))), "http POST failed")
+
+	for shareIdx := 1; shareIdx < kn; shareIdx++ {
+		_, err := cl.GetFullDeposit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, uint64(shareIdx), identityKeys[shareIdx-1])
+		require.ErrorContains(t, err, "http GET failed")
+
+		require.NoError(t, cl.PostPartialDeposits(ctx, lock.LockHash, uint64(shareIdx), identityKeys[shareIdx-1], partialDeposit(shareIdx, msg.WithdrawalCredentials)))
+	}
+
+	valPubkey, err := lock.Validators[0].PublicKey()
+	require.NoError(t, err)
+
+	depositDatas, err := cl.GetFullDeposit(ctx, lock.Validators[0].PublicKeyHex(), lock.LockHash, kn, identityKeys[kn-1])
+	require.NoError(t, err)
+	require.Len(t, depositDatas, 1)
+	require.Equal(t, msg.WithdrawalCredentials, depositDatas[0].WithdrawalCredentials)
+	require.Equal(t, msg.Amount, depositDatas[0].Amount)
+	require.NoError(t, tbls.Verify(valPubkey, sigRoot[:], tbls.Signature(depositDatas[0].Signature)))
+}
+
+func newTestServer(t *testing.T, config Config) *httptest.Server {
+	t.Helper()
+
+	handler, err := newHandler(config)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
@@ -0,0 +1,15 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package apiserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var requestCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "apiserver",
+	Name:      "request_total",
+	Help:      "Total number of api server requests by endpoint and http status code",
+}, []string{"endpoint", "status_code"})
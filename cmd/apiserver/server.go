@@ -0,0 +1,619 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	collLocks       = "locks"
+	collDefinitions = "definitions"
+	collExits       = "exits"
+	collDeposits    = "deposits"
+
+	// maxBodySize is the maximum request body size, large enough for cluster locks with thousands of validators.
+	maxBodySize = 64 << 20
+)
+
+// apiError is an error with a http status code returned to the client.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (a apiError) Error() string {
+	return fmt.Sprintf("api error[status=%d,msg=%s]", a.StatusCode, a.Message)
+}
+
+// errResponse is the json error response body.
+type errResponse struct {
+	Message string `json:"message"`
+}
+
+// storedExits are the partial exits of a validator by share index.
+type storedExits map[uint64]obolapi.ExitBlob
+
+// storedDeposits are the partial deposit data of a validator by amount and share index.
+type storedDeposits map[eth2p0.Gwei]map[uint64]*eth2p0.DepositData
+
+// server implements the Obol API endpoints used by charon.
+type server struct {
+	store *fileStore
+	// eth2Cl is the optional beacon node client used to verify exits, it is nil if not configured.
+	eth2Cl eth2wrap.Client
+	// forkVersion is the genesis fork version of the beacon node network.
+	forkVersion []byte
+	// contractVerifier is the optional ERC-1271 contract signature verifier, it is nil if not configured.
+	contractVerifier cluster.ContractSigVerifier
+}
+
+// verifyOptions returns the cluster signature verification options of the request.
+func (s *server) verifyOptions(r *http.Request) []cluster.VerifyOption {
+	if s.contractVerifier == nil {
+		return nil
+	}
+
+	return []cluster.VerifyOption{cluster.WithContractSigVerifier(r.Context(), s.contractVerifier)}
+}
+
+// newRouter returns the http router serving the Obol API endpoints.
+func newRouter(s *server) *mux.Router {
+	r := mux.NewRouter()
+
+	r.Handle("/lock", wrap("publish_lock", s.publishLock)).Methods(http.MethodPost)
+	r.Handle("/lock/{lock_hash}", wrap("get_lock", s.getLock)).Methods(http.MethodGet)
+	r.Handle("/lock/{lock_hash}/launchpad", wrap("get_lock", s.getLock)).Methods(http.MethodGet)
+	r.Handle("/dv", wrap("publish_definition", s.publishDefinition)).Methods(http.MethodPost)
+	r.Handle("/dv/{config_hash}", wrap("get_definition", s.getDefinition)).Methods(http.MethodGet)
+	r.Handle("/exp/partial_exits/{lock_hash}", wrap("partial_exits", s.postPartialExits)).Methods(http.MethodPost)
+	r.Handle("/exp/exit/{lock_hash}/{share_index}/{validator_pubkey}", wrap("full_exit", s.getFullExit)).Methods(http.MethodGet)
+	r.Handle("/exp/partial_deposits/{lock_hash}", wrap("partial_deposits", s.postPartialDeposits)).Methods(http.MethodPost)
+	r.Handle("/exp/deposit/{lock_hash}/{share_index}/{validator_pubkey}", wrap("full_deposit", s.getFullDeposit)).Methods(http.MethodGet)
+
+	return r
+}
+
+// handlerFunc is a http handler returning an optional json response or an error.
+type handlerFunc func(r *http.Request, body []byte) (any, error)
+
+// wrap returns a http handler by wrapping the provided function with body reading, error handling and json encoding.
+func wrap(endpoint string, fn handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := log.WithCtx(r.Context(), z.Str("endpoint", endpoint))
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			writeError(ctx, w, endpoint, apiError{StatusCode: http.StatusBadRequest, Message: "read body"})
+			return
+		}
+
+		resp, err := fn(r.WithContext(ctx), body)
+		if err != nil {
+			writeError(ctx, w, endpoint, err)
+			return
+		}
+
+		requestCounter.WithLabelValues(endpoint, strconv.Itoa(http.StatusOK)).Inc()
+
+		if resp == nil {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error(ctx, "Failed writing api response", err)
+		}
+	})
+}
+
+// writeError writes the error as json response.
+func writeError(ctx context.Context, w http.ResponseWriter, endpoint string, err error) {
+	var aerr apiError
+	if !errors.As(err, &aerr) {
+		log.Error(ctx, "Internal api server error", err)
+		aerr = apiError{StatusCode: http.StatusInternalServerError, Message: "internal server error"}
+	}
+
+	requestCounter.WithLabelValues(endpoint, strconv.Itoa(aerr.StatusCode)).Inc()
+
+	b, _ := json.Marshal(errResponse{Message: aerr.Message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(aerr.StatusCode)
+	_, _ = w.Write(b)
+}
+
+func badRequest(msg string) error {
+	return apiError{StatusCode: http.StatusBadRequest, Message: msg}
+}
+
+func notFound(msg string) error {
+	return apiError{StatusCode: http.StatusNotFound, Message: msg}
+}
+
+// publishLock verifies and stores the cluster lock and its definition.
+func (s *server) publishLock(r *http.Request, body []byte) (any, error) {
+	var lock cluster.Lock
+	if err := json.Unmarshal(body, &lock); err != nil {
+		return nil, badRequest("invalid cluster lock")
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return nil, badRequest("invalid cluster lock hashes")
+	}
+
+	if err := lock.VerifySignatures(s.verifyOptions(r)...); err != nil {
+		return nil, badRequest("invalid cluster lock signatures")
+	}
+
+	if err := s.store.Put(lock.Definition, collDefinitions, to0x(lock.ConfigHash)); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Put(lock, collLocks, to0x(lock.LockHash)); err != nil {
+		return nil, err
+	}
+
+	log.Info(r.Context(), "Published cluster lock", z.Str("lock_hash", to0x(lock.LockHash)), z.Str("name", lock.Name))
+
+	return nil, nil
+}
+
+func (s *server) getLock(r *http.Request, _ []byte) (any, error) {
+	lock, err := s.lock(mux.Vars(r)["lock_hash"])
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// publishDefinition verifies and stores a cluster definition, allowing operators to fetch it before the DKG.
+func (s *server) publishDefinition(r *http.Request, body []byte) (any, error) {
+	var def cluster.Definition
+	if err := json.Unmarshal(body, &def); err != nil {
+		return nil, badRequest("invalid cluster definition")
+	}
+
+	if err := def.VerifyHashes(); err != nil {
+		return nil, badRequest("invalid cluster definition hashes")
+	}
+
+	if err := def.VerifySignatures(s.verifyOptions(r)...); err != nil {
+		return nil, badRequest("invalid cluster definition signatures")
+	}
+
+	if err := s.store.Put(def, collDefinitions, to0x(def.ConfigHash)); err != nil {
+		return nil, err
+	}
+
+	log.Info(r.Context(), "Published cluster definition", z.Str("config_hash", to0x(def.ConfigHash)), z.Str("name", def.Name))
+
+	return nil, nil
+}
+
+func (s *server) getDefinition(r *http.Request, _ []byte) (any, error) {
+	var def cluster.Definition
+	if err := s.store.Get(&def, collDefinitions, mux.Vars(r)["config_hash"]); errors.Is(err, errNotFound) {
+		return nil, notFound("definition not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// postPartialExits verifies and stores partial exits signed by a single operator.
+func (s *server) postPartialExits(r *http.Request, body []byte) (any, error) {
+	if s.eth2Cl == nil {
+		return nil, apiError{StatusCode: http.StatusNotImplemented, Message: "exit aggregation requires a beacon node"}
+	}
+
+	lock, err := s.lock(mux.Vars(r)["lock_hash"])
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(lock.ForkVersion, s.forkVersion) {
+		return nil, badRequest("cluster network not supported by api server")
+	}
+
+	var req obolapi.PartialExitRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, badRequest("invalid body")
+	}
+
+	root, err := req.HashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid partial exits")
+	}
+
+	if err := verifyOperatorSignature(lock, req.ShareIdx, req.Signature, root[:]); err != nil {
+		return nil, err
+	}
+
+	// Verify all exits before storing any, so rejected requests don't store partial state.
+	// Exits are grouped by validator, so each validator's stored exits are updated once.
+	var (
+		keys    [][]string
+		blobs   [][]obolapi.ExitBlob
+		keyIdxs = make(map[string]int)
+	)
+	for _, exit := range req.PartialExits {
+		if exit.SignedExitMessage.Message == nil {
+			return nil, badRequest("missing exit message")
+		}
+
+		pubshare, err := validatorPubShare(lock, exit.PublicKey, req.ShareIdx)
+		if err != nil {
+			return nil, err
+		}
+
+		msgRoot, err := exit.SignedExitMessage.Message.HashTreeRoot()
+		if err != nil {
+			return nil, badRequest("invalid exit message")
+		}
+
+		sigData, err := signing.GetDataRoot(r.Context(), s.eth2Cl, signing.DomainExit, exit.SignedExitMessage.Message.Epoch, msgRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tbls.Verify(pubshare, sigData[:], tbls.Signature(exit.SignedExitMessage.Signature)); err != nil {
+			return nil, badRequest("invalid partial exit signature: " + exit.PublicKey)
+		}
+
+		key := strings.ToLower(exit.PublicKey)
+		idx, ok := keyIdxs[key]
+		if !ok {
+			idx = len(keys)
+			keyIdxs[key] = idx
+			keys = append(keys, []string{to0x(lock.LockHash), key})
+			blobs = append(blobs, nil)
+		}
+		blobs[idx] = append(blobs[idx], exit)
+	}
+
+	exits := make([]storedExits, len(keys))
+	vs := make([]any, len(keys))
+	for i := range exits {
+		vs[i] = &exits[i]
+	}
+
+	err = s.store.UpdateAll(vs, func(i int) error {
+		if exits[i] == nil {
+			exits[i] = make(storedExits)
+		}
+
+		for _, exit := range blobs[i] {
+			for shareIdx, other := range exits[i] {
+				if shareIdx != req.ShareIdx && *other.SignedExitMessage.Message != *exit.SignedExitMessage.Message {
+					return badRequest("partial exit message differs from other operators: " + exit.PublicKey)
+				}
+			}
+
+			exits[i][req.ShareIdx] = exit
+		}
+
+		return nil
+	}, collExits, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(r.Context(), "Stored partial exits", z.U64("share_idx", req.ShareIdx), z.Int("exits", len(req.PartialExits)))
+
+	return nil, nil
+}
+
+// getFullExit returns the partial exit signatures of a validator once threshold operators submitted them.
+func (s *server) getFullExit(r *http.Request, _ []byte) (any, error) {
+	lock, valPubkey, err := s.authFullRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var exits storedExits
+	if err := s.store.Get(&exits, collExits, to0x(lock.LockHash), valPubkey); errors.Is(err, errNotFound) {
+		return nil, notFound("no partial exits for validator")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(exits) < lock.Threshold {
+		return nil, apiError{StatusCode: http.StatusUnauthorized, Message: "not enough partial exits stored"}
+	}
+
+	resp := obolapi.FullExitResponse{Signatures: make([]string, len(lock.Operators))}
+	for shareIdx, exit := range exits {
+		resp.Signatures[shareIdx-1] = to0x(exit.SignedExitMessage.Signature[:])
+		resp.Epoch = strconv.FormatUint(uint64(exit.SignedExitMessage.Message.Epoch), 10)
+		resp.ValidatorIndex = exit.SignedExitMessage.Message.ValidatorIndex
+	}
+
+	return resp, nil
+}
+
+// postPartialDeposits verifies and stores partial deposit data signed by a single operator.
+func (s *server) postPartialDeposits(r *http.Request, body []byte) (any, error) {
+	lock, err := s.lock(mux.Vars(r)["lock_hash"])
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return nil, badRequest("unknown cluster network")
+	}
+
+	var req obolapi.PartialDepositRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, badRequest("invalid body")
+	}
+
+	root, err := req.HashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid partial deposits")
+	}
+
+	if err := verifyOperatorSignature(lock, req.ShareIdx, req.Signature, root[:]); err != nil {
+		return nil, err
+	}
+
+	// Verify all deposits before storing any, so rejected requests don't store partial state.
+	// Deposits are grouped by validator, so each validator's stored deposits are updated once.
+	var (
+		keys    [][]string
+		datas   [][]eth2p0.DepositData
+		keyIdxs = make(map[string]int)
+	)
+	for _, dd := range req.PartialDepositData {
+		valIdx, pubshare, err := validatorIndexAndPubShare(lock, dd.PublicKey.String(), req.ShareIdx)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs := lock.ValidatorAddresses[valIdx]
+		msg, err := deposit.NewMessage(dd.PublicKey, addrs.WithdrawalAddress, dd.Amount, addrs.Compounding)
+		if err != nil {
+			return nil, badRequest("invalid deposit amount")
+		}
+
+		if !bytes.Equal(msg.WithdrawalCredentials, dd.WithdrawalCredentials) {
+			return nil, badRequest("withdrawal credentials don't match cluster lock")
+		}
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tbls.Verify(pubshare, sigRoot[:], tbls.Signature(dd.Signature)); err != nil {
+			return nil, badRequest("invalid partial deposit signature: " + dd.PublicKey.String())
+		}
+
+		key := dd.PublicKey.String()
+		idx, ok := keyIdxs[key]
+		if !ok {
+			idx = len(keys)
+			keyIdxs[key] = idx
+			keys = append(keys, []string{to0x(lock.LockHash), key})
+			datas = append(datas, nil)
+		}
+		datas[idx] = append(datas[idx], dd)
+	}
+
+	deposits := make([]storedDeposits, len(keys))
+	vs := make([]any, len(keys))
+	for i := range deposits {
+		vs[i] = &deposits[i]
+	}
+
+	err = s.store.UpdateAll(vs, func(i int) error {
+		if deposits[i] == nil {
+			deposits[i] = make(storedDeposits)
+		}
+
+		for _, dd := range datas[i] {
+			if deposits[i][dd.Amount] == nil {
+				deposits[i][dd.Amount] = make(map[uint64]*eth2p0.DepositData)
+			}
+			deposits[i][dd.Amount][req.ShareIdx] = &dd
+		}
+
+		return nil
+	}, collDeposits, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(r.Context(), "Stored partial deposits", z.U64("share_idx", req.ShareIdx), z.Int("deposits", len(req.PartialDepositData)))
+
+	return nil, nil
+}
+
+// getFullDeposit returns the partial deposit signatures of all amounts of a validator submitted by threshold operators.
+func (s *server) getFullDeposit(r *http.Request, _ []byte) (any, error) {
+	lock, valPubkey, err := s.authFullRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var deposits storedDeposits
+	if err := s.store.Get(&deposits, collDeposits, to0x(lock.LockHash), valPubkey); errors.Is(err, errNotFound) {
+		return nil, notFound("no partial deposits for validator")
+	} else if err != nil {
+		return nil, err
+	}
+
+	amounts := slices.Collect(maps.Keys(deposits))
+	slices.Sort(amounts)
+
+	resp := obolapi.FullDepositResponse{PublicKey: valPubkey}
+	for _, amount := range amounts {
+		partials := deposits[amount]
+		if len(partials) < lock.Threshold {
+			continue
+		}
+
+		sigs := make([]string, len(lock.Operators))
+		for shareIdx, dd := range partials {
+			sigs[shareIdx-1] = to0x(dd.Signature[:])
+			resp.WithdrawalCredentials = to0x(dd.WithdrawalCredentials)
+		}
+
+		resp.Amounts = append(resp.Amounts, obolapi.FullDepositAmount{
+			Amount:     strconv.FormatUint(uint64(amount), 10),
+			Signatures: sigs,
+		})
+	}
+
+	if len(resp.Amounts) == 0 {
+		return nil, apiError{StatusCode: http.StatusUnauthorized, Message: "not enough partial deposits stored"}
+	}
+
+	return resp, nil
+}
+
+// authFullRequest authenticates full exit and deposit requests, returning the cluster lock and the normalised validator public key.
+// The bearer token must be the operator's identity key signature of the auth blob hash tree root.
+func (s *server) authFullRequest(r *http.Request) (cluster.Lock, string, error) {
+	vars := mux.Vars(r)
+
+	lock, err := s.lock(vars["lock_hash"])
+	if err != nil {
+		return cluster.Lock{}, "", err
+	}
+
+	shareIdx, err := strconv.ParseUint(vars["share_index"], 10, 64)
+	if err != nil {
+		return cluster.Lock{}, "", badRequest("malformed share index")
+	}
+
+	valPubkey, err := from0x(vars["validator_pubkey"], 48)
+	if err != nil {
+		return cluster.Lock{}, "", badRequest("invalid validator public key")
+	}
+
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return cluster.Lock{}, "", apiError{StatusCode: http.StatusUnauthorized, Message: "missing authorization header"}
+	}
+
+	sig, err := from0x(strings.TrimSpace(bearer), 65)
+	if err != nil {
+		return cluster.Lock{}, "", badRequest("bearer token must be hex-encoded")
+	}
+
+	// Exit and deposit auth blobs have identical schemas.
+	root, err := obolapi.FullExitAuthBlob{
+		LockHash:        lock.LockHash,
+		ValidatorPubkey: valPubkey,
+		ShareIndex:      shareIdx,
+	}.HashTreeRoot()
+	if err != nil {
+		return cluster.Lock{}, "", err
+	}
+
+	if err := verifyOperatorSignature(lock, shareIdx, sig, root[:]); err != nil {
+		return cluster.Lock{}, "", err
+	}
+
+	return lock, to0x(valPubkey), nil
+}
+
+// lock returns the stored cluster lock by lock hash.
+func (s *server) lock(lockHash string) (cluster.Lock, error) {
+	var lock cluster.Lock
+	if err := s.store.Get(&lock, collLocks, lockHash); errors.Is(err, errNotFound) {
+		return cluster.Lock{}, notFound("lock not found")
+	} else if err != nil {
+		return cluster.Lock{}, err
+	}
+
+	return lock, nil
+}
+
+// verifyOperatorSignature verifies that sig of hash was created with the identity key of the operator with the provided share index.
+func verifyOperatorSignature(lock cluster.Lock, shareIdx uint64, sig, hash []byte) error {
+	if shareIdx == 0 || shareIdx > uint64(len(lock.Operators)) {
+		return badRequest("invalid share index")
+	}
+
+	opENR, err := enr.Parse(lock.Operators[shareIdx-1].ENR)
+	if err != nil {
+		return errors.Wrap(err, "parse operator enr")
+	}
+
+	verified, err := k1util.Verify65(opENR.PubKey, hash, sig)
+	if err != nil || !verified {
+		return apiError{StatusCode: http.StatusUnauthorized, Message: "invalid operator signature"}
+	}
+
+	return nil
+}
+
+// validatorPubShare returns the public key share of the validator in the lock for the provided share index.
+func validatorPubShare(lock cluster.Lock, pubkey string, shareIdx uint64) (tbls.PublicKey, error) {
+	_, pubshare, err := validatorIndexAndPubShare(lock, pubkey, shareIdx)
+	return pubshare, err
+}
+
+// validatorIndexAndPubShare returns the index of the validator in the lock and its public key share for the provided share index.
+func validatorIndexAndPubShare(lock cluster.Lock, pubkey string, shareIdx uint64) (int, tbls.PublicKey, error) {
+	for i, val := range lock.Validators {
+		if strings.EqualFold(val.PublicKeyHex(), pubkey) {
+			pubshare, err := val.PublicShare(int(shareIdx) - 1)
+			if err != nil {
+				return 0, tbls.PublicKey{}, badRequest("invalid validator public share")
+			}
+
+			return i, pubshare, nil
+		}
+	}
+
+	return 0, tbls.PublicKey{}, badRequest("validator not found in cluster lock: " + pubkey)
+}
+
+func to0x(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// from0x decodes hex-encoded data and expects it to be exactly of len(length).
+func from0x(data string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode hex")
+	} else if len(b) != length {
+		return nil, errors.New("invalid hex length", z.Int("expect", length), z.Int("actual", len(b)))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,162 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package apiserver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// errNotFound is returned by the store if the requested object doesn't exist.
+var errNotFound = errors.New("not found")
+
+// fileStore is a simple persistent json object store backed by files in a directory.
+// Objects are identified by a collection and a list of keys, which map to nested directories and a file.
+type fileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// newFileStore returns a new file store persisting objects in the provided directory.
+func newFileStore(dir string) (*fileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create store directory", z.Str("dir", dir))
+	}
+
+	return &fileStore{dir: dir}, nil
+}
+
+// path returns the file path of the object identified by the collection and keys.
+func (s *fileStore) path(collection string, keys ...string) (string, error) {
+	elems := []string{s.dir, collection}
+	for _, key := range keys {
+		if key == "" || strings.ContainsAny(key, `/\.`) {
+			return "", errors.New("invalid store key", z.Str("key", key))
+		}
+		elems = append(elems, strings.ToLower(key))
+	}
+
+	return filepath.Join(elems...) + ".json", nil
+}
+
+// Get unmarshals the object identified by the collection and keys into v.
+// It returns errNotFound if the object doesn't exist.
+func (s *fileStore) Get(v any, collection string, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(v, collection, keys...)
+}
+
+func (s *fileStore) get(v any, collection string, keys ...string) error {
+	path, err := s.path(collection, keys...)
+	if err != nil {
+		// Objects with invalid keys can never be stored.
+		return errNotFound
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "read store file")
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrap(err, "unmarshal store file", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// Put stores v as the object identified by the collection and keys, replacing any existing object.
+func (s *fileStore) Put(v any, collection string, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.put(v, collection, keys...)
+}
+
+func (s *fileStore) put(v any, collection string, keys ...string) error {
+	path, err := s.path(collection, keys...)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal store object")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(err, "create store directory")
+	}
+
+	// Write to a temporary file first and rename it, ensuring objects are never partially written.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil { //nolint:gosec // Objects are public data.
+		return errors.Wrap(err, "write store file")
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(err, "rename store file")
+	}
+
+	return nil
+}
+
+// Update atomically applies fn to the object identified by the collection and keys and stores the result.
+// The found argument of fn is false if the object didn't exist yet.
+func (s *fileStore) Update(v any, fn func(found bool) error, collection string, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := true
+	if err := s.get(v, collection, keys...); errors.Is(err, errNotFound) {
+		found = false
+	} else if err != nil {
+		return err
+	}
+
+	if err := fn(found); err != nil {
+		return err
+	}
+
+	return s.put(v, collection, keys...)
+}
+
+// UpdateAll atomically applies fn to the objects identified by the collection and each of the keys and stores the results.
+// The objects are unmarshalled into the respective vs and left unchanged if they don't exist yet.
+// Results are only stored if fn succeeds for all objects, so a failed update doesn't store any object.
+func (s *fileStore) UpdateAll(vs []any, fn func(i int) error, collection string, keys [][]string) error {
+	if len(vs) != len(keys) {
+		return errors.New("bug: mismatching store objects and keys")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, v := range vs {
+		if err := s.get(v, collection, keys[i]...); err != nil && !errors.Is(err, errNotFound) {
+			return err
+		}
+
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+
+	for i, v := range vs {
+		if err := s.put(v, collection, keys[i]...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
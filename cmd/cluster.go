@@ -0,0 +1,286 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const (
+	clusterFileDefinition = "definition"
+	clusterFileLock       = "lock"
+	clusterFileManifest   = "manifest"
+
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+func newClusterCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect cluster definition, lock and manifest files",
+		Long:  "Inspect cluster definition, lock and manifest files by reporting semantic differences between them or risky settings.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindOutputFormatFlag binds the output format flag and validates its value.
+func bindOutputFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVar(format, "format", outputFormatText, "Output format, either text or json.")
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if *format != outputFormatText && *format != outputFormatJSON {
+			return errors.New("invalid output format", z.Str("format", *format))
+		}
+
+		return nil
+	})
+}
+
+// clusterSummary is a normalised view of a cluster definition, lock or manifest file.
+type clusterSummary struct {
+	Path              string             `json:"path"`
+	FileType          string             `json:"file_type"`
+	Name              string             `json:"name"`
+	Version           string             `json:"version,omitempty"`
+	Threshold         int                `json:"threshold"`
+	ForkVersion       string             `json:"fork_version"`
+	ConsensusProtocol string             `json:"consensus_protocol,omitempty"`
+	NumValidators     int                `json:"num_validators"`
+	Operators         []operatorSummary  `json:"operators"`
+	Validators        []validatorSummary `json:"validators"`
+	// VerifyErrors are the hash and signature verification errors of locks and definitions.
+	VerifyErrors []string `json:"verify_errors,omitempty"`
+}
+
+type operatorSummary struct {
+	Address string `json:"address"`
+	ENR     string `json:"enr"`
+}
+
+type validatorSummary struct {
+	// PublicKey is the validator public key, it is empty for cluster definitions.
+	PublicKey           string `json:"public_key,omitempty"`
+	FeeRecipientAddress string `json:"fee_recipient_address"`
+	WithdrawalAddress   string `json:"withdrawal_address"`
+	Compounding         bool   `json:"compounding,omitempty"`
+	BuilderRegistration bool   `json:"builder_registration"`
+}
+
+// id returns the identifier of the validator at index idx, its public key unless byIndex is true.
+func (v validatorSummary) id(idx int, byIndex bool) string {
+	if !byIndex {
+		return v.PublicKey
+	}
+
+	return fmt.Sprintf("validator %d", idx)
+}
+
+// loadClusterSummary loads a cluster definition, lock or protobuf manifest file without failing on
+// verification errors, which are returned as part of the summary instead.
+func loadClusterSummary(ctx context.Context, path string, executionClientRPC string) (clusterSummary, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return clusterSummary{}, errors.Wrap(err, "read file", z.Str("path", path))
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return loadManifestSummary(path, b, verifyOptions(ctx, executionClientRPC)...)
+	}
+
+	if _, ok := fields["lock_hash"]; ok {
+		return loadLockSummary(path, b, verifyOptions(ctx, executionClientRPC)...)
+	}
+
+	return loadDefinitionSummary(path, b, verifyOptions(ctx, executionClientRPC)...)
+}
+
+func loadLockSummary(path string, b []byte, opts ...cluster.VerifyOption) (clusterSummary, error) {
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return clusterSummary{}, errors.Wrap(err, "unmarshal cluster lock", z.Str("path", path))
+	}
+
+	legacy, err := manifest.NewRawLegacyLock(b)
+	if err != nil {
+		return clusterSummary{}, err
+	}
+
+	cl, err := manifest.Materialise(&manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}})
+	if err != nil {
+		return clusterSummary{}, errors.Wrap(err, "materialise cluster lock", z.Str("path", path))
+	}
+
+	resp := summaryFromManifest(path, clusterFileLock, cl)
+	resp.Version = lock.Version
+	resp.VerifyErrors = verifyErrors(lock.VerifyHashes(), lock.VerifySignatures(opts...))
+
+	setLockCompounding(resp.Validators, lock)
+
+	return resp, nil
+}
+
+func loadDefinitionSummary(path string, b []byte, opts ...cluster.VerifyOption) (clusterSummary, error) {
+	var def cluster.Definition
+	if err := json.Unmarshal(b, &def); err != nil {
+		return clusterSummary{}, errors.Wrap(err, "unmarshal cluster definition", z.Str("path", path))
+	}
+
+	resp := clusterSummary{
+		Path:              path,
+		FileType:          clusterFileDefinition,
+		Name:              def.Name,
+		Version:           def.Version,
+		Threshold:         def.Threshold,
+		ForkVersion:       fmt.Sprintf("%#x", def.ForkVersion),
+		ConsensusProtocol: def.ConsensusProtocol,
+		NumValidators:     def.NumValidators,
+		VerifyErrors:      verifyErrors(def.VerifyHashes(), def.VerifySignatures(opts...)),
+	}
+
+	for _, op := range def.Operators {
+		resp.Operators = append(resp.Operators, operatorSummary{Address: op.Address, ENR: op.ENR})
+	}
+
+	for _, addrs := range def.ValidatorAddresses {
+		resp.Validators = append(resp.Validators, validatorSummary{
+			FeeRecipientAddress: addrs.FeeRecipientAddress,
+			WithdrawalAddress:   addrs.WithdrawalAddress,
+			Compounding:         addrs.Compounding,
+		})
+	}
+
+	return resp, nil
+}
+
+func loadManifestSummary(path string, b []byte, opts ...cluster.VerifyOption) (clusterSummary, error) {
+	dag := new(manifestpb.SignedMutationList)
+	if err := proto.Unmarshal(b, dag); err != nil {
+		return clusterSummary{}, errors.Wrap(err, "file is neither a json cluster definition or lock, nor a protobuf cluster manifest", z.Str("path", path))
+	}
+
+	cl, err := manifest.Materialise(dag)
+	if err != nil {
+		return clusterSummary{}, errors.Wrap(err, "materialise cluster manifest", z.Str("path", path))
+	}
+
+	resp := summaryFromManifest(path, clusterFileManifest, cl)
+
+	// Manifests don't include a version or compounding, so use the initial legacy lock's.
+	// Materialise doesn't verify the legacy lock's signatures, so verify it like lock files.
+	lock, ok := legacyLockFromDAG(dag)
+	if !ok {
+		resp.VerifyErrors = []string{"cluster manifest doesn't start with a valid legacy cluster lock"}
+		return resp, nil
+	}
+
+	resp.Version = lock.Version
+	resp.VerifyErrors = verifyErrors(lock.VerifyHashes(), lock.VerifySignatures(opts...))
+	setLockCompounding(resp.Validators, lock)
+
+	return resp, nil
+}
+
+// setLockCompounding sets the compounding flag of the validators present in the lock.
+func setLockCompounding(vals []validatorSummary, lock cluster.Lock) {
+	for i, val := range lock.Validators {
+		if i >= len(lock.ValidatorAddresses) {
+			break
+		}
+
+		for j := range vals {
+			if strings.EqualFold(vals[j].PublicKey, val.PublicKeyHex()) {
+				vals[j].Compounding = lock.ValidatorAddresses[i].Compounding
+			}
+		}
+	}
+}
+
+// summaryFromManifest returns a cluster summary of the materialised cluster manifest.
+func summaryFromManifest(path, fileType string, cl *manifestpb.Cluster) clusterSummary {
+	resp := clusterSummary{
+		Path:              path,
+		FileType:          fileType,
+		Name:              cl.GetName(),
+		Threshold:         int(cl.GetThreshold()),
+		ForkVersion:       fmt.Sprintf("%#x", cl.GetForkVersion()),
+		ConsensusProtocol: cl.GetConsensusProtocol(),
+		NumValidators:     len(cl.GetValidators()),
+	}
+
+	for _, op := range cl.GetOperators() {
+		resp.Operators = append(resp.Operators, operatorSummary{Address: op.GetAddress(), ENR: op.GetEnr()})
+	}
+
+	for _, val := range cl.GetValidators() {
+		resp.Validators = append(resp.Validators, validatorSummary{
+			PublicKey:           manifest.ValidatorPublicKeyHex(val),
+			FeeRecipientAddress: val.GetFeeRecipientAddress(),
+			WithdrawalAddress:   val.GetWithdrawalAddress(),
+			BuilderRegistration: len(val.GetBuilderRegistrationJson()) > 0,
+		})
+	}
+
+	return resp
+}
+
+// network returns the network name of the cluster or its fork version if unknown.
+func (s clusterSummary) network() string {
+	forkVersion, err := hex.DecodeString(strings.TrimPrefix(s.ForkVersion, "0x"))
+	if err != nil {
+		return s.ForkVersion
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(forkVersion)
+	if err != nil {
+		return s.ForkVersion
+	}
+
+	return network
+}
+
+// verifyErrors returns the non-nil error messages.
+func verifyErrors(errs ...error) []string {
+	var resp []string
+	for _, err := range errs {
+		if err != nil {
+			resp = append(resp, err.Error())
+		}
+	}
+
+	return resp
+}
+
+// writeJSON writes v as indented json to w.
+func writeJSON(w io.Writer, v any) error {
+	b, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal output")
+	}
+
+	if _, err := fmt.Fprintln(w, string(b)); err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	return nil
+}
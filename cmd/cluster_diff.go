@@ -0,0 +1,246 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+type clusterDiffConfig struct {
+	PathA              string
+	PathB              string
+	Format             string
+	ExecutionClientRPC string
+}
+
+// clusterDifference is a semantic difference between two cluster files.
+type clusterDifference struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+func newClusterDiffCmd(runFunc func(context.Context, io.Writer, clusterDiffConfig) error) *cobra.Command {
+	var config clusterDiffConfig
+
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Report semantic differences between two cluster files",
+		Long: "Reports semantic differences between two cluster definition, lock or manifest files, " +
+			"e.g., changed validators, fee recipient and withdrawal addresses, operators, operator order or threshold.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.PathA = args[0]
+			config.PathB = args[1]
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	bindOutputFormatFlag(cmd, &config.Format)
+	bindExecutionClientRPCFlag(cmd.Flags(), &config.ExecutionClientRPC)
+
+	return cmd
+}
+
+func runClusterDiff(ctx context.Context, w io.Writer, config clusterDiffConfig) error {
+	a, err := loadClusterSummary(ctx, config.PathA, config.ExecutionClientRPC)
+	if err != nil {
+		return err
+	}
+
+	b, err := loadClusterSummary(ctx, config.PathB, config.ExecutionClientRPC)
+	if err != nil {
+		return err
+	}
+
+	diffs := diffClusters(a, b)
+
+	if config.Format == outputFormatJSON {
+		return writeJSON(w, struct {
+			A           string              `json:"a"`
+			B           string              `json:"b"`
+			Differences []clusterDifference `json:"differences"`
+		}{A: a.Path, B: b.Path, Differences: diffs})
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "--- %s (%s)\n+++ %s (%s)\n", a.Path, a.FileType, b.Path, b.FileType)
+
+	if len(diffs) == 0 {
+		sb.WriteString("No differences found\n")
+	}
+
+	for _, diff := range diffs {
+		_, _ = fmt.Fprintf(&sb, "%s: %s -> %s\n", diff.Field, orNone(diff.A), orNone(diff.B))
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	return nil
+}
+
+// diffClusters returns the semantic differences between the two cluster summaries.
+func diffClusters(a, b clusterSummary) []clusterDifference {
+	var resp []clusterDifference
+
+	add := func(field, valA, valB string) {
+		if valA != valB {
+			resp = append(resp, clusterDifference{Field: field, A: valA, B: valB})
+		}
+	}
+
+	add("name", a.Name, b.Name)
+	add("version", a.Version, b.Version)
+	add("network", a.network(), b.network())
+	add("consensus_protocol", a.ConsensusProtocol, b.ConsensusProtocol)
+	add("threshold", strconv.Itoa(a.Threshold), strconv.Itoa(b.Threshold))
+	add("num_operators", strconv.Itoa(len(a.Operators)), strconv.Itoa(len(b.Operators)))
+	add("num_validators", strconv.Itoa(a.NumValidators), strconv.Itoa(b.NumValidators))
+
+	resp = append(resp, diffOperators(a.Operators, b.Operators)...)
+	resp = append(resp, diffValidators(a.Validators, b.Validators)...)
+
+	return resp
+}
+
+// diffOperators returns the differences of operators identified by their ENR.
+func diffOperators(a, b []operatorSummary) []clusterDifference {
+	var resp []clusterDifference
+
+	enrsA := operatorENRs(a)
+	enrsB := operatorENRs(b)
+
+	for i, op := range a {
+		j := slices.Index(enrsB, op.ENR)
+		if j < 0 {
+			resp = append(resp, clusterDifference{Field: fmt.Sprintf("operators[%d]", i), A: op.ENR})
+			continue
+		}
+
+		if !strings.EqualFold(op.Address, b[j].Address) {
+			resp = append(resp, clusterDifference{Field: fmt.Sprintf("operators[%d].address", i), A: op.Address, B: b[j].Address})
+		}
+	}
+
+	for j, op := range b {
+		if !slices.Contains(enrsA, op.ENR) {
+			resp = append(resp, clusterDifference{Field: fmt.Sprintf("operators[%d]", j), B: op.ENR})
+		}
+	}
+
+	// Operator order defines share indexes, so report reordering of the common operators.
+	var commonA, commonB []string
+	for _, enr := range enrsA {
+		if slices.Contains(enrsB, enr) {
+			commonA = append(commonA, enr)
+		}
+	}
+	for _, enr := range enrsB {
+		if slices.Contains(enrsA, enr) {
+			commonB = append(commonB, enr)
+		}
+	}
+
+	if !slices.Equal(commonA, commonB) {
+		var order []string
+		for _, enr := range commonA {
+			order = append(order, strconv.Itoa(slices.Index(enrsB, enr)))
+		}
+
+		resp = append(resp, clusterDifference{
+			Field: "operator_order",
+			A:     "original",
+			B:     "reordered to indexes " + strings.Join(order, ","),
+		})
+	}
+
+	return resp
+}
+
+// diffValidators returns the differences of validators identified by their public key, or by index for cluster definitions.
+func diffValidators(a, b []validatorSummary) []clusterDifference {
+	var resp []clusterDifference
+
+	// Compare by index if either side is a cluster definition without public keys.
+	byIndex := slices.ContainsFunc(a, func(v validatorSummary) bool { return v.PublicKey == "" }) ||
+		slices.ContainsFunc(b, func(v validatorSummary) bool { return v.PublicKey == "" })
+
+	idsA := make(map[string]int)
+	for i, val := range a {
+		idsA[val.id(i, byIndex)] = i
+	}
+
+	idsB := make(map[string]int)
+	for i, val := range b {
+		idsB[val.id(i, byIndex)] = i
+	}
+
+	for i, valA := range a {
+		id := valA.id(i, byIndex)
+
+		j, ok := idsB[id]
+		if !ok {
+			resp = append(resp, clusterDifference{Field: "validator", A: id})
+			continue
+		}
+
+		valB := b[j]
+		field := func(name string) string {
+			return fmt.Sprintf("validator[%s].%s", id, name)
+		}
+
+		if !strings.EqualFold(valA.FeeRecipientAddress, valB.FeeRecipientAddress) {
+			resp = append(resp, clusterDifference{Field: field("fee_recipient_address"), A: valA.FeeRecipientAddress, B: valB.FeeRecipientAddress})
+		}
+
+		if !strings.EqualFold(valA.WithdrawalAddress, valB.WithdrawalAddress) {
+			resp = append(resp, clusterDifference{Field: field("withdrawal_address"), A: valA.WithdrawalAddress, B: valB.WithdrawalAddress})
+		}
+
+		if valA.Compounding != valB.Compounding {
+			resp = append(resp, clusterDifference{Field: field("compounding"), A: strconv.FormatBool(valA.Compounding), B: strconv.FormatBool(valB.Compounding)})
+		}
+
+		// Definitions don't have builder registrations.
+		if valA.PublicKey != "" && valB.PublicKey != "" && valA.BuilderRegistration != valB.BuilderRegistration {
+			resp = append(resp, clusterDifference{Field: field("builder_registration"), A: strconv.FormatBool(valA.BuilderRegistration), B: strconv.FormatBool(valB.BuilderRegistration)})
+		}
+	}
+
+	for j, valB := range b {
+		if _, ok := idsA[valB.id(j, byIndex)]; !ok {
+			resp = append(resp, clusterDifference{Field: "validator", B: valB.id(j, byIndex)})
+		}
+	}
+
+	return resp
+}
+
+func operatorENRs(ops []operatorSummary) []string {
+	var resp []string
+	for _, op := range ops {
+		resp = append(resp, op.ENR)
+	}
+
+	return resp
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+
+	return s
+}
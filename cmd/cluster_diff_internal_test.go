@@ -0,0 +1,103 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRunClusterDiff(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 3, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	dir := t.TempDir()
+	lockPath := writeTestJSON(t, dir, "lock.json", lock)
+	defPath := writeTestJSON(t, dir, "definition.json", lock.Definition)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+	b, err := proto.Marshal(dag)
+	require.NoError(t, err)
+	manifestPath := filepath.Join(dir, "manifest.pb")
+	require.NoError(t, os.WriteFile(manifestPath, b, 0o644))
+
+	t.Run("identical", func(t *testing.T) {
+		for _, path := range []string{lockPath, defPath, manifestPath} {
+			var buf bytes.Buffer
+			require.NoError(t, runClusterDiff(context.Background(), &buf, clusterDiffConfig{PathA: lockPath, PathB: path, Format: outputFormatText}))
+			require.Contains(t, buf.String(), "No differences found", path)
+		}
+	})
+
+	t.Run("address case", func(t *testing.T) {
+		lowercase := lock
+		lowercase.ValidatorAddresses = append([]cluster.ValidatorAddresses(nil), lock.ValidatorAddresses...)
+		for i, addrs := range lowercase.ValidatorAddresses {
+			lowercase.ValidatorAddresses[i].FeeRecipientAddress = strings.ToLower(addrs.FeeRecipientAddress)
+			lowercase.ValidatorAddresses[i].WithdrawalAddress = strings.ToLower(addrs.WithdrawalAddress)
+		}
+		lowercasePath := writeTestJSON(t, dir, "lowercase.json", lowercase)
+
+		var buf bytes.Buffer
+		require.NoError(t, runClusterDiff(context.Background(), &buf, clusterDiffConfig{PathA: lockPath, PathB: lowercasePath, Format: outputFormatText}))
+		require.Contains(t, buf.String(), "No differences found")
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		changed := lock
+		changed.Threshold = 2
+		changed.Operators = []cluster.Operator{lock.Operators[1], lock.Operators[0], lock.Operators[2], lock.Operators[3]}
+		changed.NumValidators = 2
+		changed.Validators = lock.Validators[:2]
+		changed.ValidatorAddresses = append([]cluster.ValidatorAddresses(nil), lock.ValidatorAddresses[:2]...)
+		changed.ValidatorAddresses[0].FeeRecipientAddress = testutil.RandomETHAddress()
+		changedPath := writeTestJSON(t, dir, "changed.json", changed)
+
+		var buf bytes.Buffer
+		require.NoError(t, runClusterDiff(context.Background(), &buf, clusterDiffConfig{PathA: lockPath, PathB: changedPath, Format: outputFormatJSON}))
+
+		var resp struct {
+			Differences []clusterDifference `json:"differences"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+
+		fields := make(map[string]clusterDifference)
+		for _, diff := range resp.Differences {
+			fields[diff.Field] = diff
+		}
+
+		require.Len(t, fields, 5)
+		require.Equal(t, clusterDifference{Field: "threshold", A: "3", B: "2"}, fields["threshold"])
+		require.Equal(t, clusterDifference{Field: "num_validators", A: "3", B: "2"}, fields["num_validators"])
+		require.Equal(t, "reordered to indexes 1,0,2,3", fields["operator_order"].B)
+		require.Equal(t, clusterDifference{Field: "validator", A: lock.Validators[2].PublicKeyHex()}, fields["validator"])
+
+		feeField := "validator[" + lock.Validators[0].PublicKeyHex() + "].fee_recipient_address"
+		require.Equal(t, changed.ValidatorAddresses[0].FeeRecipientAddress, fields[feeField].B)
+	})
+}
+
+func writeTestJSON(t *testing.T, dir, filename string, v any) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, filename)
+	require.NoError(t, os.WriteFile(path, b, 0o644))
+
+	return path
+}
@@ -0,0 +1,169 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+type clusterLintConfig struct {
+	Path               string
+	Format             string
+	ExecutionClientRPC string
+}
+
+// lintFinding is a risky setting found in a cluster file.
+type lintFinding struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+func newClusterLintCmd(runFunc func(context.Context, io.Writer, clusterLintConfig) error) *cobra.Command {
+	var config clusterLintConfig
+
+	cmd := &cobra.Command{
+		Use:   "lint <file>",
+		Short: "Report risky settings of a cluster file",
+		Long: "Reports risky settings of a cluster definition, lock or manifest file, e.g., non-default thresholds, " +
+			"mismatched withdrawal addresses, deprecated versions or missing builder registrations. It fails if any errors are found.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Path = args[0]
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	bindOutputFormatFlag(cmd, &config.Format)
+	bindExecutionClientRPCFlag(cmd.Flags(), &config.ExecutionClientRPC)
+
+	return cmd
+}
+
+func runClusterLint(ctx context.Context, w io.Writer, config clusterLintConfig) error {
+	summary, err := loadClusterSummary(ctx, config.Path, config.ExecutionClientRPC)
+	if err != nil {
+		return err
+	}
+
+	findings := lintCluster(summary)
+
+	var numErrs int
+	for _, finding := range findings {
+		if finding.Severity == lintError {
+			numErrs++
+		}
+	}
+
+	if config.Format == outputFormatJSON {
+		if err := writeJSON(w, struct {
+			Path     string        `json:"path"`
+			FileType string        `json:"file_type"`
+			Findings []lintFinding `json:"findings"`
+		}{Path: summary.Path, FileType: summary.FileType, Findings: findings}); err != nil {
+			return err
+		}
+	} else {
+		var sb strings.Builder
+		_, _ = fmt.Fprintf(&sb, "%s (%s)\n", summary.Path, summary.FileType)
+
+		for _, finding := range findings {
+			_, _ = fmt.Fprintf(&sb, "%s [%s] %s\n", strings.ToUpper(finding.Severity), finding.Check, finding.Message)
+		}
+
+		_, _ = fmt.Fprintf(&sb, "%d errors, %d warnings\n", numErrs, len(findings)-numErrs)
+
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return errors.Wrap(err, "write output")
+		}
+	}
+
+	if numErrs > 0 {
+		return errors.New("cluster lint found errors", z.Int("errors", numErrs))
+	}
+
+	return nil
+}
+
+// lintCluster returns the risky settings of the cluster.
+func lintCluster(s clusterSummary) []lintFinding {
+	var resp []lintFinding
+
+	add := func(severity, check, msg string, args ...any) {
+		resp = append(resp, lintFinding{Severity: severity, Check: check, Message: fmt.Sprintf(msg, args...)})
+	}
+
+	for _, verifyErr := range s.VerifyErrors {
+		add(lintError, "verification", "Verification failed: %s", verifyErr)
+	}
+
+	if s.Version != "" && !cluster.SupportNodeSignatures(s.Version) {
+		add(lintWarning, "deprecated_version", "Version %s is deprecated, it lacks node signatures and pre-generated builder registrations", s.Version)
+	}
+
+	numOps := len(s.Operators)
+	switch {
+	case numOps == 0:
+	case s.Threshold <= numOps/2:
+		add(lintError, "threshold", "Threshold %d of %d operators allows conflicting signatures, it must be more than half", s.Threshold, numOps)
+	case s.Threshold > numOps:
+		add(lintError, "threshold", "Threshold %d exceeds the number of operators %d", s.Threshold, numOps)
+	case s.Threshold != cluster.Threshold(numOps):
+		add(lintWarning, "threshold", "Non-default threshold %d for %d operators, default is %d", s.Threshold, numOps, cluster.Threshold(numOps))
+	}
+
+	enrs := make(map[string]bool)
+	for i, op := range s.Operators {
+		if op.ENR != "" && enrs[op.ENR] {
+			add(lintError, "duplicate_operator", "Operator %d has a duplicate ENR", i)
+		}
+		enrs[op.ENR] = true
+	}
+
+	network := s.network()
+	mainOrGnosis := network == eth2util.Mainnet.Name || network == eth2util.Gnosis.Name
+
+	withdrawalAddrs := make(map[string]bool)
+	for i, val := range s.Validators {
+		id := val.id(i, val.PublicKey == "")
+		withdrawalAddrs[strings.ToLower(val.WithdrawalAddress)] = true
+
+		if val.WithdrawalAddress == zeroAddress {
+			severity := lintWarning
+			if mainOrGnosis {
+				severity = lintError
+			}
+			add(severity, "zero_withdrawal_address", "Validator %s has the zero withdrawal address", id)
+		}
+
+		if val.FeeRecipientAddress == zeroAddress {
+			add(lintWarning, "zero_fee_recipient_address", "Validator %s has the zero fee recipient address", id)
+		}
+
+		if val.PublicKey != "" && !val.BuilderRegistration && (s.Version == "" || cluster.SupportPregenRegistrations(s.Version)) {
+			add(lintWarning, "missing_builder_registration", "Validator %s has no pre-generated builder registration", id)
+		}
+	}
+
+	if len(withdrawalAddrs) > 1 {
+		add(lintWarning, "mismatched_withdrawal_addresses", "Validators have %d different withdrawal addresses", len(withdrawalAddrs))
+	}
+
+	return resp
+}
@@ -0,0 +1,94 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+func TestRunClusterLint(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0, rand.New(rand.NewSource(0)))
+	dir := t.TempDir()
+
+	t.Run("valid lock", func(t *testing.T) {
+		path := writeTestJSON(t, dir, "lock.json", lock)
+
+		var buf bytes.Buffer
+		require.NoError(t, runClusterLint(context.Background(), &buf, clusterLintConfig{Path: path, Format: outputFormatText}))
+		require.Contains(t, buf.String(), "0 errors")
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		writeManifest := func(t *testing.T, filename string, lock cluster.Lock) string {
+			t.Helper()
+
+			b, err := json.Marshal(lock)
+			require.NoError(t, err)
+			legacy, err := manifest.NewRawLegacyLock(b)
+			require.NoError(t, err)
+			b, err = proto.Marshal(&manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}})
+			require.NoError(t, err)
+
+			path := filepath.Join(dir, filename)
+			require.NoError(t, os.WriteFile(path, b, 0o644))
+
+			return path
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, runClusterLint(context.Background(), &buf, clusterLintConfig{Path: writeManifest(t, "manifest.pb", lock), Format: outputFormatText}))
+		require.Contains(t, buf.String(), "0 errors")
+
+		tampered := lock
+		tampered.Validators = append([]cluster.DistValidator(nil), lock.Validators...)
+		tampered.Validators[0].PubKey = lock.Validators[1].PubKey
+
+		buf.Reset()
+		err := runClusterLint(context.Background(), &buf, clusterLintConfig{Path: writeManifest(t, "tampered.pb", tampered), Format: outputFormatText})
+		require.ErrorContains(t, err, "cluster lint found errors")
+		require.Contains(t, buf.String(), "Verification failed")
+	})
+
+	t.Run("risky lock", func(t *testing.T) {
+		risky := lock
+		risky.Threshold = 2
+		risky.ValidatorAddresses = append([]cluster.ValidatorAddresses(nil), lock.ValidatorAddresses...)
+		risky.ValidatorAddresses[0].WithdrawalAddress = zeroAddress
+		risky.Validators = append([]cluster.DistValidator(nil), lock.Validators...)
+		risky.Validators[1].BuilderRegistration = cluster.BuilderRegistration{}
+		path := writeTestJSON(t, dir, "risky.json", risky)
+
+		var buf bytes.Buffer
+		err := runClusterLint(context.Background(), &buf, clusterLintConfig{Path: path, Format: outputFormatJSON})
+		require.ErrorContains(t, err, "cluster lint found errors")
+
+		var resp struct {
+			Findings []lintFinding `json:"findings"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+
+		checks := make(map[string]string)
+		for _, finding := range resp.Findings {
+			checks[finding.Check] = finding.Severity
+		}
+
+		require.Equal(t, lintError, checks["verification"])
+		require.Equal(t, lintError, checks["threshold"])
+		require.Equal(t, lintWarning, checks["zero_withdrawal_address"])
+		require.Equal(t, lintWarning, checks["mismatched_withdrawal_addresses"])
+		require.Equal(t, lintWarning, checks["missing_builder_registration"])
+	})
+}
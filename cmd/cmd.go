@@ -17,6 +17,7 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cmd/apiserver"
 	"github.com/obolnetwork/charon/cmd/relay"
 	"github.com/obolnetwork/charon/dkg"
 )
@@ -56,13 +57,31 @@ func New() *cobra.Command {
 			),
 			newAddValidatorsCmd(runAddValidatorsSolo),
 			newViewClusterManifestCmd(runViewClusterManifest),
+			newManifestCmd(newManifestMigrateCmd(runManifestMigrate)),
+			newEditCmd(
+				newEditProposeValidatorAddressesCmd(runEditProposeValidatorAddresses),
+				newEditProposeRemoveValidatorsCmd(runEditProposeRemoveValidators),
+				newEditApproveCmd(runEditApprove),
+				newEditApplyCmd(runEditApply),
+			),
+			newAPIServerCmd(apiserver.Run),
 		),
 		newExitCmd(
 			newListActiveValidatorsCmd(runListActiveValidatorsCmd),
 			newSignPartialExitCmd(runSignPartialExit),
 			newBcastFullExitCmd(runBcastFullExit),
 			newFetchExitCmd(runFetchExit),
+			newELRequestCmd(runELRequest),
+		),
+		newDepositCmd(
+			newDepositSignCmd(runDepositSign),
+			newDepositFetchCmd(runDepositFetch),
+		),
+		newClusterCmd(
+			newClusterDiffCmd(runClusterDiff),
+			newClusterLintCmd(runClusterLint),
 		),
+		newDebugCmd(newDebugReplayCmd(app.Replay)),
 		newUnsafeCmd(newRunCmd(app.Run, true)),
 	)
 }
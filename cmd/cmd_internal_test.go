@@ -81,6 +81,7 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
+				BuilderRecastEpochs:     1,
 				JaegerAddr:              "",
 				JaegerService:           "charon",
 			},
@@ -99,6 +100,14 @@ func TestCmdFlags(t *testing.T) {
 			Args:     slice("run"),
 			ErrorMsg: "either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified",
 		},
+		{
+			Name: "run builder relays require builder api",
+			Args: slice("run", "--builder-relays=https://relay.example"),
+			Envs: map[string]string{
+				"CHARON_BEACON_NODE_ENDPOINTS": "http://beacon.node",
+			},
+			ErrorMsg: "flag 'builder-relays' requires flag 'builder-api=true'",
+		},
 		{
 			Name: "unsafe run",
 			Args: slice("unsafe", "run", "--p2p-fuzz=true"),
@@ -132,6 +141,7 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
+				BuilderRecastEpochs:     1,
 				JaegerAddr:              "",
 				JaegerService:           "charon",
 				TestConfig: app.TestConfig{
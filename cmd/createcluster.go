@@ -14,6 +14,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -72,6 +73,7 @@ type clusterConfig struct {
 	NumDVs            int
 
 	DepositAmounts []int // Amounts specified in ETH (integers).
+	Compounding    []bool
 
 	SplitKeys    bool
 	SplitKeysDir string
@@ -81,6 +83,8 @@ type clusterConfig struct {
 	PublishAddr string
 	Publish     bool
 
+	ExecutionClientRPC string
+
 	ConsensusProtocol string
 
 	testnetConfig eth2util.Network
@@ -101,6 +105,7 @@ func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig)
 
 	bindClusterFlags(cmd.Flags(), &conf)
 	bindInsecureFlags(cmd.Flags(), &conf.InsecureKeys)
+	bindExecutionClientRPCFlag(cmd.Flags(), &conf.ExecutionClientRPC)
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
 		thresholdPresent := cmd.Flags().Lookup("threshold").Changed
@@ -141,7 +146,8 @@ func bindClusterFlags(flags *pflag.FlagSet, config *clusterConfig) {
 	flags.StringVar(&config.testnetConfig.GenesisForkVersionHex, "testnet-fork-version", "", "Genesis fork version of the custom test network (in hex).")
 	flags.Uint64Var(&config.testnetConfig.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
 	flags.Int64Var(&config.testnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
-	flags.IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH.")
+	flags.IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH, or up to 2048ETH for compounding validators.")
+	flags.BoolSliceVar(&config.Compounding, "compounding", nil, "Comma separated list of booleans enabling compounding (0x02) withdrawal credentials for each validator. Either provide a single value or a value for each validator.")
 	flags.StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the cluster. Selected automatically when not specified.")
 }
 
@@ -159,7 +165,7 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 
 	var def cluster.Definition
 	if conf.DefFile != "" { // Load definition from DefFile
-		def, err = loadDefinition(ctx, conf.DefFile)
+		def, err = loadDefinition(ctx, conf.DefFile, verifyOptions(ctx, conf.ExecutionClientRPC)...)
 		if err != nil {
 			return err
 		}
@@ -193,7 +199,7 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 	// Get a cluster definition, either from a definition file or from the config.
 	if conf.DefFile != "" {
 		// Validate the provided definition.
-		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def, verifyOptions(ctx, conf.ExecutionClientRPC)...)
 		if err != nil {
 			return err
 		}
@@ -266,7 +272,7 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
-	depositDatas, err := createDepositDatas(def.WithdrawalAddresses(), network, secrets, depositAmounts)
+	depositDatas, err := createDepositDatas(def.ValidatorAddresses, network, secrets, depositAmounts)
 	if err != nil {
 		return err
 	}
@@ -362,7 +368,7 @@ func validateCreateConfig(ctx context.Context, conf clusterConfig) error {
 	if len(conf.DepositAmounts) > 0 {
 		amounts := deposit.EthsToGweis(conf.DepositAmounts)
 
-		if err := deposit.VerifyDepositAmounts(amounts); err != nil {
+		if err := verifyDepositAmounts(amounts, conf.Compounding); err != nil {
 			return err
 		}
 	}
@@ -417,8 +423,8 @@ func detectNodeDirs(clusterDir string, nodeAmount int) error {
 }
 
 // signDepositDatas returns a list of DepositData for each partial deposit amount.
-func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, network string, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
-	if len(secrets) != len(withdrawalAddresses) {
+func signDepositDatas(secrets []tbls.PrivateKey, vaddrs []cluster.ValidatorAddresses, network string, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+	if len(secrets) != len(vaddrs) {
 		return nil, errors.New("insufficient withdrawal addresses")
 	}
 	if len(depositAmounts) == 0 {
@@ -429,7 +435,7 @@ func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, n
 	for _, depositAmount := range depositAmounts {
 		var datas []eth2p0.DepositData
 		for i, secret := range secrets {
-			withdrawalAddr, err := eth2util.ChecksumAddress(withdrawalAddresses[i])
+			withdrawalAddr, err := eth2util.ChecksumAddress(vaddrs[i].WithdrawalAddress)
 			if err != nil {
 				return nil, err
 			}
@@ -439,7 +445,7 @@ func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, n
 				return nil, errors.Wrap(err, "secret to pubkey")
 			}
 
-			msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pk), withdrawalAddr, depositAmount)
+			msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pk), withdrawalAddr, depositAmount, vaddrs[i].Compounding)
 			if err != nil {
 				return nil, err
 			}
@@ -608,8 +614,8 @@ func generateKeys(numDVs int) ([]tbls.PrivateKey, error) {
 }
 
 // createDepositDatas creates a slice of deposit datas using the provided parameters and returns it.
-func createDepositDatas(withdrawalAddresses []string, network string, secrets []tbls.PrivateKey, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
-	if len(secrets) != len(withdrawalAddresses) {
+func createDepositDatas(vaddrs []cluster.ValidatorAddresses, network string, secrets []tbls.PrivateKey, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+	if len(secrets) != len(vaddrs) {
 		return nil, errors.New("insufficient withdrawal addresses")
 	}
 	if len(depositAmounts) == 0 {
@@ -617,7 +623,7 @@ func createDepositDatas(withdrawalAddresses []string, network string, secrets []
 	}
 	depositAmounts = deposit.DedupAmounts(depositAmounts)
 
-	return signDepositDatas(secrets, withdrawalAddresses, network, depositAmounts)
+	return signDepositDatas(secrets, vaddrs, network, depositAmounts)
 }
 
 // createValidatorRegistrations creates a slice of builder validator registrations using the provided parameters and returns it.
@@ -832,6 +838,11 @@ func newDefFromConfig(ctx context.Context, conf clusterConfig) (cluster.Definiti
 		return cluster.Definition{}, err
 	}
 
+	compounding, err := validateCompounding(conf.NumDVs, conf.Compounding)
+	if err != nil {
+		return cluster.Definition{}, err
+	}
+
 	var forkVersion string
 	if conf.Network != "" {
 		forkVersion, err = eth2util.NetworkToForkVersion(conf.Network)
@@ -854,6 +865,9 @@ func newDefFromConfig(ctx context.Context, conf clusterConfig) (cluster.Definiti
 	if len(conf.DepositAmounts) > 0 {
 		opts = append(opts, cluster.WithVersion(cluster.MinVersionForPartialDeposits))
 	}
+	if slices.Contains(compounding, true) {
+		opts = append(opts, cluster.WithVersion(cluster.MinVersionForCompounding), cluster.WithCompounding(compounding))
+	}
 	def, err := cluster.NewDefinition(conf.Name, conf.NumDVs, threshold, feeRecipientAddrs,
 		withdrawalAddrs, forkVersion, cluster.Creator{}, ops, conf.DepositAmounts,
 		conf.ConsensusProtocol, rand.Reader, opts...)
@@ -914,7 +928,7 @@ func nodeDir(clusterDir string, i int) string {
 }
 
 // validateDef returns an error if the provided cluster definition is invalid.
-func validateDef(ctx context.Context, insecureKeys bool, keymanagerAddrs []string, def cluster.Definition) error {
+func validateDef(ctx context.Context, insecureKeys bool, keymanagerAddrs []string, def cluster.Definition, verifyOpts ...cluster.VerifyOption) error {
 	if def.NumValidators == 0 {
 		return errors.New("cannot create cluster with zero validators, specify at least one")
 	}
@@ -928,7 +942,7 @@ func validateDef(ctx context.Context, insecureKeys bool, keymanagerAddrs []strin
 	}
 
 	if len(def.DepositAmounts) > 0 {
-		if err := deposit.VerifyDepositAmounts(def.DepositAmounts); err != nil {
+		if err := def.VerifyDepositAmounts(); err != nil {
 			return errors.Wrap(err, "deposit amounts verification failed")
 		}
 	}
@@ -952,7 +966,7 @@ func validateDef(ctx context.Context, insecureKeys bool, keymanagerAddrs []strin
 		return err
 	}
 
-	if err = def.VerifySignatures(); err != nil {
+	if err = def.VerifySignatures(verifyOpts...); err != nil {
 		return err
 	}
 
@@ -990,7 +1004,7 @@ func aggSign(secrets [][]tbls.PrivateKey, message []byte) ([]byte, error) {
 
 // loadDefinition returns the cluster definition from disk or an HTTP URL. It also verifies signatures
 // and hashes before returning the definition.
-func loadDefinition(ctx context.Context, defFile string) (cluster.Definition, error) {
+func loadDefinition(ctx context.Context, defFile string, verifyOpts ...cluster.VerifyOption) (cluster.Definition, error) {
 	var def cluster.Definition
 
 	// Fetch definition from network if URI is provided
@@ -1017,7 +1031,7 @@ func loadDefinition(ctx context.Context, defFile string) (cluster.Definition, er
 			z.Str("definition_hash", fmt.Sprintf("%#x", def.DefinitionHash)))
 	}
 
-	if err := def.VerifySignatures(); err != nil {
+	if err := def.VerifySignatures(verifyOpts...); err != nil {
 		return cluster.Definition{}, err
 	}
 	if err := def.VerifyHashes(); err != nil {
@@ -1106,6 +1120,41 @@ func validateAddresses(numVals int, feeRecipientAddrs []string, withdrawalAddrs
 	return feeRecipientAddrs, withdrawalAddrs, nil
 }
 
+// validateCompounding checks if we have sufficient compounding values. It also fills the slice if only one is provided.
+func validateCompounding(numVals int, compounding []bool) ([]bool, error) {
+	if len(compounding) == 0 {
+		return nil, nil
+	}
+
+	if len(compounding) != numVals && len(compounding) != 1 {
+		return nil, errors.New("mismatching --num-validators and --compounding",
+			z.Int("num_validators", numVals), z.Int("compounding", len(compounding)))
+	}
+
+	if len(compounding) == 1 {
+		for i := 1; i < numVals; i++ {
+			compounding = append(compounding, compounding[0])
+		}
+	}
+
+	return compounding, nil
+}
+
+// verifyDepositAmounts verifies the partial deposit amounts for both compounding and non-compounding validators.
+func verifyDepositAmounts(amounts []eth2p0.Gwei, compounding []bool) error {
+	if !slices.Contains(compounding, true) {
+		return deposit.VerifyDepositAmounts(amounts, false)
+	}
+
+	if slices.Contains(compounding, false) {
+		if err := deposit.VerifyDepositAmounts(amounts, false); err != nil {
+			return err
+		}
+	}
+
+	return deposit.VerifyDepositAmounts(amounts, true)
+}
+
 func builderRegistrationFromETH2(reg core.VersionedSignedValidatorRegistration) (cluster.BuilderRegistration, error) {
 	feeRecipient, err := reg.FeeRecipient()
 	if err != nil {
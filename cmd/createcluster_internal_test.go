@@ -79,6 +79,17 @@ func TestCreateCluster(t *testing.T) {
 				DepositAmounts: []int{8, 8, 8, 8},
 			},
 		},
+		{
+			Name: "compounding partial deposits",
+			Config: clusterConfig{
+				NumNodes:       4,
+				Threshold:      3,
+				NumDVs:         1,
+				Network:        eth2util.Goerli.Name,
+				DepositAmounts: []int{32, 1024},
+				Compounding:    []bool{true},
+			},
+		},
 		{
 			Name: "splitkeys",
 			Config: clusterConfig{
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/spf13/cobra"
 
@@ -32,6 +33,7 @@ type createDKGConfig struct {
 	Network           string
 	DKGAlgo           string
 	DepositAmounts    []int // Amounts specified in ETH (integers).
+	Compounding       []bool
 	OperatorENRs      []string
 	ConsensusProtocol string
 }
@@ -81,7 +83,8 @@ func bindCreateDKGFlags(cmd *cobra.Command, config *createDKGConfig) {
 	cmd.Flags().StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
 	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, goerli, sepolia, holesky, gnosis, chiado.")
 	cmd.Flags().StringVar(&config.DKGAlgo, "dkg-algorithm", "default", "DKG algorithm to use; default, frost")
-	cmd.Flags().IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH.")
+	cmd.Flags().IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH, or up to 2048ETH for compounding validators.")
+	cmd.Flags().BoolSliceVar(&config.Compounding, "compounding", nil, "Comma separated list of booleans enabling compounding (0x02) withdrawal credentials for each validator. Either provide a single value or a value for each validator.")
 	cmd.Flags().StringSliceVar(&config.OperatorENRs, operatorENRs, nil, "[REQUIRED] Comma-separated list of each operator's Charon ENR address.")
 	cmd.Flags().StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the cluster. Selected automatically when not specified.")
 
@@ -100,7 +103,7 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		conf.Network = eth2util.Goerli.Name
 	}
 
-	if err = validateDKGConfig(len(conf.OperatorENRs), conf.Network, conf.DepositAmounts, conf.ConsensusProtocol); err != nil {
+	if err = validateDKGConfig(len(conf.OperatorENRs), conf.Network, conf.DepositAmounts, conf.Compounding, conf.ConsensusProtocol); err != nil {
 		return err
 	}
 
@@ -109,6 +112,11 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		return err
 	}
 
+	conf.Compounding, err = validateCompounding(conf.NumValidators, conf.Compounding)
+	if err != nil {
+		return err
+	}
+
 	if err = validateWithdrawalAddrs(conf.WithdrawalAddrs, conf.Network); err != nil {
 		return err
 	}
@@ -147,6 +155,9 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 	if len(conf.DepositAmounts) > 0 {
 		opts = append(opts, cluster.WithVersion(cluster.MinVersionForPartialDeposits))
 	}
+	if slices.Contains(conf.Compounding, true) {
+		opts = append(opts, cluster.WithVersion(cluster.MinVersionForCompounding), cluster.WithCompounding(conf.Compounding))
+	}
 	def, err := cluster.NewDefinition(
 		conf.Name, conf.NumValidators, conf.Threshold,
 		conf.FeeRecipientAddrs, conf.WithdrawalAddrs,
@@ -199,7 +210,7 @@ func validateWithdrawalAddrs(addrs []string, network string) error {
 }
 
 // validateDKGConfig returns an error if any of the provided config parameter is invalid.
-func validateDKGConfig(numOperators int, network string, depositAmounts []int, consensusProtocol string) error {
+func validateDKGConfig(numOperators int, network string, depositAmounts []int, compounding []bool, consensusProtocol string) error {
 	// Don't allow cluster size to be less than 3.
 	if numOperators < minNodes {
 		return errors.New("number of operators is below minimum", z.Int("operators", numOperators), z.Int("min", minNodes))
@@ -212,7 +223,7 @@ func validateDKGConfig(numOperators int, network string, depositAmounts []int, c
 	if len(depositAmounts) > 0 {
 		amounts := deposit.EthsToGweis(depositAmounts)
 
-		if err := deposit.VerifyDepositAmounts(amounts); err != nil {
+		if err := verifyDepositAmounts(amounts, compounding); err != nil {
 			return err
 		}
 	}
@@ -196,23 +196,31 @@ func TestValidateWithdrawalAddr(t *testing.T) {
 func TestValidateDKGConfig(t *testing.T) {
 	t.Run("insufficient ENRs", func(t *testing.T) {
 		numOperators := 2
-		err := validateDKGConfig(numOperators, "", nil, "")
+		err := validateDKGConfig(numOperators, "", nil, nil, "")
 		require.ErrorContains(t, err, "number of operators is below minimum")
 	})
 
 	t.Run("invalid network", func(t *testing.T) {
 		numOperators := 4
-		err := validateDKGConfig(numOperators, "cosmos", nil, "")
+		err := validateDKGConfig(numOperators, "cosmos", nil, nil, "")
 		require.ErrorContains(t, err, "unsupported network")
 	})
 
 	t.Run("wrong deposit amounts sum", func(t *testing.T) {
-		err := validateDKGConfig(4, "goerli", []int{8, 16}, "")
+		err := validateDKGConfig(4, "goerli", []int{8, 16}, nil, "")
+		require.ErrorContains(t, err, "sum of partial deposit amounts must sum up to 32ETH")
+	})
+
+	t.Run("compounding deposit amounts", func(t *testing.T) {
+		err := validateDKGConfig(4, "goerli", []int{32, 1024}, []bool{true}, "")
+		require.NoError(t, err)
+
+		err = validateDKGConfig(4, "goerli", []int{32, 1024}, []bool{true, false}, "")
 		require.ErrorContains(t, err, "sum of partial deposit amounts must sum up to 32ETH")
 	})
 
 	t.Run("unsupported consensus protocol", func(t *testing.T) {
-		err := validateDKGConfig(4, "goerli", nil, "unreal")
+		err := validateDKGConfig(4, "goerli", nil, nil, "unreal")
 		require.ErrorContains(t, err, "unsupported consensus protocol")
 	})
 }
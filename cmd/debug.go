@@ -0,0 +1,65 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+func newDebugCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug charon nodes",
+		Long:  "Debug charon nodes, e.g. by replaying recorded inputs to reproduce failed duties.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newDebugReplayCmd(runFunc func(context.Context, app.Config, string) error) *cobra.Command {
+	var (
+		conf       app.Config
+		recordFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay the inputs recorded by a charon node",
+		Long: "Re-runs the core workflow of a charon node against the beacon node responses, validator client requests " +
+			"and inbound p2p messages recorded via 'charon run --record-file', reproducing the duties performed at the time. " +
+			"It fails if the replayed beacon node submissions differ from the recorded ones.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := log.InitLogger(conf.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), conf, recordFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&recordFile, "record-file", "", "Path to the file of inputs recorded by the node to replay.")
+	cmd.Flags().StringVar(&conf.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&conf.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&conf.PrivKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file of the recorded node.")
+	cmd.Flags().DurationVar(&conf.BeaconNodeTimeout, "beacon-node-timeout", eth2ClientTimeout, "Timeout for the HTTP requests Charon makes to the replayed beacon node.")
+	cmd.Flags().BoolVar(&conf.BuilderAPI, "builder-api", false, "Enables the builder api. Must match the recorded node.")
+	bindNoVerifyFlag(cmd.Flags(), &conf.NoVerify)
+	bindLogFlags(cmd.Flags(), &conf.Log)
+	bindFeatureFlags(cmd.Flags(), &conf.Feature)
+
+	mustMarkFlagRequired(cmd, "record-file")
+
+	return cmd
+}
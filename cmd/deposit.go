@@ -0,0 +1,127 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+)
+
+type depositConfig struct {
+	ValidatorPublicKeys []string
+	All                 bool
+	PrivateKeyPath      string
+	ValidatorKeysDir    string
+	LockFilePath        string
+	ManifestFilePath    string
+	LockVerify          lockVerifyConfig
+	PublishAddress      string
+	PublishTimeout      time.Duration
+	DepositAmounts      []int // Amounts specified in ETH (integers).
+	DepositDataDir      string
+	Log                 log.Config
+}
+
+func newDepositCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "deposit",
+		Short: "Sign and fetch additional deposit data for distributed validators.",
+		Long:  "Sign and fetch threshold aggregated deposit data for existing distributed validators, e.g. to top up a validator, using a remote API.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindDepositFlags binds the flags common to all deposit commands.
+func bindDepositFlags(cmd *cobra.Command, config *depositConfig) {
+	cmd.Flags().StringSliceVar(&config.ValidatorPublicKeys, "validator-public-keys", nil, "Comma separated list of public keys of the validators to deposit to, must be present in the cluster lock manifest.")
+	cmd.Flags().BoolVar(&config.All, "all", false, "Deposit to all validators in the cluster.")
+	cmd.Flags().StringVar(&config.PrivateKeyPath, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.ManifestFilePath, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	bindLockVerifyFlags(cmd.Flags(), &config.LockVerify)
+	cmd.Flags().StringVar(&config.PublishAddress, "publish-address", "https://api.obol.tech/v1", "The URL of the remote API.")
+	cmd.Flags().DurationVar(&config.PublishTimeout, "publish-timeout", 5*time.Minute, "Timeout for accessing the remote API.")
+
+	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
+		valPubkPresent := cmd.Flags().Lookup("validator-public-keys").Changed
+
+		if !valPubkPresent && !config.All {
+			return errors.New("either validator-public-keys or all must be specified")
+		}
+
+		if config.All && valPubkPresent {
+			return errors.New("validator-public-keys should not be specified when all is, as it is obsolete and misleading")
+		}
+
+		return nil
+	})
+}
+
+// depositValidators returns the cluster validators selected by the deposit config.
+func depositValidators(cl *manifestpb.Cluster, config depositConfig) ([]*manifestpb.Validator, error) {
+	if config.All {
+		return cl.GetValidators(), nil
+	}
+
+	var resp []*manifestpb.Validator
+	for _, pubkeyHex := range config.ValidatorPublicKeys {
+		pubkey, err := core.PubKey(pubkeyHex).Bytes()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator public key", z.Str("validator_public_key", pubkeyHex))
+		}
+
+		var found bool
+		for _, val := range cl.GetValidators() {
+			if string(val.GetPublicKey()) == string(pubkey) {
+				resp = append(resp, val)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.New("validator public key not found in cluster lock", z.Str("validator_public_key", pubkeyHex))
+		}
+	}
+
+	return resp, nil
+}
+
+// lockCompounding returns whether each validator in the cluster uses compounding withdrawal credentials,
+// since compounding isn't part of the materialised cluster manifest but of its initial legacy lock.
+func lockCompounding(ctx context.Context, manifestFilePath, lockFilePath string, verifyConf lockVerifyConfig) (map[core.PubKey]bool, error) {
+	dag, err := loadDAGFromDisk(ctx, manifestFilePath, lockFilePath, verifyConf)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[core.PubKey]bool)
+
+	lock, ok := legacyLockFromDAG(dag)
+	if !ok {
+		return resp, nil
+	}
+
+	for i, val := range lock.Validators {
+		pubkey, err := core.PubKeyFromBytes(val.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		resp[pubkey] = i < len(lock.ValidatorAddresses) && lock.ValidatorAddresses[i].Compounding
+	}
+
+	return resp, nil
+}
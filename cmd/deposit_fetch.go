@@ -0,0 +1,145 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func newDepositFetchCmd(runFunc func(context.Context, depositConfig) error) *cobra.Command {
+	var config depositConfig
+
+	cmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "Fetch aggregated deposit data from the remote API",
+		Long:  `Fetches the threshold aggregated deposit data of distributed validators from the remote API and writes deposit-data-*.json files to disk.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindDepositFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.DepositDataDir, "deposit-data-dir", "./", "Path to the directory to store the fetched deposit data files.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runDepositFetch(ctx context.Context, config depositConfig) error {
+	if _, err := os.Stat(config.DepositDataDir); err != nil {
+		return errors.Wrap(err, "deposit data directory", z.Str("deposit_data_dir", config.DepositDataDir))
+	}
+
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(cl.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+	}
+
+	vals, err := depositValidators(cl, config)
+	if err != nil {
+		return err
+	}
+
+	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+	if err != nil {
+		return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	}
+
+	byAmount := make(map[eth2p0.Gwei][]eth2p0.DepositData)
+	for _, val := range vals {
+		valPubKeyHex := fmt.Sprintf("%#x", val.GetPublicKey())
+		valCtx := log.WithCtx(ctx, z.Str("validator", valPubKeyHex))
+
+		log.Info(valCtx, "Retrieving full deposit data")
+
+		depositDatas, err := oAPI.GetFullDeposit(valCtx, valPubKeyHex, cl.GetInitialMutationHash(), shareIdx, identityKey)
+		if err != nil {
+			return errors.Wrap(err, "load full deposit data from Obol API", z.Str("validator_public_key", valPubKeyHex))
+		}
+
+		for _, dd := range depositDatas {
+			if err := verifyDepositData(dd, val.GetPublicKey(), network); err != nil {
+				return errors.Wrap(err, "verify full deposit data", z.Str("validator_public_key", valPubKeyHex), z.U64("amount", uint64(dd.Amount)))
+			}
+
+			byAmount[dd.Amount] = append(byAmount[dd.Amount], dd)
+		}
+	}
+
+	amounts := slices.Collect(maps.Keys(byAmount))
+	slices.Sort(amounts)
+
+	for _, amount := range amounts {
+		depositPath := deposit.GetDepositFilePath(config.DepositDataDir, amount)
+		if _, err := os.Stat(depositPath); err == nil {
+			return errors.New("deposit data file already exists", z.Str("path", depositPath))
+		}
+
+		if err := deposit.WriteDepositDataFile(byAmount[amount], network, config.DepositDataDir); err != nil {
+			return errors.Wrap(err, "write deposit data file", z.Str("path", depositPath))
+		}
+
+		log.Info(ctx, "Stored deposit data", z.Str("path", depositPath), z.Int("validators", len(byAmount[amount])))
+	}
+
+	return nil
+}
+
+// verifyDepositData verifies the aggregated deposit data signature against the validator public key.
+func verifyDepositData(dd eth2p0.DepositData, pubkey []byte, network string) error {
+	if string(dd.PublicKey[:]) != string(pubkey) {
+		return errors.New("deposit data public key mismatch")
+	}
+
+	sigRoot, err := deposit.GetMessageSigningRoot(eth2p0.DepositMessage{
+		PublicKey:             dd.PublicKey,
+		WithdrawalCredentials: dd.WithdrawalCredentials,
+		Amount:                dd.Amount,
+	}, network)
+	if err != nil {
+		return err
+	}
+
+	return tbls.Verify(tbls.PublicKey(dd.PublicKey), sigRoot[:], tbls.Signature(dd.Signature))
+}
@@ -0,0 +1,124 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil/obolapimock"
+)
+
+func Test_runDepositFetch(t *testing.T) {
+	t.Run("threshold signed", func(t *testing.T) {
+		testRunDepositFlow(t, 3, false)
+	})
+	t.Run("threshold signed all", func(t *testing.T) {
+		testRunDepositFlow(t, 3, true)
+	})
+	t.Run("below threshold", func(t *testing.T) {
+		testRunDepositFlow(t, 2, false)
+	})
+}
+
+func testRunDepositFlow(t *testing.T, signers int, all bool) {
+	t.Helper()
+	ctx := context.Background()
+
+	const (
+		valAmt      = 2
+		operatorAmt = 4
+	)
+
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, 3, operatorAmt, 0, rand.New(rand.NewSource(0)))
+
+	root := t.TempDir()
+
+	operatorShares := make([][]tbls.PrivateKey, operatorAmt)
+	for opIdx := range operatorAmt {
+		for _, share := range keyShares {
+			operatorShares[opIdx] = append(operatorShares[opIdx], share[opIdx])
+		}
+	}
+
+	mBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	writeAllLockData(t, root, operatorAmt, enrs, operatorShares, mBytes)
+
+	handler, addLockFiles := obolapimock.MockServer(false, nil)
+	srv := httptest.NewServer(handler)
+	addLockFiles(lock)
+	defer srv.Close()
+
+	var pubkeys []string
+	if !all {
+		pubkeys = []string{lock.Validators[0].PublicKeyHex()}
+	}
+
+	for idx := range signers {
+		baseDir := filepath.Join(root, fmt.Sprintf("op%d", idx))
+
+		config := depositConfig{
+			ValidatorPublicKeys: pubkeys,
+			All:                 all,
+			PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
+			ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
+			LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
+			PublishAddress:      srv.URL,
+			PublishTimeout:      10 * time.Second,
+			DepositAmounts:      []int{1, 8, 8},
+		}
+
+		require.NoError(t, runDepositSign(ctx, config), "operator index: %v", idx)
+	}
+
+	baseDir := filepath.Join(root, "op3")
+	outDir := t.TempDir()
+
+	config := depositConfig{
+		ValidatorPublicKeys: pubkeys,
+		All:                 all,
+		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
+		LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
+		PublishAddress:      srv.URL,
+		PublishTimeout:      10 * time.Second,
+		DepositDataDir:      outDir,
+	}
+
+	err = runDepositFetch(ctx, config)
+	if signers < lock.Threshold {
+		require.ErrorContains(t, err, "http GET failed")
+		return
+	}
+	require.NoError(t, err)
+
+	expectVals := 1
+	if all {
+		expectVals = valAmt
+	}
+
+	for _, amount := range deposit.EthsToGweis([]int{1, 8}) {
+		b, err := os.ReadFile(deposit.GetDepositFilePath(outDir, amount))
+		require.NoError(t, err)
+
+		var depositDatas []map[string]any
+		require.NoError(t, json.Unmarshal(b, &depositDatas))
+		require.Len(t, depositDatas, expectVals)
+	}
+
+	// Fetching again doesn't overwrite existing files.
+	require.ErrorContains(t, runDepositFetch(ctx, config), "deposit data file already exists")
+}
@@ -0,0 +1,163 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func newDepositSignCmd(runFunc func(context.Context, depositConfig) error) *cobra.Command {
+	var config depositConfig
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign partial deposit data for distributed validators",
+		Long:  `Signs partial deposit data for the provided amounts of existing distributed validators and submits it to a remote API for aggregation.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindDepositFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to the directory containing the validator private key share files and passwords.")
+	cmd.Flags().IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of deposit amounts (integers) in ETH to sign for each validator. Compounding validators allow up to 2048ETH per deposit.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "deposit-amounts")
+
+	return cmd
+}
+
+func runDepositSign(ctx context.Context, config depositConfig) error {
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	compounding, err := lockCompounding(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return err
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(cl.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	rawValKeys, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
+	if err != nil {
+		return errors.Wrap(err, "load keystore, check if path exists", z.Str("validator_keys_dir", config.ValidatorKeysDir))
+	}
+
+	valKeys, err := rawValKeys.SequencedKeys()
+	if err != nil {
+		return errors.Wrap(err, "load keystore")
+	}
+
+	shares, err := keystore.KeysharesToValidatorPubkey(cl, valKeys)
+	if err != nil {
+		return errors.Wrap(err, "match local validator key shares with their counterparty in cluster lock")
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+	}
+
+	vals, err := depositValidators(cl, config)
+	if err != nil {
+		return err
+	}
+
+	amounts := deposit.DedupAmounts(deposit.EthsToGweis(config.DepositAmounts))
+
+	var depositDatas []eth2p0.DepositData
+	for _, val := range vals {
+		pubkey, err := core.PubKeyFromBytes(val.GetPublicKey())
+		if err != nil {
+			return err
+		}
+
+		share, ok := shares[pubkey]
+		if !ok {
+			return errors.New("validator key share not found", z.Str("validator_public_key", pubkey.String()))
+		}
+
+		for _, amount := range amounts {
+			dd, err := signPartialDeposit(share.Share, eth2p0.BLSPubKey(val.GetPublicKey()), val.GetWithdrawalAddress(), amount, compounding[pubkey], network)
+			if err != nil {
+				return errors.Wrap(err, "sign partial deposit data", z.Str("validator_public_key", pubkey.String()), z.U64("amount", uint64(amount)))
+			}
+
+			depositDatas = append(depositDatas, dd)
+		}
+
+		log.Info(ctx, "Signed partial deposit data", z.Str("validator_public_key", pubkey.String()), z.Any("amounts", amounts))
+	}
+
+	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+	if err != nil {
+		return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	}
+
+	if err := oAPI.PostPartialDeposits(ctx, cl.GetInitialMutationHash(), shareIdx, identityKey, depositDatas...); err != nil {
+		return errors.Wrap(err, "http POST partial deposit data to Obol API")
+	}
+
+	log.Info(ctx, "Submitted partial deposit data to remote API", z.Int("deposits", len(depositDatas)))
+
+	return nil
+}
+
+// signPartialDeposit returns deposit data signed with the provided validator key share.
+func signPartialDeposit(share tbls.PrivateKey, pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gwei, compounding bool, network string) (eth2p0.DepositData, error) {
+	msg, err := deposit.NewMessage(pubkey, withdrawalAddr, amount, compounding)
+	if err != nil {
+		return eth2p0.DepositData{}, err
+	}
+
+	sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+	if err != nil {
+		return eth2p0.DepositData{}, err
+	}
+
+	sig, err := tbls.Sign(share, sigRoot[:])
+	if err != nil {
+		return eth2p0.DepositData{}, err
+	}
+
+	return eth2p0.DepositData{
+		PublicKey:             msg.PublicKey,
+		WithdrawalCredentials: msg.WithdrawalCredentials,
+		Amount:                msg.Amount,
+		Signature:             eth2p0.BLSSignature(sig),
+	}, nil
+}
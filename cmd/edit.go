@@ -0,0 +1,931 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// editProposeConfig is config for the `edit propose-validator-addresses` command.
+type editProposeConfig struct {
+	ManifestFilePath    string
+	LockFilePath        string
+	LockVerify          lockVerifyConfig
+	ProposalFilePath    string
+	ValidatorPublicKeys []string
+	FeeRecipientAddrs   []string
+	RegistrationsFile   string
+	Log                 log.Config
+}
+
+// editProposeRemoveConfig is config for the `edit propose-remove-validators` command.
+type editProposeRemoveConfig struct {
+	ManifestFilePath    string
+	LockFilePath        string
+	LockVerify          lockVerifyConfig
+	ProposalFilePath    string
+	ValidatorPublicKeys []string
+	BeaconNodeEndpoints []string
+	BeaconNodeTimeout   time.Duration
+	Log                 log.Config
+}
+
+// editApproveConfig is config for the `edit approve` command.
+type editApproveConfig struct {
+	ManifestFilePath    string
+	LockFilePath        string
+	LockVerify          lockVerifyConfig
+	ProposalFilePath    string
+	PrivateKeyPath      string
+	BeaconNodeEndpoints []string
+	BeaconNodeTimeout   time.Duration
+	Log                 log.Config
+}
+
+// editApplyConfig is config for the `edit apply` command.
+type editApplyConfig struct {
+	ManifestFilePath  string
+	LockFilePath      string
+	LockVerify        lockVerifyConfig
+	ProposalFilePaths []string
+	ValidatorKeysDir  string
+	Log               log.Config
+}
+
+// defaultProposalFile is the default path of the proposal file of the edit commands.
+const defaultProposalFile = "cluster-edit-proposal.pb"
+
+func newEditCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "edit",
+		Short: "Propose, approve and apply cluster manifest mutations",
+		Long: "Propose, approve and apply mutations to the cluster manifest. A proposal file contains the proposed mutation " +
+			"followed by node approvals, it is applied to the cluster manifest once approved by at least threshold nodes.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindEditClusterFlags binds the cluster manifest, lock file and lock verification flags of the edit commands.
+func bindEditClusterFlags(cmd *cobra.Command, manifestFilePath, lockFilePath *string, lockVerify *lockVerifyConfig) {
+	cmd.Flags().StringVar(manifestFilePath, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(lockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	bindLockVerifyFlags(cmd.Flags(), lockVerify)
+}
+
+// bindEditBeaconFlags binds the beacon node flags of the edit commands.
+func bindEditBeaconFlags(cmd *cobra.Command, endpoints *[]string, timeout *time.Duration) {
+	cmd.Flags().StringSliceVar(endpoints, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs used to verify that validators are exited.")
+	cmd.Flags().DurationVar(timeout, "beacon-node-timeout", 30*time.Second, "Timeout for beacon node HTTP calls.")
+}
+
+func newEditProposeValidatorAddressesCmd(runFunc func(context.Context, editProposeConfig) error) *cobra.Command {
+	var config editProposeConfig
+
+	cmd := &cobra.Command{
+		Use:   "propose-validator-addresses",
+		Short: "Proposes new fee recipient addresses of existing validators",
+		Long: "Creates a proposal file to update the fee recipient addresses and pre-generated builder registrations of existing validators. " +
+			"Withdrawal addresses can't be updated since they are immutable once deposited. Validators with pre-generated builder " +
+			"registrations require new registrations signed with the new fee recipient. " +
+			"The proposal file must be approved by at least threshold nodes before it can be applied.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindEditClusterFlags(cmd, &config.ManifestFilePath, &config.LockFilePath, &config.LockVerify)
+	cmd.Flags().StringVar(&config.ProposalFilePath, "proposal-file", defaultProposalFile, "The path of the proposal file to create.")
+	cmd.Flags().StringSliceVar(&config.ValidatorPublicKeys, "validator-public-keys", nil, "Comma separated list of public keys of the validators to update, must be present in the cluster.")
+	cmd.Flags().StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of new fee recipient Ethereum addresses. Either provide a single address for all validators or an address for each validator.")
+	cmd.Flags().StringVar(&config.RegistrationsFile, "builder-registrations-file", "", "Optional path to a JSON file containing an array of new signed builder registrations of the validators to update.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "validator-public-keys")
+
+	return cmd
+}
+
+func runEditProposeValidatorAddresses(ctx context.Context, config editProposeConfig) error {
+	if len(config.FeeRecipientAddrs) == 0 && config.RegistrationsFile == "" {
+		return errors.New("either fee-recipient-addresses or builder-registrations-file must be specified")
+	}
+
+	feeRecipients, err := editAddresses(config.FeeRecipientAddrs, len(config.ValidatorPublicKeys))
+	if err != nil {
+		return errors.Wrap(err, "invalid fee recipient addresses")
+	}
+
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	pubkeys, err := editPubkeys(cl, config.ValidatorPublicKeys)
+	if err != nil {
+		return err
+	}
+
+	registrations, err := loadEditRegistrations(config.RegistrationsFile, pubkeys)
+	if err != nil {
+		return err
+	}
+
+	var addrs []*manifestpb.ValidatorAddresses
+	for i, pubkey := range pubkeys {
+		addrs = append(addrs, &manifestpb.ValidatorAddresses{
+			PublicKey:               pubkey,
+			FeeRecipientAddress:     feeRecipients[i],
+			BuilderRegistrationJson: registrations[i],
+		})
+	}
+
+	proposal, err := manifest.NewValidatorAddresses(cl.GetLatestMutationHash(), addrs)
+	if err != nil {
+		return err
+	}
+
+	// Verify the proposal applies to the current cluster, ignoring node approvals.
+	if err := verifyProposal(cl, proposal); err != nil {
+		return err
+	} else if err := manifest.VerifyValidatorAddresses(cl, proposal); err != nil {
+		return err
+	}
+
+	if err := writeProposal(config.ProposalFilePath, &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{proposal}}); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Validator addresses proposal created, it requires approvals of threshold nodes",
+		z.Str("proposal_file", config.ProposalFilePath),
+		z.Int("validators", len(addrs)),
+		z.I64("threshold", int64(cl.GetThreshold())))
+
+	return nil
+}
+
+func newEditProposeRemoveValidatorsCmd(runFunc func(context.Context, editProposeRemoveConfig) error) *cobra.Command {
+	var config editProposeRemoveConfig
+
+	cmd := &cobra.Command{
+		Use:   "propose-remove-validators",
+		Short: "Proposes to remove exited validators from the cluster",
+		Long: "Creates a proposal file to remove validators that exited on the beacon chain. Removed validators are moved to " +
+			"the archived validators of the cluster manifest and their key shares are archived when the proposal is applied. " +
+			"The proposal file must be approved by at least threshold nodes before it can be applied.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindEditClusterFlags(cmd, &config.ManifestFilePath, &config.LockFilePath, &config.LockVerify)
+	bindEditBeaconFlags(cmd, &config.BeaconNodeEndpoints, &config.BeaconNodeTimeout)
+	cmd.Flags().StringVar(&config.ProposalFilePath, "proposal-file", defaultProposalFile, "The path of the proposal file to create.")
+	cmd.Flags().StringSliceVar(&config.ValidatorPublicKeys, "validator-public-keys", nil, "Comma separated list of public keys of the exited validators to remove, must be present in the cluster.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "validator-public-keys")
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+
+	return cmd
+}
+
+func runEditProposeRemoveValidators(ctx context.Context, config editProposeRemoveConfig) error {
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	pubkeys, err := editPubkeys(cl, config.ValidatorPublicKeys)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyValidatorsExited(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, pubkeys); err != nil {
+		return err
+	}
+
+	proposal, err := manifest.NewValidatorRemovals(cl.GetLatestMutationHash(), pubkeys)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyProposal(cl, proposal); err != nil {
+		return err
+	}
+
+	if err := writeProposal(config.ProposalFilePath, &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{proposal}}); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Remove validators proposal created, it requires approvals of threshold nodes",
+		z.Str("proposal_file", config.ProposalFilePath),
+		z.Int("validators", len(pubkeys)),
+		z.I64("threshold", int64(cl.GetThreshold())))
+
+	return nil
+}
+
+func newEditApproveCmd(runFunc func(context.Context, editApproveConfig) error) *cobra.Command {
+	var config editApproveConfig
+
+	cmd := &cobra.Command{
+		Use:   "approve",
+		Short: "Approves a cluster manifest mutation proposal",
+		Long:  "Verifies the proposal file against the local cluster manifest, signs it with the charon enr private key and adds the node approval to the proposal file.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindEditClusterFlags(cmd, &config.ManifestFilePath, &config.LockFilePath, &config.LockVerify)
+	cmd.Flags().StringVar(&config.ProposalFilePath, "proposal-file", defaultProposalFile, "The path of the proposal file to approve.")
+	cmd.Flags().StringVar(&config.PrivateKeyPath, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	bindEditBeaconFlags(cmd, &config.BeaconNodeEndpoints, &config.BeaconNodeTimeout)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runEditApprove(ctx context.Context, config editApproveConfig) error {
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	list, err := readProposal(config.ProposalFilePath)
+	if err != nil {
+		return err
+	}
+
+	proposal := list.GetMutations()[0]
+	if err := verifyProposal(cl, proposal); err != nil {
+		return err
+	}
+
+	// Don't approve proposals that can't be applied.
+	if manifest.MutationType(proposal.GetMutation().GetType()) == manifest.TypeValidatorAddresses {
+		if err := manifest.VerifyValidatorAddresses(cl, proposal); err != nil {
+			return err
+		}
+	}
+
+	peerIdx, err := editPeerIdx(cl, identityKey.PubKey().SerializeCompressed())
+	if err != nil {
+		return err
+	}
+
+	if err := inspectProposal(ctx, config, proposal); err != nil {
+		return err
+	}
+
+	proposalHash, err := manifest.Hash(proposal)
+	if err != nil {
+		return errors.Wrap(err, "hash proposal")
+	}
+
+	approval, err := manifest.SignNodeApproval(proposalHash, identityKey)
+	if err != nil {
+		return err
+	}
+
+	// Replace any previous approval of this node.
+	mutations := []*manifestpb.SignedMutation{proposal}
+	for _, other := range list.GetMutations()[1:] {
+		if !bytes.Equal(other.GetSigner(), approval.GetSigner()) {
+			mutations = append(mutations, other)
+		}
+	}
+	mutations = append(mutations, approval)
+
+	if err := writeProposal(config.ProposalFilePath, &manifestpb.SignedMutationList{Mutations: mutations}); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Proposal approved",
+		z.Str("proposal_file", config.ProposalFilePath),
+		z.Int("peer_index", peerIdx),
+		z.Int("approvals", len(mutations)-1),
+		z.I64("threshold", int64(cl.GetThreshold())))
+
+	return nil
+}
+
+// inspectProposal logs the changes of the proposal for the operator to review and
+// verifies that validators proposed to be removed are exited.
+func inspectProposal(ctx context.Context, config editApproveConfig, proposal *manifestpb.SignedMutation) error {
+	switch manifest.MutationType(proposal.GetMutation().GetType()) {
+	case manifest.TypeValidatorAddresses:
+		addrs := new(manifestpb.ValidatorAddressesList)
+		if err := proposal.GetMutation().GetData().UnmarshalTo(addrs); err != nil {
+			return errors.Wrap(err, "unmarshal validator addresses")
+		}
+
+		for _, val := range addrs.GetValidators() {
+			pubkey, err := core.PubKeyFromBytes(val.GetPublicKey())
+			if err != nil {
+				return err
+			}
+
+			log.Info(ctx, "Approving validator addresses",
+				z.Str("validator_public_key", pubkey.String()),
+				z.Str("fee_recipient_address", val.GetFeeRecipientAddress()),
+				z.Str("withdrawal_address", val.GetWithdrawalAddress()))
+		}
+	case manifest.TypeValidatorRemovals:
+		pubkeys, err := manifest.ValidatorRemovalPublicKeys(proposal)
+		if err != nil {
+			return err
+		}
+
+		if len(config.BeaconNodeEndpoints) == 0 {
+			return errors.New("beacon-node-endpoints must be specified to verify that removed validators are exited")
+		}
+
+		if err := verifyValidatorsExited(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, pubkeys); err != nil {
+			return err
+		}
+
+		for _, pubkey := range pubkeys {
+			log.Info(ctx, "Approving validator removal", z.Str("validator_public_key", fmt.Sprintf("%#x", pubkey)))
+		}
+	}
+
+	return nil
+}
+
+func newEditApplyCmd(runFunc func(context.Context, editApplyConfig) error) *cobra.Command {
+	var config editApplyConfig
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Applies an approved cluster manifest mutation proposal",
+		Long: "Combines the node approvals of the provided copies of a proposal file, verifies that at least threshold nodes approved it " +
+			"and appends the resulting mutation to the cluster manifest file. All nodes must apply the same proposal.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindEditClusterFlags(cmd, &config.ManifestFilePath, &config.LockFilePath, &config.LockVerify)
+	cmd.Flags().StringSliceVar(&config.ProposalFilePaths, "proposal-files", []string{defaultProposalFile}, "Comma separated list of approved copies of the same proposal file.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to the directory containing the validator private key share files and passwords. Key shares of removed validators are moved to its archived subdirectory.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runEditApply(ctx context.Context, config editApplyConfig) error {
+	if len(config.ProposalFilePaths) == 0 {
+		return errors.New("no proposal files")
+	}
+
+	dag, err := loadDAGFromDisk(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return err
+	}
+
+	cl, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise cluster dag")
+	}
+
+	var (
+		proposal  *manifestpb.SignedMutation
+		approvals = make(map[int]*manifestpb.SignedMutation)
+	)
+	for _, path := range config.ProposalFilePaths {
+		list, err := readProposal(path)
+		if err != nil {
+			return err
+		}
+
+		if proposal == nil {
+			proposal = list.GetMutations()[0]
+		} else if !proto.Equal(proposal, list.GetMutations()[0]) {
+			return errors.New("proposal files contain different proposals", z.Str("proposal_file", path))
+		}
+
+		for _, approval := range list.GetMutations()[1:] {
+			peerIdx, err := editPeerIdx(cl, approval.GetSigner())
+			if err != nil {
+				return err
+			}
+
+			approvals[peerIdx] = approval
+		}
+	}
+
+	if err := verifyProposal(cl, proposal); err != nil {
+		return err
+	}
+
+	// Order node approvals by peer index for a deterministic mutation.
+	var peerIdxs []int
+	for peerIdx := range approvals {
+		peerIdxs = append(peerIdxs, peerIdx)
+	}
+	sort.Ints(peerIdxs)
+
+	var ordered []*manifestpb.SignedMutation
+	for _, peerIdx := range peerIdxs {
+		ordered = append(ordered, approvals[peerIdx])
+	}
+
+	if len(ordered) == 0 {
+		return errors.New("proposal not approved by any node")
+	}
+
+	nodeApprovals, err := manifest.NewNodeApprovalsComposite(ordered)
+	if err != nil {
+		return err
+	}
+
+	var mutation *manifestpb.SignedMutation
+	if manifest.MutationType(proposal.GetMutation().GetType()) == manifest.TypeValidatorRemovals {
+		mutation, err = manifest.NewRemoveValidators(proposal, nodeApprovals)
+	} else {
+		mutation, err = manifest.NewUpdateValidatorAddresses(proposal, nodeApprovals)
+	}
+	if err != nil {
+		return err
+	}
+
+	dag.Mutations = append(dag.Mutations, mutation)
+
+	// Materialise the whole DAG to verify node approvals before writing it.
+	prev := cl
+	cl, err = manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "apply proposal")
+	}
+
+	// Key shares of removed validators are archived before writing the cluster manifest,
+	// so the validator keys always match the cluster manifest on disk.
+	var backup string
+	if manifest.MutationType(proposal.GetMutation().GetType()) == manifest.TypeValidatorRemovals {
+		removed, err := manifest.ValidatorRemovalPublicKeys(proposal)
+		if err != nil {
+			return err
+		}
+
+		backup, err = swapKeyShares(ctx, config.ValidatorKeysDir, prev, removed)
+		if err != nil {
+			return errors.Wrap(err, "archive key shares of removed validators", z.Str("validator_keys_dir", config.ValidatorKeysDir))
+		}
+	}
+
+	if err := writeManifestAtomic(config.ManifestFilePath, dag); err != nil {
+		if backup != "" {
+			if err := restoreKeyShares(config.ValidatorKeysDir, backup); err != nil {
+				log.Error(ctx, "Failed restoring validator keys directory, restore it manually from the backup", err)
+			}
+		}
+
+		return err
+	}
+
+	if backup != "" {
+		if err := os.RemoveAll(backup); err != nil {
+			log.Warn(ctx, "Failed removing validator keys directory backup", err, z.Str("backup", backup))
+		}
+	}
+
+	log.Info(ctx, "Proposal applied to cluster manifest",
+		z.Str("manifest_file_path", config.ManifestFilePath),
+		z.Str("cluster_hash", hex7(cl.GetInitialMutationHash())),
+		z.Str("latest_mutation_hash", hex7(cl.GetLatestMutationHash())),
+		z.Int("approvals", len(ordered)))
+
+	return nil
+}
+
+// loadEditRegistrations returns the json-formatted builder registrations of the provided validators
+// read from the registrations file, or nil registrations if no file is provided.
+func loadEditRegistrations(path string, pubkeys [][]byte) ([][]byte, error) {
+	resp := make([][]byte, len(pubkeys))
+	if path == "" {
+		return resp, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read builder registrations file", z.Str("path", path))
+	}
+
+	var regs []*eth2api.VersionedSignedValidatorRegistration
+	if err := json.Unmarshal(b, &regs); err != nil {
+		return nil, errors.Wrap(err, "unmarshal builder registrations file", z.Str("path", path))
+	}
+
+	for _, reg := range regs {
+		pubkey, err := reg.PubKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "builder registration public key")
+		}
+
+		idx := slices.IndexFunc(pubkeys, func(p []byte) bool { return bytes.Equal(p, pubkey[:]) })
+		if idx < 0 {
+			return nil, errors.New("builder registration of validator not proposed", z.Str("pubkey", fmt.Sprintf("%#x", pubkey[:])))
+		} else if resp[idx] != nil {
+			return nil, errors.New("duplicate builder registration", z.Str("pubkey", fmt.Sprintf("%#x", pubkey[:])))
+		}
+
+		resp[idx], err = json.Marshal(reg)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal builder registration")
+		}
+	}
+
+	return resp, nil
+}
+
+// editAddresses returns the checksummed addresses for numVals validators,
+// repeating a single address for all validators. It returns empty addresses if none are provided.
+func editAddresses(addrs []string, numVals int) ([]string, error) {
+	if len(addrs) == 0 {
+		return make([]string, numVals), nil
+	}
+
+	if len(addrs) == 1 {
+		addrs = repeatAddr(addrs[0], numVals)
+	}
+
+	if len(addrs) != numVals {
+		return nil, errors.New("addresses and validator public keys lengths mismatch",
+			z.Int("addresses", len(addrs)), z.Int("validator_public_keys", numVals))
+	}
+
+	var resp []string
+	for _, addr := range addrs {
+		checksummed, err := eth2util.ChecksumAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, checksummed)
+	}
+
+	return resp, nil
+}
+
+// editPubkeys returns the validator public keys parsed from hex, ensuring they are present in the cluster.
+func editPubkeys(cl *manifestpb.Cluster, pubkeyHexes []string) ([][]byte, error) {
+	var resp [][]byte
+	for _, pubkeyHex := range pubkeyHexes {
+		pubkey, err := core.PubKey(pubkeyHex).Bytes()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator public key", z.Str("validator_public_key", pubkeyHex))
+		}
+
+		if !slices.ContainsFunc(cl.GetValidators(), func(val *manifestpb.Validator) bool {
+			return bytes.Equal(val.GetPublicKey(), pubkey)
+		}) {
+			return nil, errors.New("validator public key not found in cluster", z.Str("validator_public_key", pubkeyHex))
+		}
+
+		resp = append(resp, pubkey)
+	}
+
+	return resp, nil
+}
+
+// verifyValidatorsExited returns an error if any of the validators isn't exited on the beacon chain.
+func verifyValidatorsExited(ctx context.Context, endpoints []string, timeout time.Duration, pubkeys [][]byte) error {
+	eth2Cl, err := eth2Client(ctx, endpoints, timeout, [4]byte{}) // fine to avoid initializing a fork version, we're just querying the BN
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", endpoints))
+	}
+
+	var eth2Pubkeys []eth2p0.BLSPubKey
+	for _, pubkey := range pubkeys {
+		eth2Pubkeys = append(eth2Pubkeys, eth2p0.BLSPubKey(pubkey))
+	}
+
+	resp, err := eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{
+		PubKeys: eth2Pubkeys,
+		State:   "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "fetch validators from beacon", z.Str("beacon_address", eth2Cl.Address()))
+	}
+
+	states := make(map[eth2p0.BLSPubKey]eth2v1.ValidatorState)
+	for _, val := range resp.Data {
+		states[val.Validator.PublicKey] = val.Status
+	}
+
+	for _, pubkey := range eth2Pubkeys {
+		state, ok := states[pubkey]
+		if !ok {
+			return errors.New("validator not found on beacon node", z.Str("validator_public_key", pubkey.String()))
+		} else if !state.HasExited() {
+			return errors.New("validator not exited", z.Str("validator_public_key", pubkey.String()), z.Str("status", state.String()))
+		}
+	}
+
+	return nil
+}
+
+// swapKeyShares replaces the validator keys directory by a staged copy with the key shares of the removed validators
+// moved to its archived subdirectory, see stageKeyShares. It returns the backup of the previous directory which must
+// be removed once the cluster manifest is written or restored if that fails, or an empty string if the validator keys
+// directory doesn't exist.
+func swapKeyShares(ctx context.Context, dir string, cl *manifestpb.Cluster, removed [][]byte) (string, error) {
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		log.Warn(ctx, "Validator keys directory not found, key shares of removed validators not archived", nil, z.Str("validator_keys_dir", dir))
+		return "", nil
+	}
+
+	staged, err := stageKeyShares(ctx, dir, cl, removed)
+	if err != nil {
+		return "", err
+	}
+
+	backup := strings.TrimSuffix(staged, ".tmp") + ".bak"
+	if err := os.Rename(dir, backup); err != nil {
+		_ = os.RemoveAll(staged)
+		return "", errors.Wrap(err, "backup validator keys directory")
+	}
+
+	if err := os.Rename(staged, dir); err != nil {
+		_ = os.Rename(backup, dir)
+		_ = os.RemoveAll(staged)
+
+		return "", errors.Wrap(err, "swap in staged validator keys directory")
+	}
+
+	return backup, nil
+}
+
+// restoreKeyShares restores the validator keys directory from its backup.
+func restoreKeyShares(dir, backup string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Wrap(err, "remove staged validator keys directory")
+	}
+
+	if err := os.Rename(backup, dir); err != nil {
+		return errors.Wrap(err, "restore validator keys directory", z.Str("backup", backup))
+	}
+
+	return nil
+}
+
+// stageKeyShares copies the validator keys directory to a temporary sibling directory, moving the keystore files
+// of the removed validators to the archived subdirectory and renumbering the remaining keystore files sequentially,
+// since keystore indexes must match the order of the cluster validators. It returns the staged directory.
+// Staging an already archived directory doesn't change it, so a failed apply can be rerun.
+func stageKeyShares(ctx context.Context, dir string, cl *manifestpb.Cluster, removed [][]byte) (string, error) {
+	keyFiles, err := keystore.LoadFilesUnordered(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "load keystore")
+	}
+
+	if _, err := keyFiles.SequencedKeys(); err != nil {
+		return "", errors.Wrap(err, "load keystore")
+	}
+
+	sort.Slice(keyFiles, func(i, j int) bool {
+		return keyFiles[i].FileIndex < keyFiles[j].FileIndex
+	})
+
+	// moves maps the keystore filenames relative to dir to their staged filenames.
+	moves := make(map[string]string)
+
+	var remaining []keystore.KeyFile
+	for _, keyFile := range keyFiles {
+		pubshare, err := tbls.SecretToPublicKey(keyFile.PrivateKey)
+		if err != nil {
+			return "", errors.Wrap(err, "private share to public share")
+		}
+
+		idx := slices.IndexFunc(cl.GetValidators(), func(val *manifestpb.Validator) bool {
+			return slices.ContainsFunc(val.GetPubShares(), func(share []byte) bool {
+				return bytes.Equal(share, pubshare[:])
+			})
+		})
+		if idx < 0 {
+			return "", errors.New("key share not found in cluster", z.Str("filename", keyFile.Filename))
+		}
+
+		pubkey := cl.GetValidators()[idx].GetPublicKey()
+		if !slices.ContainsFunc(removed, func(other []byte) bool { return bytes.Equal(other, pubkey) }) {
+			remaining = append(remaining, keyFile)
+			continue
+		}
+
+		archived := filepath.Join("archived", fmt.Sprintf("keystore-%x.json", pubkey))
+		addKeystoreMove(moves, filepath.Base(keyFile.Filename), archived)
+
+		log.Info(ctx, "Archiving key share of removed validator",
+			z.Str("validator_public_key", fmt.Sprintf("%#x", pubkey)), z.Str("filename", filepath.Join(dir, archived)))
+	}
+
+	for i, keyFile := range remaining {
+		prefix := strings.TrimSuffix(filepath.Base(keyFile.Filename), fmt.Sprintf("%d.json", keyFile.FileIndex))
+		addKeystoreMove(moves, filepath.Base(keyFile.Filename), fmt.Sprintf("%s%d.json", prefix, i))
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "stat validator keys directory")
+	}
+
+	staged, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".*.tmp")
+	if err != nil {
+		return "", errors.Wrap(err, "create staged validator keys directory")
+	}
+
+	if err := copyKeysDir(dir, staged, info.Mode().Perm(), moves); err != nil {
+		_ = os.RemoveAll(staged)
+		return "", err
+	}
+
+	return staged, nil
+}
+
+// addKeystoreMove adds the move of the keystore file and its password file.
+func addKeystoreMove(moves map[string]string, from, to string) {
+	moves[from] = to
+	moves[strings.TrimSuffix(from, ".json")+".txt"] = strings.TrimSuffix(to, ".json") + ".txt"
+}
+
+// copyKeysDir copies all files in dir to the staged directory, renaming the files in moves.
+func copyKeysDir(dir, staged string, perm os.FileMode, moves map[string]string) error {
+	if err := os.Chmod(staged, perm); err != nil {
+		return errors.Wrap(err, "chmod staged validator keys directory")
+	}
+
+	return filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return errors.Wrap(err, "walk validator keys directory")
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return errors.Wrap(err, "relative keystore path")
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return errors.Wrap(err, "keystore file info", z.Str("filename", path))
+		}
+
+		if entry.IsDir() {
+			if rel == "." {
+				return nil
+			}
+
+			if err := os.MkdirAll(filepath.Join(staged, rel), info.Mode().Perm()); err != nil {
+				return errors.Wrap(err, "create staged directory", z.Str("dir", rel))
+			}
+
+			return nil
+		}
+
+		if to, ok := moves[rel]; ok {
+			rel = to
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "read keystore file", z.Str("filename", path))
+		}
+
+		target := filepath.Join(staged, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return errors.Wrap(err, "create staged directory", z.Str("dir", filepath.Dir(rel)))
+		}
+
+		if err := os.WriteFile(target, b, info.Mode().Perm()); err != nil {
+			return errors.Wrap(err, "write staged keystore file", z.Str("filename", target))
+		}
+
+		return nil
+	})
+}
+
+// verifyProposal returns an error if the proposal doesn't apply to the latest mutation of the cluster.
+func verifyProposal(cl *manifestpb.Cluster, proposal *manifestpb.SignedMutation) error {
+	switch manifest.MutationType(proposal.GetMutation().GetType()) {
+	case manifest.TypeValidatorAddresses, manifest.TypeValidatorRemovals:
+	default:
+		return errors.New("unsupported proposal mutation type", z.Str("type", proposal.GetMutation().GetType()))
+	}
+
+	if !bytes.Equal(proposal.GetMutation().GetParent(), cl.GetLatestMutationHash()) {
+		return errors.New("proposal doesn't apply to the latest cluster manifest, it may have been applied already",
+			z.Str("proposal_parent", hex7(proposal.GetMutation().GetParent())),
+			z.Str("latest_mutation_hash", hex7(cl.GetLatestMutationHash())))
+	}
+
+	return nil
+}
+
+// editPeerIdx returns the index of the cluster operator with the provided compressed public key.
+func editPeerIdx(cl *manifestpb.Cluster, pubkey []byte) (int, error) {
+	peers, err := manifest.ClusterPeers(cl)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, p := range peers {
+		peerPubkey, err := p.PublicKey()
+		if err != nil {
+			return 0, errors.Wrap(err, "get peer public key")
+		}
+
+		if bytes.Equal(peerPubkey.SerializeCompressed(), pubkey) {
+			return i, nil
+		}
+	}
+
+	return 0, errors.New("node is not an operator of the cluster")
+}
+
+// readProposal returns the proposal file mutations, the proposed mutation followed by node approvals.
+func readProposal(path string) (*manifestpb.SignedMutationList, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read proposal file", z.Str("proposal_file", path))
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := proto.Unmarshal(b, list); err != nil {
+		return nil, errors.Wrap(err, "unmarshal proposal file", z.Str("proposal_file", path))
+	}
+
+	if len(list.GetMutations()) == 0 {
+		return nil, errors.New("empty proposal file", z.Str("proposal_file", path))
+	}
+
+	return list, nil
+}
+
+// writeProposal writes the proposal file mutations to disk.
+func writeProposal(path string, list *manifestpb.SignedMutationList) error {
+	b, err := proto.Marshal(list)
+	if err != nil {
+		return errors.Wrap(err, "proto marshal proposal")
+	}
+
+	//nolint:gosec // File needs to be read-write since approvals are added to the proposal.
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return errors.Wrap(err, "write proposal file", z.Str("proposal_file", path))
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestEditValidatorAddresses(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, shares := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	manifestFile := filepath.Join(dir, "cluster-manifest.pb")
+	proposalFile := filepath.Join(dir, "proposal.pb")
+	registrationsFile := filepath.Join(dir, "builder-registrations.json")
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	var keyFiles []string
+	for i, key := range p2pKeys {
+		keyFile := filepath.Join(dir, fmt.Sprintf("charon-enr-private-key-%d", i))
+		require.NoError(t, k1util.Save(key, keyFile))
+		keyFiles = append(keyFiles, keyFile)
+	}
+
+	feeRecipient := testutil.RandomChecksummedETHAddress(t, seed)
+
+	propose := func(t *testing.T) error {
+		t.Helper()
+
+		return runEditProposeValidatorAddresses(ctx, editProposeConfig{
+			ManifestFilePath:    manifestFile,
+			LockFilePath:        lockFile,
+			ProposalFilePath:    proposalFile,
+			ValidatorPublicKeys: []string{lock.Validators[1].PublicKeyHex()},
+			FeeRecipientAddrs:   []string{feeRecipient},
+			RegistrationsFile:   registrationsFile,
+		})
+	}
+
+	// The pre-generated builder registration of the validator must be replaced.
+	require.NoError(t, os.WriteFile(registrationsFile, []byte("[]"), 0o644))
+	require.ErrorContains(t, propose(t), "fee recipient change requires a new builder registration")
+
+	approve := func(t *testing.T, peerIdx int) error {
+		t.Helper()
+
+		return runEditApprove(ctx, editApproveConfig{
+			ManifestFilePath: manifestFile,
+			LockFilePath:     lockFile,
+			ProposalFilePath: proposalFile,
+			PrivateKeyPath:   keyFiles[peerIdx],
+		})
+	}
+
+	// Proposals that can't be applied aren't approved.
+	cl, err := manifest.LoadCluster(manifestFile, lockFile, nil)
+	require.NoError(t, err)
+	invalid, err := manifest.NewValidatorAddresses(cl.GetLatestMutationHash(), []*manifestpb.ValidatorAddresses{{
+		PublicKey:           lock.Validators[1].PubKey,
+		FeeRecipientAddress: feeRecipient,
+	}})
+	require.NoError(t, err)
+	require.NoError(t, writeProposal(proposalFile, &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{invalid}}))
+	require.ErrorContains(t, approve(t, 0), "fee recipient change requires a new builder registration")
+
+	shareMap := make(map[int]tbls.PrivateKey)
+	for i, share := range shares[1] {
+		shareMap[i+1] = share
+	}
+	secret, err := tbls.RecoverSecret(shareMap, uint(len(shares[1])), uint(lock.Threshold))
+	require.NoError(t, err)
+
+	regs, err := signValidatorRegistrations([]tbls.PrivateKey{secret}, []string{feeRecipient}, lock.ForkVersion, false)
+	require.NoError(t, err)
+	regJSON, err := json.Marshal(&regs[0].VersionedSignedValidatorRegistration)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(registrationsFile, []byte("["+string(regJSON)+"]"), 0o644))
+
+	require.NoError(t, propose(t))
+
+	apply := func(t *testing.T) error {
+		t.Helper()
+
+		return runEditApply(ctx, editApplyConfig{
+			ManifestFilePath:  manifestFile,
+			LockFilePath:      lockFile,
+			ProposalFilePaths: []string{proposalFile},
+		})
+	}
+
+	// Approving twice doesn't count twice.
+	require.NoError(t, approve(t, 0))
+	require.NoError(t, approve(t, 0))
+	require.NoError(t, approve(t, 2))
+	require.ErrorContains(t, apply(t), "insufficient node approvals")
+
+	require.NoError(t, approve(t, 3))
+	require.NoError(t, apply(t))
+
+	cl, err = manifest.LoadCluster(manifestFile, lockFile, nil)
+	require.NoError(t, err)
+	require.Equal(t, feeRecipient, cl.GetValidators()[1].GetFeeRecipientAddress())
+	require.JSONEq(t, string(regJSON), string(cl.GetValidators()[1].GetBuilderRegistrationJson()))
+	require.Equal(t, lock.ValidatorAddresses[0].FeeRecipientAddress, cl.GetValidators()[0].GetFeeRecipientAddress())
+
+	// The proposal no longer applies to the updated cluster manifest.
+	require.ErrorContains(t, approve(t, 1), "proposal doesn't apply to the latest cluster manifest")
+	require.ErrorContains(t, apply(t), "proposal doesn't apply to the latest cluster manifest")
+}
+
+func TestEditRemoveValidators(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, shares := cluster.NewForT(t, 3, 3, 4, seed, random)
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	manifestFile := filepath.Join(dir, "cluster-manifest.pb")
+	proposalFile := filepath.Join(dir, "proposal.pb")
+	keysDir := filepath.Join(dir, "validator_keys")
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+	require.NoError(t, os.Mkdir(keysDir, 0o755))
+
+	var keyFiles []string
+	for i, key := range p2pKeys {
+		keyFile := filepath.Join(dir, fmt.Sprintf("charon-enr-private-key-%d", i))
+		require.NoError(t, k1util.Save(key, keyFile))
+		keyFiles = append(keyFiles, keyFile)
+	}
+
+	// Store the key shares of the first node.
+	var nodeShares []tbls.PrivateKey
+	for _, valShares := range shares {
+		nodeShares = append(nodeShares, valShares[0])
+	}
+	require.NoError(t, keystore.StoreKeysInsecure(nodeShares, keysDir, keystore.ConfirmInsecureKeys))
+
+	// Only the first validator is exited.
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, val := range lock.Validators {
+		status := eth2v1.ValidatorStateActiveOngoing
+		if idx == 0 {
+			status = eth2v1.ValidatorStateWithdrawalPossible
+		}
+
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:  eth2p0.ValidatorIndex(idx),
+			Status: status,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(val.PubKey),
+				WithdrawalCredentials: testutil.RandomBytes32(),
+			},
+		}
+	}
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(validatorSet))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+
+	propose := func(t *testing.T, valIdx int) error {
+		t.Helper()
+
+		return runEditProposeRemoveValidators(ctx, editProposeRemoveConfig{
+			ManifestFilePath:    manifestFile,
+			LockFilePath:        lockFile,
+			ProposalFilePath:    proposalFile,
+			ValidatorPublicKeys: []string{lock.Validators[valIdx].PublicKeyHex()},
+			BeaconNodeEndpoints: []string{bmock.Address()},
+			BeaconNodeTimeout:   time.Second,
+		})
+	}
+
+	require.ErrorContains(t, propose(t, 1), "validator not exited")
+	require.NoError(t, propose(t, 0))
+
+	for _, peerIdx := range []int{0, 1, 2} {
+		require.NoError(t, runEditApprove(ctx, editApproveConfig{
+			ManifestFilePath:    manifestFile,
+			LockFilePath:        lockFile,
+			ProposalFilePath:    proposalFile,
+			PrivateKeyPath:      keyFiles[peerIdx],
+			BeaconNodeEndpoints: []string{bmock.Address()},
+			BeaconNodeTimeout:   time.Second,
+		}))
+	}
+
+	// The validator keys directory is restored if the cluster manifest can't be written.
+	require.ErrorContains(t, runEditApply(ctx, editApplyConfig{
+		ManifestFilePath:  filepath.Join(dir, "missing", "cluster-manifest.pb"),
+		LockFilePath:      lockFile,
+		ProposalFilePaths: []string{proposalFile},
+		ValidatorKeysDir:  keysDir,
+	}), "create temporary cluster manifest")
+
+	keys, err := keystore.LoadFilesUnordered(keysDir)
+	require.NoError(t, err)
+	sequenced, err := keys.SequencedKeys()
+	require.NoError(t, err)
+	require.Equal(t, nodeShares, sequenced)
+	require.NoDirExists(t, filepath.Join(keysDir, "archived"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.False(t, strings.HasPrefix(entry.Name(), "validator_keys."), "staged directory not removed: %s", entry.Name())
+	}
+
+	require.NoError(t, runEditApply(ctx, editApplyConfig{
+		ManifestFilePath:  manifestFile,
+		LockFilePath:      lockFile,
+		ProposalFilePaths: []string{proposalFile},
+		ValidatorKeysDir:  keysDir,
+	}))
+
+	cl, err := manifest.LoadCluster(manifestFile, lockFile, nil)
+	require.NoError(t, err)
+	require.Len(t, cl.GetValidators(), 2)
+	require.Len(t, cl.GetArchivedValidators(), 1)
+	require.Equal(t, lock.Validators[0].PubKey, cl.GetArchivedValidators()[0].GetPublicKey())
+
+	// Remaining key shares are renumbered to match the remaining validators.
+	keys, err = keystore.LoadFilesUnordered(keysDir)
+	require.NoError(t, err)
+	sequenced, err = keys.SequencedKeys()
+	require.NoError(t, err)
+	require.Equal(t, nodeShares[1:], sequenced)
+
+	// The removed validator's key share is archived.
+	archived, err := keystore.LoadFilesUnordered(filepath.Join(keysDir, "archived"))
+	require.NoError(t, err)
+	require.Equal(t, nodeShares[:1], archived.Keys())
+
+	_, err = keystore.KeysharesToValidatorPubkey(cl, sequenced)
+	require.NoError(t, err)
+
+	// Exits resolve the archived key share of the removed validator.
+	share, err := archivedValidatorShare(ctx, exitConfig{ValidatorKeysDir: keysDir}, cl, core.PubKey(lock.Validators[0].PublicKeyHex()))
+	require.NoError(t, err)
+	require.Equal(t, nodeShares[0], share.Share)
+
+	_, err = archivedValidatorShare(ctx, exitConfig{ValidatorKeysDir: keysDir}, cl, core.PubKey(lock.Validators[1].PublicKeyHex()))
+	require.ErrorContains(t, err, "validator not present in cluster lock")
+}
+
+func TestEditProposeValidatorAddressesErrors(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lock := writeMigrateTestLock(t, dir, 1)
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+
+	tests := []struct {
+		name   string
+		config editProposeConfig
+		errMsg string
+	}{
+		{
+			name:   "no addresses",
+			config: editProposeConfig{ValidatorPublicKeys: []string{lock.Validators[0].PublicKeyHex()}},
+			errMsg: "either fee-recipient-addresses or builder-registrations-file must be specified",
+		},
+		{
+			name: "mismatching addresses",
+			config: editProposeConfig{
+				ValidatorPublicKeys: []string{lock.Validators[0].PublicKeyHex()},
+				FeeRecipientAddrs:   []string{testutil.RandomChecksummedETHAddress(t, 1), testutil.RandomChecksummedETHAddress(t, 2)},
+			},
+			errMsg: "addresses and validator public keys lengths mismatch",
+		},
+		{
+			name: "unknown validator",
+			config: editProposeConfig{
+				ValidatorPublicKeys: []string{fmt.Sprintf("%#x", testutil.RandomBytes48())},
+				FeeRecipientAddrs:   []string{testutil.RandomChecksummedETHAddress(t, 1)},
+			},
+			errMsg: "validator public key not found in cluster",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.LockFilePath = lockFile
+			test.config.ProposalFilePath = filepath.Join(dir, "proposal.pb")
+
+			require.ErrorContains(t, runEditProposeValidatorAddresses(ctx, test.config), test.errMsg)
+		})
+	}
+}
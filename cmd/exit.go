@@ -27,8 +27,12 @@ type exitConfig struct {
 	PrivateKeyPath        string
 	ValidatorKeysDir      string
 	LockFilePath          string
+	ManifestFilePath      string
+	LockVerify            lockVerifyConfig
 	PublishAddress        string
 	PublishTimeout        time.Duration
+	NodeAPIAddress        string
+	NodeAPIToken          string
 	ExitEpoch             uint64
 	FetchedExitPath       string
 	PlaintextOutput       bool
@@ -37,6 +41,14 @@ type exitConfig struct {
 	ExitFromFileDir       string
 	Log                   log.Config
 	All                   bool
+	ELRequestType         string
+	WithdrawalAmount      uint64
+	TargetPubkey          string
+	RequestFee            uint64
+	SafeAddress           string
+	ELRequestFile         string
+	TrackELRequests       bool
+	TrackTimeout          time.Duration
 	testnetConfig         eth2util.Network
 }
 
@@ -44,7 +56,7 @@ func newExitCmd(cmds ...*cobra.Command) *cobra.Command {
 	root := &cobra.Command{
 		Use:   "exit",
 		Short: "Exit a distributed validator.",
-		Long:  "Sign and broadcast distributed validator exit messages using a remote API.",
+		Long:  "Sign and broadcast distributed validator exit messages using a remote API, or peer-to-peer via the local charon nodes.",
 	}
 
 	root.AddCommand(cmds...)
@@ -74,6 +86,10 @@ const (
 	testnetChainID
 	testnetGenesisTimestamp
 	testnetCapellaHardFork
+	nodeAPIAddress
+	nodeAPIToken
+	manifestFilePath
+	lockVerify
 )
 
 func (ef exitFlag) String() string {
@@ -116,6 +132,14 @@ func (ef exitFlag) String() string {
 		return "testnet-genesis-timestamp"
 	case testnetCapellaHardFork:
 		return "testnet-capella-hard-fork"
+	case nodeAPIAddress:
+		return "node-api-address"
+	case nodeAPIToken:
+		return "node-api-token"
+	case manifestFilePath:
+		return "manifest-file"
+	case lockVerify:
+		return "no-verify"
 	default:
 		return "unknown"
 	}
@@ -146,7 +170,9 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 		case privateKeyPath:
 			cmd.Flags().StringVar(&config.PrivateKeyPath, privateKeyPath.String(), ".charon/charon-enr-private-key", maybeRequired("The path to the charon enr private key file. "))
 		case lockFilePath:
-			cmd.Flags().StringVar(&config.LockFilePath, lockFilePath.String(), ".charon/cluster-lock.json", maybeRequired("The path to the cluster lock file defining the distributed validator cluster."))
+			cmd.Flags().StringVar(&config.LockFilePath, lockFilePath.String(), ".charon/cluster-lock.json", maybeRequired("The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence."))
+		case manifestFilePath:
+			cmd.Flags().StringVar(&config.ManifestFilePath, manifestFilePath.String(), ".charon/cluster-manifest.pb", maybeRequired("The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence."))
 		case validatorKeysDir:
 			cmd.Flags().StringVar(&config.ValidatorKeysDir, validatorKeysDir.String(), ".charon/validator_keys", maybeRequired("Path to the directory containing the validator private key share files and passwords."))
 		case validatorPubkey:
@@ -177,6 +203,12 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 			cmd.Flags().Int64Var(&config.testnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
 		case testnetCapellaHardFork:
 			cmd.Flags().StringVar(&config.testnetConfig.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
+		case nodeAPIAddress:
+			cmd.Flags().StringVar(&config.NodeAPIAddress, nodeAPIAddress.String(), "", "Validator API address of the local charon node, e.g. http://127.0.0.1:3600. If set, partial exits are submitted to the node instead of --publish-address. The nodes exchange the partial exits peer-to-peer and broadcast the aggregated exit to the beacon node once threshold is reached.")
+		case lockVerify:
+			bindLockVerifyFlags(cmd.Flags(), &config.LockVerify)
+		case nodeAPIToken:
+			cmd.Flags().StringVar(&config.NodeAPIToken, nodeAPIToken.String(), "", "Bearer token authorising requests to the local charon node validator API, if it requires authentication.")
 		}
 
 		if f.required {
@@ -55,6 +55,8 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 		{publishAddress, false},
 		{privateKeyPath, false},
 		{lockFilePath, false},
+		{manifestFilePath, false},
+		{lockVerify, false},
 		{validatorKeysDir, false},
 		{exitEpoch, false},
 		{validatorPubkey, false},
@@ -116,9 +118,9 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "load identity key")
 	}
 
-	cl, err := loadClusterManifest("", config.LockFilePath)
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
 	if err != nil {
-		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
 	}
 
 	eth2Cl, err := eth2Client(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
@@ -0,0 +1,384 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/elrequest"
+)
+
+const (
+	// partialWithdrawalTolerance is the balance decrease in gwei below the withdrawal amount
+	// still considered a processed partial withdrawal, accounting for rewards accrued in the meantime.
+	partialWithdrawalTolerance = 10000000 // 0.01 ETH
+
+	// compoundingWithdrawalPrefix is the withdrawal credentials prefix of compounding validators.
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// elRequestTrackInterval is the interval at which the beacon node is polled when tracking requests.
+var elRequestTrackInterval = 12 * time.Second
+
+func newELRequestCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "el-request",
+		Short: "Generate execution layer withdrawal or consolidation requests for distributed validators",
+		Long: "Generates EIP-7002 withdrawal requests (full exits or partial withdrawals) or EIP-7251 consolidation requests " +
+			"for distributed validators as a Safe transaction builder batch file, to be submitted by the validators' withdrawal address. " +
+			"These requests don't require threshold BLS signatures. Optionally tracks processing of the requests via a beacon node.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitFlags(cmd, &config, []exitCLIFlag{
+		{lockFilePath, false},
+		{manifestFilePath, false},
+		{lockVerify, false},
+		{validatorPubkey, false},
+		{all, false},
+		{beaconNodeEndpoints, false},
+		{beaconNodeTimeout, false},
+		{testnetName, false},
+		{testnetForkVersion, false},
+		{testnetChainID, false},
+		{testnetGenesisTimestamp, false},
+		{testnetCapellaHardFork, false},
+	})
+
+	cmd.Flags().StringVar(&config.ELRequestType, "request-type", string(elrequest.TypeWithdrawal), "Type of execution layer request. Options: withdrawal, consolidation.")
+	cmd.Flags().Uint64Var(&config.WithdrawalAmount, "withdrawal-amount", 0, "Partial withdrawal amount in gwei of withdrawal requests, only supported for validators with compounding withdrawal credentials. Zero requests a full exit of the validator.")
+	cmd.Flags().StringVar(&config.TargetPubkey, "target-public-key", "", "Public key of the target validator of consolidation requests. If empty, the validators switch to compounding withdrawal credentials instead.")
+	cmd.Flags().Uint64Var(&config.RequestFee, "request-fee", elrequest.MinRequestFee, "Fee in wei sent with each request. Must be at least the current request fee of the predeploy contract, which increases if the request queue is congested. Excess fees are not refunded.")
+	cmd.Flags().StringVar(&config.SafeAddress, "safe-address", "", "Address of the Safe submitting the requests. Defaults to the withdrawal address of the validators.")
+	cmd.Flags().StringVar(&config.ELRequestFile, "output-file", "./el-requests.json", "Path to write the Safe transaction builder batch file to.")
+	cmd.Flags().BoolVar(&config.TrackELRequests, "track", false, "Track processing of the requests via the beacon node after generating them. Requires --beacon-node-endpoints.")
+	cmd.Flags().DurationVar(&config.TrackTimeout, "track-timeout", 24*time.Hour, "Maximum duration to track processing of the requests.")
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
+		valPubkPresent := cmd.Flags().Lookup(validatorPubkey.String()).Changed
+
+		if !valPubkPresent && !config.All {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("either %s or %s must be specified.", validatorPubkey.String(), all.String()))
+		}
+
+		if config.All && valPubkPresent {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s should not be specified when %s is, as it is obsolete and misleading.", validatorPubkey.String(), all.String()))
+		}
+
+		switch elrequest.Type(config.ELRequestType) {
+		case elrequest.TypeWithdrawal:
+			if config.TargetPubkey != "" {
+				return errors.New("target-public-key only supported for consolidation requests")
+			}
+		case elrequest.TypeConsolidation:
+			if config.WithdrawalAmount != 0 {
+				return errors.New("withdrawal-amount only supported for withdrawal requests")
+			}
+		default:
+			return errors.New("unsupported request type", z.Str("request_type", config.ELRequestType))
+		}
+
+		if config.RequestFee < elrequest.MinRequestFee {
+			return errors.New("request fee below minimum", z.U64("request_fee", config.RequestFee))
+		}
+
+		if config.TrackELRequests && len(config.BeaconNodeEndpoints) == 0 {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s is required when tracking requests.", beaconNodeEndpoints.String()))
+		}
+
+		return nil
+	})
+
+	return cmd
+}
+
+func runELRequest(ctx context.Context, config exitConfig) error {
+	// Check if custom testnet configuration is provided.
+	if config.testnetConfig.IsNonZero() {
+		// Add testnet config to supported networks.
+		eth2util.AddTestNetwork(config.testnetConfig)
+	}
+
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	chainID, err := eth2util.ForkVersionToChainID(cl.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	var target eth2p0.BLSPubKey
+	if config.TargetPubkey != "" {
+		target, err = core.PubKey(config.TargetPubkey).ToETH2()
+		if err != nil {
+			return errors.Wrap(err, "invalid target public key", z.Str("target_public_key", config.TargetPubkey))
+		}
+	}
+
+	var (
+		reqs           []elrequest.Request
+		withdrawalAddr string
+	)
+	for _, val := range cl.GetValidators() {
+		pubkey := eth2p0.BLSPubKey(val.GetPublicKey())
+		if !config.All && !strings.EqualFold(pubkey.String(), config.ValidatorPubkey) {
+			continue
+		}
+
+		if withdrawalAddr == "" {
+			withdrawalAddr = val.GetWithdrawalAddress()
+		} else if !strings.EqualFold(withdrawalAddr, val.GetWithdrawalAddress()) {
+			return errors.New("validators have different withdrawal addresses, generate requests per validator instead")
+		}
+
+		switch elrequest.Type(config.ELRequestType) {
+		case elrequest.TypeWithdrawal:
+			reqs = append(reqs, elrequest.NewWithdrawal(pubkey, eth2p0.Gwei(config.WithdrawalAmount), config.RequestFee))
+		case elrequest.TypeConsolidation:
+			if config.TargetPubkey == "" {
+				reqs = append(reqs, elrequest.NewConsolidation(pubkey, pubkey, config.RequestFee))
+			} else if pubkey != target { // Don't consolidate the target into itself.
+				reqs = append(reqs, elrequest.NewConsolidation(pubkey, target, config.RequestFee))
+			}
+		default:
+			return errors.New("unsupported request type", z.Str("request_type", config.ELRequestType))
+		}
+	}
+
+	if len(reqs) == 0 {
+		return errors.New("validator public key not found in cluster lock", z.Str("validator_public_key", config.ValidatorPubkey))
+	}
+
+	safeAddr := config.SafeAddress
+	if safeAddr == "" {
+		safeAddr = withdrawalAddr
+	} else if !strings.EqualFold(safeAddr, withdrawalAddr) {
+		return errors.New("safe address doesn't match the validators' withdrawal address, requests would be rejected",
+			z.Str("safe_address", safeAddr), z.Str("withdrawal_address", withdrawalAddr))
+	}
+
+	var eth2Cl eth2wrap.Client
+	if len(config.BeaconNodeEndpoints) > 0 {
+		eth2Cl, err = eth2Client(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+		if err != nil {
+			return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
+		}
+	}
+
+	if config.WithdrawalAmount > 0 {
+		if err := verifyCompounding(ctx, config, eth2Cl, reqs); err != nil {
+			return err
+		}
+	}
+
+	batch := elrequest.NewSafeBatch(chainID, safeAddr, time.Now(), reqs)
+
+	b, err := json.MarshalIndent(batch, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal safe batch")
+	}
+
+	//nolint:gosec // File contains no secrets.
+	if err := os.WriteFile(config.ELRequestFile, b, 0o644); err != nil {
+		return errors.Wrap(err, "write safe batch file", z.Str("output_file", config.ELRequestFile))
+	}
+
+	for _, req := range reqs {
+		log.Info(ctx, "Generated execution layer request", z.Str("request", req.String()))
+	}
+	log.Info(ctx, "Wrote execution layer requests to Safe transaction builder batch file",
+		z.Str("output_file", config.ELRequestFile), z.Str("safe_address", safeAddr), z.Int("requests", len(reqs)))
+
+	if !config.TrackELRequests {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.TrackTimeout)
+	defer cancel()
+
+	return trackELRequests(ctx, eth2Cl, reqs)
+}
+
+// verifyCompounding returns an error if any of the requests' validators doesn't have compounding withdrawal credentials,
+// since the consensus layer ignores partial withdrawal requests of other validators, burning the request fee.
+// The credentials are queried from the beacon node if provided, otherwise the cluster lock's compounding flags are used.
+func verifyCompounding(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, reqs []elrequest.Request) error {
+	if eth2Cl == nil {
+		compounding, err := lockCompounding(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
+		if err != nil {
+			return err
+		}
+
+		for _, req := range reqs {
+			pubkey, err := core.PubKeyFromBytes(req.PubKey[:])
+			if err != nil {
+				return err
+			}
+
+			if !compounding[pubkey] {
+				return errors.New("partial withdrawals require compounding withdrawal credentials; "+
+					"specify beacon-node-endpoints if the validator switched to compounding since cluster creation",
+					z.Str("validator_public_key", req.PubKey.String()))
+			}
+		}
+
+		return nil
+	}
+
+	var pubkeys []eth2p0.BLSPubKey
+	for _, req := range reqs {
+		pubkeys = append(pubkeys, req.PubKey)
+	}
+
+	resp, err := queryBeaconForValidator(ctx, eth2Cl, pubkeys, nil)
+	if err != nil {
+		return err
+	}
+
+	vals := make(map[eth2p0.BLSPubKey]*eth2v1.Validator)
+	for _, val := range resp.Data {
+		vals[val.Validator.PublicKey] = val
+	}
+
+	for _, pubkey := range pubkeys {
+		val, ok := vals[pubkey]
+		if !ok {
+			return errors.New("validator not found on beacon node", z.Str("validator_public_key", pubkey.String()))
+		}
+
+		creds := val.Validator.WithdrawalCredentials
+		if len(creds) == 0 || creds[0] != compoundingWithdrawalPrefix {
+			return errors.New("partial withdrawals require compounding withdrawal credentials",
+				z.Str("validator_public_key", pubkey.String()), z.Str("withdrawal_credentials", fmt.Sprintf("%#x", creds)))
+		}
+	}
+
+	return nil
+}
+
+// trackELRequests polls the beacon node until all the provided requests are processed.
+func trackELRequests(ctx context.Context, eth2Cl eth2wrap.Client, reqs []elrequest.Request) error {
+	var pubkeys []eth2p0.BLSPubKey
+	for _, req := range reqs {
+		pubkeys = append(pubkeys, req.PubKey)
+	}
+
+	fetch := func() (map[eth2p0.BLSPubKey]*eth2v1.Validator, error) {
+		resp, err := queryBeaconForValidator(ctx, eth2Cl, pubkeys, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		vals := make(map[eth2p0.BLSPubKey]*eth2v1.Validator)
+		for _, val := range resp.Data {
+			vals[val.Validator.PublicKey] = val
+		}
+
+		return vals, nil
+	}
+
+	initial, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	for _, req := range reqs {
+		if _, ok := initial[req.PubKey]; !ok {
+			return errors.New("validator not found on beacon node", z.Str("validator_public_key", req.PubKey.String()))
+		}
+	}
+
+	log.Info(ctx, "Tracking execution layer requests, waiting for the requests to be submitted and processed")
+
+	ticker := time.NewTicker(elRequestTrackInterval)
+	defer ticker.Stop()
+
+	pending := reqs
+	current := initial
+	for {
+		var remaining []elrequest.Request
+		for _, req := range pending {
+			if !elRequestProcessed(req, initial[req.PubKey], current[req.PubKey]) {
+				remaining = append(remaining, req)
+				continue
+			}
+
+			log.Info(ctx, "Execution layer request processed", z.Str("request", req.String()),
+				z.Str("status", current[req.PubKey].Status.String()))
+		}
+
+		pending = remaining
+		if len(pending) == 0 {
+			log.Info(ctx, "All execution layer requests processed")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("timeout tracking execution layer requests", z.Int("pending", len(pending)))
+		case <-ticker.C:
+		}
+
+		current, err = fetch()
+		if err != nil {
+			log.Warn(ctx, "Failed fetching validators from beacon node, retrying", err)
+			current = initial
+		}
+	}
+}
+
+// elRequestProcessed returns true if the validator state indicates the request was processed.
+func elRequestProcessed(req elrequest.Request, initial, current *eth2v1.Validator) bool {
+	if current == nil || current.Validator == nil {
+		return false
+	}
+
+	switch {
+	case req.IsFullExit():
+		return current.Validator.ExitEpoch != math.MaxUint64
+	case req.Type == elrequest.TypeWithdrawal:
+		return initial.Balance >= current.Balance && initial.Balance-current.Balance+partialWithdrawalTolerance >= req.Amount
+	case req.IsSwitchToCompounding():
+		creds := current.Validator.WithdrawalCredentials
+		return len(creds) > 0 && creds[0] == compoundingWithdrawalPrefix
+	default:
+		// Consolidated source validators are exited.
+		return current.Validator.ExitEpoch != math.MaxUint64
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/elrequest"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func Test_runELRequest(t *testing.T) {
+	ctx := context.Background()
+
+	const valAmt = 3
+
+	lock, _, _ := cluster.NewForT(t, valAmt, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	root := t.TempDir()
+	lockFile := filepath.Join(root, "cluster-lock.json")
+
+	lockBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, lockBytes, 0o644))
+
+	// All validators already exited.
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, v := range lock.Validators {
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:   eth2p0.ValidatorIndex(idx),
+			Balance: 32000000000,
+			Status:  eth2v1.ValidatorStateActiveExiting,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(v.PubKey),
+				WithdrawalCredentials: make([]byte, 32),
+				ExitEpoch:             100,
+			},
+		}
+	}
+
+	beaconMock, err := beaconmock.New(beaconmock.WithValidatorSet(validatorSet))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	outFile := filepath.Join(root, "el-requests.json")
+	config := exitConfig{
+		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		BeaconNodeTimeout:   30 * time.Second,
+		LockFilePath:        lockFile,
+		ValidatorPubkey:     lock.Validators[0].PublicKeyHex(),
+		ELRequestType:       string(elrequest.TypeWithdrawal),
+		RequestFee:          elrequest.MinRequestFee,
+		ELRequestFile:       outFile,
+		TrackELRequests:     true,
+		TrackTimeout:        time.Minute,
+	}
+
+	require.NoError(t, runELRequest(ctx, config))
+
+	b, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	var batch elrequest.SafeBatch
+	require.NoError(t, json.Unmarshal(b, &batch))
+	require.Len(t, batch.Transactions, 1)
+	require.True(t, strings.EqualFold(lock.ValidatorAddresses[0].WithdrawalAddress, batch.Meta.CreatedFromSafeAddress))
+
+	t.Run("safe address mismatch", func(t *testing.T) {
+		config := config
+		config.SafeAddress = "0x0000000000000000000000000000000000000001"
+		require.ErrorContains(t, runELRequest(ctx, config), "safe address doesn't match")
+	})
+
+	t.Run("different withdrawal addresses", func(t *testing.T) {
+		config := config
+		config.ValidatorPubkey = ""
+		config.All = true
+		require.ErrorContains(t, runELRequest(ctx, config), "validators have different withdrawal addresses")
+	})
+
+	t.Run("partial withdrawal not compounding", func(t *testing.T) {
+		config := config
+		config.WithdrawalAmount = 1000000000
+		require.ErrorContains(t, runELRequest(ctx, config), "partial withdrawals require compounding withdrawal credentials")
+
+		// Without a beacon node, the cluster lock's compounding flag is used.
+		config.BeaconNodeEndpoints = nil
+		config.TrackELRequests = false
+		require.ErrorContains(t, runELRequest(ctx, config), "partial withdrawals require compounding withdrawal credentials")
+	})
+
+	t.Run("partial withdrawal compounding lock", func(t *testing.T) {
+		dir := t.TempDir()
+		compoundingLock := writeMigrateTestLock(t, dir, 0)
+
+		config := config
+		config.LockFilePath = filepath.Join(dir, "cluster-lock.json")
+		config.ValidatorPubkey = compoundingLock.Validators[1].PublicKeyHex()
+		config.WithdrawalAmount = 1000000000
+		config.BeaconNodeEndpoints = nil
+		config.TrackELRequests = false
+		require.NoError(t, runELRequest(ctx, config))
+	})
+
+	t.Run("partial withdrawal compounding credentials", func(t *testing.T) {
+		compoundingSet := beaconmock.ValidatorSet{0: {
+			Index:   0,
+			Balance: 64000000000,
+			Status:  eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(lock.Validators[0].PubKey),
+				WithdrawalCredentials: append([]byte{compoundingWithdrawalPrefix}, make([]byte, 31)...),
+				ExitEpoch:             math.MaxUint64,
+			},
+		}}
+
+		compoundingMock, err := beaconmock.New(beaconmock.WithValidatorSet(compoundingSet))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, compoundingMock.Close())
+		}()
+
+		// The beacon node's credentials take precedence over the cluster lock's compounding flag.
+		config := config
+		config.BeaconNodeEndpoints = []string{compoundingMock.Address()}
+		config.WithdrawalAmount = 1000000000
+		config.TrackELRequests = false
+		require.NoError(t, runELRequest(ctx, config))
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		config := config
+		config.ValidatorPubkey = eth2p0.BLSPubKey{0xff}.String()
+		require.ErrorContains(t, runELRequest(ctx, config), "validator public key not found")
+	})
+}
+
+func Test_trackELRequests(t *testing.T) {
+	ctx := context.Background()
+
+	const amount = 1000000000
+
+	prevInterval := elRequestTrackInterval
+	elRequestTrackInterval = time.Millisecond
+	t.Cleanup(func() { elRequestTrackInterval = prevInterval })
+
+	pubkey := eth2p0.BLSPubKey{0x01}
+	req := elrequest.NewWithdrawal(pubkey, amount, elrequest.MinRequestFee)
+
+	// The partial withdrawal is processed after a few polls.
+	var polls atomic.Int32
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+	bmock.ValidatorsFunc = func(context.Context, *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+		balance := eth2p0.Gwei(64000000000)
+		if polls.Add(1) > 3 {
+			balance -= amount
+		}
+
+		return map[eth2p0.ValidatorIndex]*eth2v1.Validator{0: {
+			Index:   0,
+			Balance: balance,
+			Status:  eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             pubkey,
+				WithdrawalCredentials: append([]byte{compoundingWithdrawalPrefix}, make([]byte, 31)...),
+				ExitEpoch:             math.MaxUint64,
+			},
+		}}, nil
+	}
+
+	require.NoError(t, trackELRequests(ctx, bmock, []elrequest.Request{req}))
+	require.Greater(t, polls.Load(), int32(3))
+
+	t.Run("timeout", func(t *testing.T) {
+		polls.Store(math.MinInt32) // Never processed.
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorContains(t, trackELRequests(ctx, bmock, []elrequest.Request{req}), "timeout tracking execution layer requests")
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		req := elrequest.NewWithdrawal(eth2p0.BLSPubKey{0x02}, amount, elrequest.MinRequestFee)
+		require.ErrorContains(t, trackELRequests(ctx, bmock, []elrequest.Request{req}), "validator not found on beacon node")
+	})
+}
+
+func Test_elRequestProcessed(t *testing.T) {
+	pubkey := eth2p0.BLSPubKey{0x01}
+	val := func(balance eth2p0.Gwei, exitEpoch eth2p0.Epoch, prefix byte) *eth2v1.Validator {
+		return &eth2v1.Validator{
+			Balance: balance,
+			Validator: &eth2p0.Validator{
+				PublicKey:             pubkey,
+				WithdrawalCredentials: append([]byte{prefix}, make([]byte, 31)...),
+				ExitEpoch:             exitEpoch,
+			},
+		}
+	}
+	active := val(64000000000, math.MaxUint64, 0x01)
+
+	tests := []struct {
+		name      string
+		req       elrequest.Request
+		current   *eth2v1.Validator
+		processed bool
+	}{
+		{"full exit pending", elrequest.NewWithdrawal(pubkey, 0, 1), active, false},
+		{"full exit processed", elrequest.NewWithdrawal(pubkey, 0, 1), val(64000000000, 10, 0x01), true},
+		{"partial pending", elrequest.NewWithdrawal(pubkey, 16000000000, 1), val(64000100000, math.MaxUint64, 0x01), false},
+		{"partial processed", elrequest.NewWithdrawal(pubkey, 16000000000, 1), val(48005000000, math.MaxUint64, 0x01), true},
+		{"compounding pending", elrequest.NewConsolidation(pubkey, pubkey, 1), active, false},
+		{"compounding processed", elrequest.NewConsolidation(pubkey, pubkey, 1), val(64000000000, math.MaxUint64, 0x02), true},
+		{"consolidation pending", elrequest.NewConsolidation(pubkey, eth2p0.BLSPubKey{0x02}, 1), active, false},
+		{"consolidation processed", elrequest.NewConsolidation(pubkey, eth2p0.BLSPubKey{0x02}, 1), val(64000000000, 10, 0x01), true},
+		{"missing validator", elrequest.NewWithdrawal(pubkey, 0, 1), nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.processed, elRequestProcessed(test.req, active, test.current))
+		})
+	}
+}
@@ -46,6 +46,8 @@ func newFetchExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Com
 		{publishAddress, false},
 		{privateKeyPath, false},
 		{lockFilePath, false},
+		{manifestFilePath, false},
+		{lockVerify, false},
 		{validatorPubkey, false},
 		{all, false},
 		{fetchedExitPath, false},
@@ -103,9 +105,9 @@ func runFetchExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
 	}
 
-	cl, err := loadClusterManifest("", config.LockFilePath)
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
 	if err != nil {
-		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
 	}
 
 	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
@@ -42,6 +42,8 @@ func newListActiveValidatorsCmd(runFunc func(context.Context, exitConfig) error)
 
 	bindExitFlags(cmd, &config, []exitCLIFlag{
 		{lockFilePath, false},
+		{manifestFilePath, false},
+		{lockVerify, false},
 		{beaconNodeEndpoints, true},
 		{beaconNodeTimeout, false},
 		{testnetName, false},
@@ -82,9 +84,9 @@ func runListActiveValidatorsCmd(ctx context.Context, config exitConfig) error {
 }
 
 func listActiveVals(ctx context.Context, config exitConfig) ([]string, error) {
-	cl, err := loadClusterManifest("", config.LockFilePath)
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
 	if err != nil {
-		return nil, errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+		return nil, errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
 	}
 
 	eth2Cl, err := eth2Client(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte{}) // fine to avoid initializing a fork version, we're just querying the BN
@@ -3,8 +3,15 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -18,6 +25,8 @@ import (
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/keystore"
@@ -29,7 +38,7 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 	cmd := &cobra.Command{
 		Use:   "sign",
 		Short: "Sign partial exit message for a distributed validator",
-		Long:  `Sign a partial exit message for a distributed validator and submit it to a remote API for aggregation.`,
+		Long:  `Sign a partial exit message for a distributed validator and submit it to a remote API for aggregation, or to the local charon node for peer-to-peer aggregation and broadcasting.`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
 			if err := log.InitLogger(config.Log); err != nil {
@@ -47,6 +56,8 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		{publishAddress, false},
 		{privateKeyPath, false},
 		{lockFilePath, false},
+		{manifestFilePath, false},
+		{lockVerify, false},
 		{validatorKeysDir, false},
 		{exitEpoch, false},
 		{validatorPubkey, false},
@@ -54,6 +65,8 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		{beaconNodeEndpoints, true},
 		{beaconNodeTimeout, false},
 		{publishTimeout, false},
+		{nodeAPIAddress, false},
+		{nodeAPIToken, false},
 		{all, false},
 		{testnetName, false},
 		{testnetForkVersion, false},
@@ -99,9 +112,9 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
 	}
 
-	cl, err := loadClusterManifest("", config.LockFilePath)
+	cl, err := loadClusterManifest(ctx, config.ManifestFilePath, config.LockFilePath, config.LockVerify)
 	if err != nil {
-		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath), z.Str("manifest_file_path", config.ManifestFilePath))
 	}
 
 	rawValKeys, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
@@ -124,9 +137,13 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
 	}
 
-	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
-	if err != nil {
-		return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	// Partial exits are either submitted to the remote API or to the local charon node.
+	var oAPI obolapi.Client
+	if config.NodeAPIAddress == "" {
+		oAPI, err = obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+		if err != nil {
+			return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+		}
 	}
 
 	eth2Cl, err := eth2Client(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
@@ -152,12 +169,16 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 			return errors.Wrap(err, "sign exits for all validators")
 		}
 	} else {
-		exitBlobs, err = signSingleValidatorExit(ctx, config, eth2Cl, shares)
+		exitBlobs, err = signSingleValidatorExit(ctx, config, eth2Cl, cl, shares)
 		if err != nil {
 			return errors.Wrap(err, "sign exit for validator")
 		}
 	}
 
+	if config.NodeAPIAddress != "" {
+		return submitExitsToNode(ctx, config, exitBlobs)
+	}
+
 	if err := oAPI.PostPartialExits(ctx, cl.GetInitialMutationHash(), shareIdx, identityKey, exitBlobs...); err != nil {
 		return errors.Wrap(err, "http POST partial exit message to Obol API")
 	}
@@ -165,7 +186,61 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 	return nil
 }
 
-func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
+// submitExitsToNode submits the partial exits to the validator API of the local charon node,
+// which exchanges them with its peers via parsigex, aggregates them and broadcasts the full exits.
+func submitExitsToNode(ctx context.Context, config exitConfig, exitBlobs []obolapi.ExitBlob) error {
+	endpoint, err := url.JoinPath(config.NodeAPIAddress, "/eth/v1/beacon/pool/voluntary_exits")
+	if err != nil {
+		return errors.Wrap(err, "invalid node api address", z.Str("node_api_address", config.NodeAPIAddress))
+	}
+
+	for _, blob := range exitBlobs {
+		body, err := json.Marshal(blob.SignedExitMessage)
+		if err != nil {
+			return errors.Wrap(err, "marshal partial exit")
+		}
+
+		if err := postToNode(ctx, endpoint, config.NodeAPIToken, config.PublishTimeout, body); err != nil {
+			return errors.Wrap(err, "submit partial exit to charon node", z.Str("validator_public_key", blob.PublicKey))
+		}
+
+		log.Info(ctx, "Submitted partial exit to charon node", z.Str("validator_public_key", blob.PublicKey))
+	}
+
+	log.Info(ctx, "Partial exits will be aggregated and broadcast by the cluster once threshold nodes submitted them")
+
+	return nil
+}
+
+// postToNode posts the json body to the charon node endpoint.
+func postToNode(ctx context.Context, endpoint string, token string, timeout time.Duration, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post request", z.Str("endpoint", endpoint))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(resp.Body)
+		return errors.New("post request failed", z.Int("status", resp.StatusCode), z.Str("body", string(data)))
+	}
+
+	return nil
+}
+
+func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, cl *manifestpb.Cluster, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
 	valEth2, err := fetchValidatorBLSPubKey(ctx, config, eth2Cl)
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch validator public key")
@@ -175,7 +250,10 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 
 	ourShare, ok := shares[validator]
 	if !ok {
-		return nil, errors.New("validator not present in cluster lock", z.Str("validator", validator.String()))
+		ourShare, err = archivedValidatorShare(ctx, config, cl, validator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	valIndex, err := fetchValidatorIndex(ctx, config, eth2Cl)
@@ -198,6 +276,40 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 	}, nil
 }
 
+// archivedValidatorShare returns the key share of a validator removed from the cluster,
+// loaded from the archived subdirectory of the validator keys directory.
+func archivedValidatorShare(ctx context.Context, config exitConfig, cl *manifestpb.Cluster, validator core.PubKey) (keystore.IndexedKeyShare, error) {
+	pubkey, err := validator.Bytes()
+	if err != nil {
+		return keystore.IndexedKeyShare{}, err
+	}
+
+	if _, archived, err := manifest.ClusterValidator(cl, pubkey); err != nil || !archived {
+		return keystore.IndexedKeyShare{}, errors.New("validator not present in cluster lock", z.Str("validator", validator.String()))
+	}
+
+	archivedDir := filepath.Join(config.ValidatorKeysDir, "archived")
+
+	keyFiles, err := keystore.LoadFilesUnordered(archivedDir)
+	if err != nil {
+		return keystore.IndexedKeyShare{}, errors.Wrap(err, "load archived keystore of removed validator", z.Str("validator_keys_dir", archivedDir))
+	}
+
+	shares, err := keystore.ArchivedKeysharesToValidatorPubkey(cl, keyFiles.Keys())
+	if err != nil {
+		return keystore.IndexedKeyShare{}, errors.Wrap(err, "match archived key shares with removed validators")
+	}
+
+	share, ok := shares[validator]
+	if !ok {
+		return keystore.IndexedKeyShare{}, errors.New("archived key share of removed validator not found", z.Str("validator", validator.String()))
+	}
+
+	log.Warn(ctx, "Validator was removed from the cluster, signing partial exit with its archived key share", nil)
+
+	return share, nil
+}
+
 func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
 	var valsEth2 []eth2p0.BLSPubKey
 	for pk := range shares {
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -494,3 +495,95 @@ func TestExitSignCLI(t *testing.T) {
 		})
 	}
 }
+
+func Test_runSignPartialExitToNode(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		valAmt      = 3
+		operatorAmt = 4
+		token       = "secret"
+	)
+
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, operatorAmt, operatorAmt, 0, rand.New(rand.NewSource(0)))
+
+	root := t.TempDir()
+
+	operatorShares := make([][]tbls.PrivateKey, operatorAmt)
+	for opIdx := range operatorAmt {
+		for _, share := range keyShares {
+			operatorShares[opIdx] = append(operatorShares[opIdx], share[opIdx])
+		}
+	}
+
+	mBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	writeAllLockData(t, root, operatorAmt, enrs, operatorShares, mBytes)
+
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, v := range lock.Validators {
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:   eth2p0.ValidatorIndex(idx),
+			Balance: 42,
+			Status:  eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(v.PubKey),
+				WithdrawalCredentials: testutil.RandomBytes32(),
+			},
+		}
+	}
+
+	beaconMock, err := beaconmock.New(beaconmock.WithValidatorSet(validatorSet))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	eth2Cl, err := eth2Client(ctx, []string{beaconMock.Address()}, 10*time.Second, [4]byte(lock.ForkVersion))
+	require.NoError(t, err)
+
+	// Mock the local charon node validator API, verifying the partial exit signatures of the first operator.
+	var exits []eth2p0.SignedVoluntaryExit
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/beacon/pool/voluntary_exits", r.URL.Path)
+		require.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+
+		var exit eth2p0.SignedVoluntaryExit
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&exit))
+
+		sigData, err := sigDataForExit(r.Context(), *exit.Message, eth2Cl, exit.Message.Epoch)
+		require.NoError(t, err)
+
+		pubshare := lock.Validators[exit.Message.ValidatorIndex].PubShares[0]
+		require.NoError(t, tbls.Verify(tbls.PublicKey(pubshare), sigData[:], tbls.Signature(exit.Signature)))
+
+		exits = append(exits, exit)
+	}))
+	defer srv.Close()
+
+	baseDir := filepath.Join(root, "op0")
+
+	config := exitConfig{
+		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
+		ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
+		LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
+		PublishAddress:      badStr, // Not used
+		NodeAPIAddress:      srv.URL,
+		NodeAPIToken:        token,
+		ExitEpoch:           194048,
+		BeaconNodeTimeout:   30 * time.Second,
+		PublishTimeout:      10 * time.Second,
+		All:                 true,
+	}
+
+	require.NoError(t, runSignPartialExit(ctx, config))
+	require.Len(t, exits, valAmt)
+
+	config.NodeAPIToken = ""
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	require.ErrorContains(t, runSignPartialExit(ctx, config), "submit partial exit to charon node")
+}
@@ -0,0 +1,184 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+// manifestMigrateConfig is config for the `manifest migrate` command.
+type manifestMigrateConfig struct {
+	LockFilePath     string
+	ManifestFilePath string
+	LockVerify       lockVerifyConfig
+	Log              log.Config
+}
+
+func newManifestCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "manifest",
+		Short: "Manage cluster manifest files",
+		Long:  "Manage cluster manifest files, the replacement of cluster lock files.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newManifestMigrateCmd(runFunc func(context.Context, manifestMigrateConfig) error) *cobra.Command {
+	var config manifestMigrateConfig
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates a cluster lock file to a cluster manifest file",
+		Long: "Converts a verified cluster lock file to a cluster manifest file containing the legacy_lock mutation. " +
+			"It is a no-op if the cluster manifest file already exists and matches the cluster lock file.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file to migrate.")
+	cmd.Flags().StringVar(&config.ManifestFilePath, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file to write.")
+	bindLockVerifyFlags(cmd.Flags(), &config.LockVerify)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runManifestMigrate(ctx context.Context, config manifestMigrateConfig) error {
+	dag, err := loadDAGFromDisk(ctx, "", config.LockFilePath, config.LockVerify)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(config.ManifestFilePath); err == nil {
+		if err := verifyManifestMatchesLock(config.ManifestFilePath, dag); err != nil {
+			return errors.Wrap(err, "existing cluster manifest doesn't match cluster lock", z.Str("manifest_file_path", config.ManifestFilePath))
+		}
+
+		log.Info(ctx, "Cluster manifest already exists and matches cluster lock, nothing to migrate",
+			z.Str("manifest_file_path", config.ManifestFilePath))
+
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "stat cluster manifest", z.Str("manifest_file_path", config.ManifestFilePath))
+	}
+
+	if err := writeManifestAtomic(config.ManifestFilePath, dag); err != nil {
+		return err
+	}
+
+	cl, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise cluster dag")
+	}
+
+	log.Info(ctx, "Cluster lock migrated to cluster manifest",
+		z.Str("cluster_name", cl.GetName()),
+		z.Str("cluster_hash", hex7(cl.GetInitialMutationHash())),
+		z.Int("num_validators", len(cl.GetValidators())),
+		z.Str("manifest_file_path", config.ManifestFilePath))
+
+	return nil
+}
+
+// writeManifestAtomic writes the DAG to a temporary file next to the manifest file, checks that it loads with
+// the DAG's cluster hash and renames it to the manifest file, so a partially written manifest is never loaded.
+func writeManifestAtomic(manifestFilePath string, dag *manifestpb.SignedMutationList) error {
+	b, err := proto.Marshal(dag)
+	if err != nil {
+		return errors.Wrap(err, "proto marshal dag")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(manifestFilePath), filepath.Base(manifestFilePath)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "create temporary cluster manifest")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "write temporary cluster manifest")
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "sync temporary cluster manifest")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close temporary cluster manifest")
+	}
+
+	//nolint:gosec // File needs to be read-write since the cluster manifest is modified by mutations.
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return errors.Wrap(err, "chmod temporary cluster manifest")
+	}
+
+	if err := verifyManifestMatchesLock(tmp.Name(), dag); err != nil {
+		return errors.Wrap(err, "verify migrated cluster manifest")
+	}
+
+	if err := os.Rename(tmp.Name(), manifestFilePath); err != nil {
+		return errors.Wrap(err, "rename cluster manifest", z.Str("manifest_file_path", manifestFilePath))
+	}
+
+	return nil
+}
+
+// verifyManifestMatchesLock returns an error if the cluster manifest file can't be loaded, is empty,
+// is invalid or doesn't have the cluster hash of the provided DAG, i.e. of the cluster lock.
+// Unlike manifest.LoadDAG, it never falls back to the cluster lock if the manifest fails to load.
+func verifyManifestMatchesLock(manifestFilePath string, lockDAG *manifestpb.SignedMutationList) error {
+	b, err := os.ReadFile(manifestFilePath)
+	if err != nil {
+		return errors.Wrap(err, "read cluster manifest")
+	}
+
+	dag := new(manifestpb.SignedMutationList)
+	if err := proto.Unmarshal(b, dag); err != nil {
+		return errors.Wrap(err, "unmarshal cluster manifest")
+	} else if len(dag.GetMutations()) == 0 {
+		return errors.New("empty cluster manifest")
+	}
+
+	if _, err := manifest.Materialise(dag); err != nil {
+		return errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	manifestHash, err := manifest.Hash(dag.GetMutations()[0])
+	if err != nil {
+		return errors.Wrap(err, "hash cluster manifest")
+	}
+
+	lockHash, err := manifest.Hash(lockDAG.GetMutations()[0])
+	if err != nil {
+		return errors.Wrap(err, "hash cluster lock")
+	}
+
+	if !bytes.Equal(manifestHash, lockHash) {
+		return errors.New("manifest and legacy cluster hashes don't match",
+			z.Str("manifest_hash", hex7(manifestHash)), z.Str("legacy_hash", hex7(lockHash)))
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestManifestMigrate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	lock := writeMigrateTestLock(t, dir, 1)
+	config := manifestMigrateConfig{
+		LockFilePath:     filepath.Join(dir, "cluster-lock.json"),
+		ManifestFilePath: filepath.Join(dir, "cluster-manifest.pb"),
+	}
+
+	require.NoError(t, runManifestMigrate(ctx, config))
+
+	cl, err := manifest.LoadCluster(config.ManifestFilePath, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, lock.LockHash, cl.GetInitialMutationHash())
+	require.Len(t, cl.GetValidators(), len(lock.Validators))
+
+	// The manifest takes precedence over the lock, so compounding must still be available.
+	compounding, err := lockCompounding(context.Background(), config.ManifestFilePath, "", lockVerifyConfig{})
+	require.NoError(t, err)
+	require.True(t, lock.ValidatorAddresses[1].Compounding)
+	for i, val := range lock.Validators {
+		pubkey, err := core.PubKeyFromBytes(val.PubKey)
+		require.NoError(t, err)
+		require.Equal(t, lock.ValidatorAddresses[i].Compounding, compounding[pubkey])
+	}
+
+	// No temporary files remain.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	t.Run("idempotent", func(t *testing.T) {
+		before, err := os.ReadFile(config.ManifestFilePath)
+		require.NoError(t, err)
+
+		require.NoError(t, runManifestMigrate(ctx, config))
+
+		after, err := os.ReadFile(config.ManifestFilePath)
+		require.NoError(t, err)
+		require.Equal(t, before, after)
+	})
+
+	t.Run("mismatching manifest", func(t *testing.T) {
+		otherDir := t.TempDir()
+		writeMigrateTestLock(t, otherDir, 2)
+
+		err := runManifestMigrate(ctx, manifestMigrateConfig{
+			LockFilePath:     filepath.Join(otherDir, "cluster-lock.json"),
+			ManifestFilePath: config.ManifestFilePath,
+		})
+		require.ErrorContains(t, err, "manifest and legacy cluster hashes don't match")
+	})
+
+	for name, content := range map[string][]byte{
+		"empty manifest":   {},
+		"corrupt manifest": []byte("corrupt"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			manifestFile := filepath.Join(t.TempDir(), "cluster-manifest.pb")
+			require.NoError(t, os.WriteFile(manifestFile, content, 0o644))
+
+			err := runManifestMigrate(ctx, manifestMigrateConfig{
+				LockFilePath:     config.LockFilePath,
+				ManifestFilePath: manifestFile,
+			})
+			require.ErrorContains(t, err, "existing cluster manifest doesn't match cluster lock")
+
+			// The existing manifest is left untouched.
+			b, err := os.ReadFile(manifestFile)
+			require.NoError(t, err)
+			require.Equal(t, content, b)
+		})
+	}
+
+	t.Run("missing lock", func(t *testing.T) {
+		err := runManifestMigrate(ctx, manifestMigrateConfig{
+			LockFilePath:     filepath.Join(t.TempDir(), "cluster-lock.json"),
+			ManifestFilePath: filepath.Join(t.TempDir(), "cluster-manifest.pb"),
+		})
+		require.ErrorContains(t, err, "load cluster dag from disk")
+	})
+}
+
+// writeMigrateTestLock writes a new cluster lock generated with the provided seed to the directory.
+func writeMigrateTestLock(t *testing.T, dir string, seed int) cluster.Lock {
+	t.Helper()
+
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, seed, random, func(def *cluster.Definition) {
+		def.Version = cluster.MinVersionForCompounding
+		if len(def.ValidatorAddresses) > 1 {
+			def.ValidatorAddresses[1].Compounding = true
+		}
+	})
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cluster-lock.json"), b, 0o644))
+
+	return lock
+}
@@ -3,33 +3,58 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
 
+	"github.com/spf13/pflag"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
 )
 
-// loadClusterManifest loads cluster manifest from disk.
-func loadClusterManifest(manifestFilePath, lockFilePath string) (*manifestpb.Cluster, error) {
-	verifyLock := func(lock cluster.Lock) error {
-		if err := lock.VerifyHashes(); err != nil {
-			return errors.Wrap(err, "cluster lock hash verification failed")
+// lockVerifyConfig configures the verification of cluster lock files loaded from disk.
+type lockVerifyConfig struct {
+	NoVerify           bool
+	ExecutionClientRPC string
+}
+
+// bindLockVerifyFlags binds the flags configuring the verification of cluster lock files.
+func bindLockVerifyFlags(flags *pflag.FlagSet, config *lockVerifyConfig) {
+	bindNoVerifyFlag(flags, &config.NoVerify)
+	bindExecutionClientRPCFlag(flags, &config.ExecutionClientRPC)
+}
+
+// newLockVerifier returns a function that verifies the hashes and signatures of cluster locks,
+// including ERC-1271 smart contract signatures if an execution client is configured.
+// Verification failures are only logged if no-verify is enabled.
+func newLockVerifier(ctx context.Context, conf lockVerifyConfig) func(cluster.Lock) error {
+	return func(lock cluster.Lock) error {
+		if err := lock.VerifyHashes(); err != nil && !conf.NoVerify {
+			return errors.Wrap(err, "cluster lock hash verification failed. Run with --no-verify to bypass verification at own risk")
+		} else if err != nil && conf.NoVerify {
+			log.Warn(ctx, "Ignoring failed cluster lock hash verification due to --no-verify flag", err)
 		}
 
-		if err := lock.VerifySignatures(); err != nil {
-			return errors.Wrap(err, "cluster lock signature verification failed")
+		if err := lock.VerifySignatures(verifyOptions(ctx, conf.ExecutionClientRPC)...); err != nil && !conf.NoVerify {
+			return errors.Wrap(err, "cluster lock signature verification failed. Run with --no-verify to bypass verification at own risk")
+		} else if err != nil && conf.NoVerify {
+			log.Warn(ctx, "Ignoring failed cluster lock signature verification due to --no-verify flag", err)
 		}
 
 		return nil
 	}
+}
 
-	cluster, err := manifest.LoadCluster(manifestFilePath, lockFilePath, verifyLock)
+// loadClusterManifest loads cluster manifest from disk.
+func loadClusterManifest(ctx context.Context, manifestFilePath, lockFilePath string, verifyConf lockVerifyConfig) (*manifestpb.Cluster, error) {
+	cluster, err := manifest.LoadCluster(manifestFilePath, lockFilePath, newLockVerifier(ctx, verifyConf))
 	if err != nil {
 		return nil, errors.Wrap(err, "load cluster manifest from disk")
 	}
@@ -38,25 +63,34 @@ func loadClusterManifest(manifestFilePath, lockFilePath string) (*manifestpb.Clu
 }
 
 // loadDAGFromDisk loads cluster DAG from disk.
-func loadDAGFromDisk(manifestFilePath, lockFilePath string) (*manifestpb.SignedMutationList, error) {
-	verifyLock := func(lock cluster.Lock) error {
-		if err := lock.VerifyHashes(); err != nil {
-			return errors.Wrap(err, "cluster lock hash verification failed")
-		}
+func loadDAGFromDisk(ctx context.Context, manifestFilePath, lockFilePath string, verifyConf lockVerifyConfig) (*manifestpb.SignedMutationList, error) {
+	dag, err := manifest.LoadDAG(manifestFilePath, lockFilePath, newLockVerifier(ctx, verifyConf))
+	if err != nil {
+		return nil, errors.Wrap(err, "load cluster dag from disk")
+	}
 
-		if err := lock.VerifySignatures(); err != nil {
-			return errors.Wrap(err, "cluster lock signature verification failed")
-		}
+	return dag, nil
+}
 
-		return nil
+// legacyLockFromDAG returns the cluster lock of the initial legacy lock mutation of the DAG.
+// It returns false if the DAG doesn't start with a legacy lock mutation.
+func legacyLockFromDAG(dag *manifestpb.SignedMutationList) (cluster.Lock, bool) {
+	muts := dag.GetMutations()
+	if len(muts) == 0 || muts[0].GetMutation().GetType() != string(manifest.TypeLegacyLock) {
+		return cluster.Lock{}, false
 	}
 
-	dag, err := manifest.LoadDAG(manifestFilePath, lockFilePath, verifyLock)
-	if err != nil {
-		return nil, errors.Wrap(err, "load cluster dag from disk")
+	legacyLock := new(manifestpb.LegacyLock)
+	if err := muts[0].GetMutation().GetData().UnmarshalTo(legacyLock); err != nil {
+		return cluster.Lock{}, false
 	}
 
-	return dag, nil
+	var lock cluster.Lock
+	if err := json.Unmarshal(legacyLock.GetJson(), &lock); err != nil {
+		return cluster.Lock{}, false
+	}
+
+	return lock, true
 }
 
 // writeCluster writes the provided cluster DAG as manifest file to node directories on disk.
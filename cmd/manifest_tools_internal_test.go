@@ -0,0 +1,28 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestLockVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+	require.NoError(t, newLockVerifier(ctx, lockVerifyConfig{})(lock))
+
+	lock.SignatureAggregate = []byte("invalid")
+	require.ErrorContains(t, newLockVerifier(ctx, lockVerifyConfig{})(lock), "cluster lock signature verification failed")
+	require.NoError(t, newLockVerifier(ctx, lockVerifyConfig{NoVerify: true})(lock))
+
+	lock.Name = "tampered"
+	require.ErrorContains(t, newLockVerifier(ctx, lockVerifyConfig{})(lock), "cluster lock hash verification failed")
+	require.NoError(t, newLockVerifier(ctx, lockVerifyConfig{NoVerify: true})(lock))
+}
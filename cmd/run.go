@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"context"
+	"math"
 	"net/url"
 	"time"
 
@@ -13,9 +14,11 @@ import (
 
 	"github.com/obolnetwork/charon/app"
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/p2p"
 )
 
@@ -50,6 +53,7 @@ func newRunCmd(runFunc func(context.Context, app.Config) error, unsafe bool) *co
 	bindRunFlags(cmd, &conf)
 	bindDebugMonitoringFlags(cmd, &conf.MonitoringAddr, &conf.DebugAddr, "127.0.0.1:3620")
 	bindNoVerifyFlag(cmd.Flags(), &conf.NoVerify)
+	bindExecutionClientRPCFlag(cmd.Flags(), &conf.ExecutionClientRPC)
 	bindP2PFlags(cmd, &conf.P2P)
 	bindLogFlags(cmd.Flags(), &conf.Log)
 	bindLokiFlags(cmd.Flags(), &conf.Log)
@@ -70,20 +74,46 @@ func bindNoVerifyFlag(flags *pflag.FlagSet, config *bool) {
 	flags.BoolVar(config, "no-verify", false, "Disables cluster definition and lock file verification.")
 }
 
+func bindExecutionClientRPCFlag(flags *pflag.FlagSet, config *string) {
+	flags.StringVar(config, "execution-client-rpc-endpoint", "", "The address of the execution client JSON-RPC API used to verify ERC-1271 smart contract signatures of creators and operators, e.g., multisig wallets.")
+}
+
+// verifyOptions returns the cluster signature verification options for the provided execution client JSON-RPC endpoint.
+func verifyOptions(ctx context.Context, executionClientRPC string) []cluster.VerifyOption {
+	if executionClientRPC == "" {
+		return nil
+	}
+
+	return []cluster.VerifyOption{cluster.WithContractSigVerifier(ctx, eth1wrap.NewClient(executionClientRPC))}
+}
+
 func bindRunFlags(cmd *cobra.Command, config *app.Config) {
+	var builderBoostFactor uint64
+
 	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
 	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", eth2ClientTimeout, "Timeout for the HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().DurationVar(&config.BeaconNodeSubmitTimeout, "beacon-node-submit-timeout", eth2ClientTimeout, "Timeout for the submission-related HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.CertFile, "validator-api-tls-cert-file", "", "Path to the PEM encoded TLS certificate served by the validator API. Enables TLS if set.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.KeyFile, "validator-api-tls-key-file", "", "Path to the PEM encoded TLS private key of the validator API certificate.")
+	cmd.Flags().BoolVar(&config.ValidatorAPITLS.SelfSigned, "validator-api-tls-self-signed", false, "Generates a self-signed validator API TLS certificate and key to the configured files if they do not exist. The certificate fingerprint is logged on startup for pinning by validator clients.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.ClientCAFile, "validator-api-tls-client-ca-file", "", "Path to the PEM encoded CA certificate used to authenticate validator client TLS certificates (mTLS). Requires TLS.")
+	cmd.Flags().StringVar(&config.ValidatorAPITokensFile, "validator-api-tokens-file", "", "Path to a JSON file of validator API bearer tokens, each identifying a validator client and optionally scoped to a list of DV public keys: [{\"name\":\"vc1\",\"token\":\"secret\",\"pubkeys\":[\"0x...\"]}]. Enables token authentication if set.")
 	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "Listening address for jaeger tracing.")
 	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "charon", "Service name used for jaeger tracing.")
 	cmd.Flags().BoolVar(&config.SimnetBMock, "simnet-beacon-mock", false, "Enables an internal mock beacon node for running a simnet.")
 	cmd.Flags().BoolVar(&config.SimnetVMock, "simnet-validator-mock", false, "Enables an internal mock validator client when running a simnet. Requires simnet-beacon-mock.")
 	cmd.Flags().StringVar(&config.SimnetValidatorKeysDir, "simnet-validator-keys-dir", ".charon/validator_keys", "The directory containing the simnet validator key shares.")
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
+	cmd.Flags().Uint64Var(&builderBoostFactor, "builder-boost-factor", math.MaxUint64, "Relative weight of builder block value versus local block value used by the beacon node to select proposals when builder-api is enabled; 0 always selects local blocks, 100 selects the most valuable block. Must be identical on all nodes in the cluster, mismatches are logged as errors.")
+	cmd.Flags().Uint64Var(&config.BuilderMinBidGwei, "builder-min-bid", 0, "Minimum builder bid value in gwei. Builder proposals with lower values fall back to locally built blocks. Must be identical on all nodes in the cluster, mismatches are logged as errors. Disabled if zero.")
+	cmd.Flags().StringSliceVar(&config.BuilderRelays, "builder-relays", nil, "Comma separated list of MEV relay URLs to which aggregated builder registrations are also submitted directly. Requires builder-api.")
+	cmd.Flags().Uint64Var(&config.BuilderRecastEpochs, "builder-recast-epochs", 1, "Number of epochs between rebroadcasts of the latest aggregated builder registrations.")
+	cmd.Flags().StringVar(&config.BuilderRegistrationFile, "builder-registration-file", "", "Path to a file persisting the latest aggregated builder registration per validator across restarts. Disabled if empty.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
+	cmd.Flags().BoolVar(&config.SyntheticSyncCommittee, "synthetic-sync-committee", false, "Enables additional synthetic sync committee duties, including contribution aggregation. Used for testing of rare duties.")
 	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
 	cmd.Flags().BoolVar(&config.SimnetBMockFuzz, "simnet-beacon-mock-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
 	cmd.Flags().StringVar(&config.TestnetConfig.Name, "testnet-name", "", "Name of the custom test network.")
@@ -91,14 +121,45 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().Uint64Var(&config.TestnetConfig.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
 	cmd.Flags().Int64Var(&config.TestnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
 	cmd.Flags().StringVar(&config.TestnetConfig.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
+	cmd.Flags().Uint64Var(&config.DoppelgangerEpochs, "doppelganger-epochs", 0, "Number of complete epochs to watch for validator activity not produced by this cluster before enabling duties. Disabled if zero.")
 	cmd.Flags().StringVar(&config.ProcDirectory, "proc-directory", "", "Directory to look into in order to detect other stack components running on the host.")
 	cmd.Flags().StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the node. Selected automatically when not specified.")
+	cmd.Flags().StringSliceVar(&config.SlotOffsets, "slot-offsets", nil, "Comma separated list of duty slot offset overrides formatted as <duty>=<duration>, e.g. 'attester=3s,aggregator=7s'. Duties are triggered at their offset after the start of the slot. Network defaults are used if not specified.")
+	cmd.Flags().BoolVar(&config.SlotOffsetsAdaptive, "slot-offsets-adaptive", false, "Enables adaptive duty slot offsets, triggering duties earlier within safe bounds when consensus or chain inclusion is observed to be late.")
+	cmd.Flags().StringVar(&config.SignatureDBSnapshotDir, "signature-db-snapshot-dir", "", "Directory in which the partial and aggregate signature databases are snapshotted on graceful shutdown and restored from on startup, so duties in progress survive restarts mid-epoch. Disabled if empty.")
+	cmd.Flags().StringVar(&config.RecordFile, "record-file", "", "Path to an append-only file recording all external inputs, i.e. beacon node responses, validator client requests and inbound p2p messages, for post-mortem replay via 'charon debug replay'. The file grows quickly, only enable when debugging. Disabled if empty.")
 
 	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
 			return errors.New("either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified")
 		}
 
+		tlsConf := config.ValidatorAPITLS
+		if (tlsConf.CertFile == "") != (tlsConf.KeyFile == "") {
+			return errors.New("flags 'validator-api-tls-cert-file' and 'validator-api-tls-key-file' must be specified together")
+		} else if tlsConf.SelfSigned && tlsConf.CertFile == "" {
+			return errors.New("flag 'validator-api-tls-self-signed' requires 'validator-api-tls-cert-file' and 'validator-api-tls-key-file'")
+		} else if tlsConf.ClientCAFile != "" && tlsConf.CertFile == "" {
+			return errors.New("flag 'validator-api-tls-client-ca-file' requires 'validator-api-tls-cert-file'")
+		}
+
+		if config.SimnetVMock && (tlsConf.CertFile != "" || config.ValidatorAPITokensFile != "") {
+			return errors.New("flag 'simnet-validator-mock' does not support validator api tls or token authentication")
+		}
+
+		if len(config.BuilderRelays) > 0 && !config.BuilderAPI {
+			return errors.New("flag 'builder-relays' requires flag 'builder-api=true'")
+		}
+
+		if config.BuilderRecastEpochs == 0 {
+			return errors.New("flag 'builder-recast-epochs' must be greater than zero")
+		}
+
+		// Only override the fetcher default if explicitly configured.
+		if cmd.Flags().Changed("builder-boost-factor") {
+			config.BuilderBoostFactor = &builderBoostFactor
+		}
+
 		return nil
 	})
 }
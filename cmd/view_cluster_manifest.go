@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -36,7 +37,7 @@ func newViewClusterManifestCmd(runFunc func(io.Writer, string) error) *cobra.Com
 }
 
 func runViewClusterManifest(out io.Writer, manifestFilePath string) error {
-	cluster, err := loadClusterManifest(manifestFilePath, "")
+	cluster, err := loadClusterManifest(context.Background(), manifestFilePath, "", lockVerifyConfig{})
 	if err != nil {
 		return err
 	}
@@ -19,6 +19,7 @@ func NewMemDB(deadliner core.Deadliner) *MemDB {
 		keysByDuty:     make(map[core.Duty][]memDBKey),
 		commands:       make(chan writeCommand),
 		queries:        make(chan readQuery),
+		snapshots:      make(chan chan snapshotResult),
 		blockedQueries: []readQuery{},
 		queryCallback:  func([]readQuery) {},
 		quit:           make(chan struct{}),
@@ -33,6 +34,7 @@ type MemDB struct {
 
 	commands       chan writeCommand
 	queries        chan readQuery
+	snapshots      chan chan snapshotResult
 	blockedQueries []readQuery
 	queryCallback  func([]readQuery) // Callback for testing.
 
@@ -127,6 +129,9 @@ func (db *MemDB) Run(ctx context.Context) {
 				db.blockedQueries = append(db.blockedQueries, query)
 				db.callbackBlockedQueriesForT()
 			}
+		case response := <-db.snapshots:
+			resp, err := snapshot(db.data)
+			response <- snapshotResult{snapshot: resp, err: err}
 		case duty := <-db.deadliner.C():
 			for _, key := range db.keysByDuty[duty] {
 				delete(db.data, key)
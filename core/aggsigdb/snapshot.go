@@ -0,0 +1,120 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package aggsigdb
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// Snapshot returns a snapshot of the aggregate signed data of all duties not yet expired.
+// The snapshot is created by the Run goroutine, or directly once Run returned.
+func (db *MemDB) Snapshot(ctx context.Context) (*pbv1.SignedDataSnapshot, error) {
+	response := make(chan snapshotResult, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-db.quit:
+		return snapshot(db.data)
+	case db.snapshots <- response:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-response:
+		return result.snapshot, result.err
+	}
+}
+
+// Restore restores the aggregate signed data of duties that have not expired from the snapshot.
+// It must be called before Run.
+func (db *MemDB) Restore(snapshot *pbv1.SignedDataSnapshot) error {
+	return restore(snapshot, db.deadliner, func(key memDBKey, data core.SignedData) {
+		if _, ok := db.data[key]; !ok {
+			db.keysByDuty[key.duty] = append(db.keysByDuty[key.duty], key)
+		}
+		db.data[key] = data
+	})
+}
+
+// Snapshot returns a snapshot of the aggregate signed data of all duties not yet expired.
+func (m *MemDBV2) Snapshot(context.Context) (*pbv1.SignedDataSnapshot, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	return snapshot(m.data)
+}
+
+// Restore restores the aggregate signed data of duties that have not expired from the snapshot.
+// It should be called before any data is stored.
+func (m *MemDBV2) Restore(snapshot *pbv1.SignedDataSnapshot) error {
+	m.Lock()
+	defer m.Unlock()
+
+	return restore(snapshot, m.deadliner, func(key memDBKey, data core.SignedData) {
+		if _, ok := m.data[key]; !ok {
+			m.keysByDuty[key.duty] = append(m.keysByDuty[key.duty], key)
+		}
+		m.data[key] = data
+	})
+}
+
+// snapshot returns a snapshot of the aggregate signed data. Since signed data can't be converted to protobufs directly,
+// each aggregate is stored as a partial signed data with a zero share index.
+func snapshot(data map[memDBKey]core.SignedData) (*pbv1.SignedDataSnapshot, error) {
+	resp := new(pbv1.SignedDataSnapshot)
+	for key, signed := range data {
+		pb, err := core.ParSignedDataToProto(core.ParSignedData{SignedData: signed})
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Entries = append(resp.Entries, &pbv1.SignedDataEntry{
+			Duty:   core.DutyToProto(key.duty),
+			PubKey: string(key.pubKey),
+			Data:   []*pbv1.ParSignedData{pb},
+		})
+	}
+
+	return resp, nil
+}
+
+// restore calls the store function with the aggregate signed data of the snapshot entries
+// of duties that the deadliner accepts, i.e., duties that have not expired.
+func restore(snapshot *pbv1.SignedDataSnapshot, deadliner core.Deadliner, storeFunc func(memDBKey, core.SignedData)) error {
+	active := make(map[core.Duty]bool)
+	for _, entry := range snapshot.GetEntries() {
+		duty := core.DutyFromProto(entry.GetDuty())
+		if _, ok := active[duty]; !ok {
+			active[duty] = deadliner.Add(duty)
+		}
+
+		if !active[duty] {
+			continue // Expired
+		}
+
+		if len(entry.GetData()) != 1 {
+			return errors.New("invalid aggregate signed data snapshot entry")
+		}
+
+		data, err := core.ParSignedDataFromProto(duty.Type, entry.GetData()[0])
+		if err != nil {
+			return err
+		}
+
+		storeFunc(memDBKey{duty: duty, pubKey: core.PubKey(entry.GetPubKey())}, data.SignedData)
+	}
+
+	return nil
+}
+
+// snapshotResult holds the result of a snapshot created by the Run goroutine.
+type snapshotResult struct {
+	snapshot *pbv1.SignedDataSnapshot
+	err      error
+}
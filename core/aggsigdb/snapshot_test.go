@@ -0,0 +1,94 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package aggsigdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/aggsigdb"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+type snapshotDB interface {
+	core.AggSigDB
+	Snapshot(ctx context.Context) (*pbv1.SignedDataSnapshot, error)
+	Restore(snapshot *pbv1.SignedDataSnapshot) error
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Run("MemDB", func(t *testing.T) {
+		testSnapshot(t, func(deadliner core.Deadliner) snapshotDB {
+			return aggsigdb.NewMemDB(deadliner)
+		})
+	})
+
+	t.Run("MemDBV2", func(t *testing.T) {
+		testSnapshot(t, func(deadliner core.Deadliner) snapshotDB {
+			return aggsigdb.NewMemDBV2(deadliner)
+		})
+	})
+}
+
+func testSnapshot(t *testing.T, newMemDB func(core.Deadliner) snapshotDB) {
+	t.Helper()
+
+	const expiredSlot = 1
+
+	var (
+		active  = core.NewRandaoDuty(10)
+		expired = core.NewRandaoDuty(expiredSlot)
+		pubkey  = testutil.RandomCorePubKey(t)
+		randao  = core.NewPartialSignedRandao(1, testutil.RandomEth2Signature(), 0).SignedData
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	db := newMemDB(newNoopDeadliner())
+	go db.Run(ctx)
+
+	require.NoError(t, db.Store(ctx, active, core.SignedDataSet{pubkey: randao}))
+	require.NoError(t, db.Store(ctx, expired, core.SignedDataSet{pubkey: randao}))
+	cancel()
+
+	snapshot, err := db.Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snapshot.GetEntries(), 2)
+
+	// Restore into a new database with a deadliner that expired the earlier duty.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	db = newMemDB(expiringDeadliner{before: expiredSlot + 1})
+	require.NoError(t, db.Restore(snapshot))
+	go db.Run(ctx)
+
+	resp, err := db.Await(ctx, active, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, randao, resp)
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+
+	_, err = db.Await(timeoutCtx, expired, pubkey)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Restored data is validated against subsequently stored data.
+	mismatch := core.NewPartialSignedRandao(eth2p0.Epoch(2), testutil.RandomEth2Signature(), 0).SignedData
+	require.ErrorContains(t, db.Store(ctx, active, core.SignedDataSet{pubkey: mismatch}), "mismatching data")
+}
+
+// expiringDeadliner is a deadliner that doesn't accept duties before the slot.
+type expiringDeadliner struct {
+	noopDeadliner
+	before uint64
+}
+
+func (d expiringDeadliner) Add(duty core.Duty) bool {
+	return duty.Slot >= d.before
+}
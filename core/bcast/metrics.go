@@ -47,6 +47,13 @@ var (
 		Name:      "recast_errors_total",
 		Help:      "The total count of failed recasted registrations by source; 'pregen' vs 'downstream'",
 	}, []string{"source"})
+
+	relayErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "bcast",
+		Name:      "relay_errors_total",
+		Help:      "The total count of failed builder registration submissions to MEV relays by relay host",
+	}, []string{"relay"})
 )
 
 // instrumentDuty increments the duty counter.
@@ -27,24 +27,77 @@ type recastTuple struct {
 	aggData core.SignedData
 }
 
+// RecasterOption configures a Recaster.
+type RecasterOption func(*Recaster)
+
+// WithRecastEpochs returns an option that configures the number of epochs between
+// registration rebroadcasts. It defaults to every epoch.
+func WithRecastEpochs(epochs uint64) RecasterOption {
+	return func(r *Recaster) {
+		r.recastEpochs = epochs
+	}
+}
+
+// WithRegistrationFile returns an option that persists the latest aggregate registration
+// per validator to the provided file and restores them on startup.
+func WithRegistrationFile(path string) RecasterOption {
+	return func(r *Recaster) {
+		r.regFile = path
+	}
+}
+
+// WithFeeRecipients returns an option that restricts the registrations restored from the registration file
+// to the provided cluster validators with matching fee recipients, since both may have changed since the
+// registrations were persisted, e.g. by cluster manifest mutations.
+func WithFeeRecipients(feeRecipients map[core.PubKey]string) RecasterOption {
+	return func(r *Recaster) {
+		r.feeRecipients = feeRecipients
+	}
+}
+
 // NewRecaster returns a new recaster.
-func NewRecaster(activeValsFunc func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error)) (*Recaster, error) {
+func NewRecaster(ctx context.Context, activeValsFunc func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error), opts ...RecasterOption) (*Recaster, error) {
 	if activeValsFunc == nil {
 		return nil, errors.New("active validators provider is nil")
 	}
 
-	return &Recaster{
+	r := &Recaster{
 		tuples:         make(map[core.PubKey]recastTuple),
 		activeValsFunc: activeValsFunc,
-	}, nil
+		recastEpochs:   1,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.recastEpochs == 0 {
+		return nil, errors.New("recast epochs must be greater than zero")
+	}
+
+	if r.regFile != "" {
+		tuples, err := loadRegistrations(ctx, r.regFile, r.feeRecipients)
+		if err != nil {
+			return nil, err
+		}
+
+		r.tuples = tuples
+	}
+
+	return r, nil
 }
 
-// Recaster rebroadcasts core.DutyBuilderRegistration aggregate signatures every epoch.
+// Recaster rebroadcasts core.DutyBuilderRegistration aggregate signatures every epoch
+// (or every configured number of epochs).
 type Recaster struct {
 	mu             sync.Mutex
+	fileMu         sync.Mutex
 	tuples         map[core.PubKey]recastTuple
 	activeValsFunc func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error)
 	subs           []func(context.Context, core.Duty, core.SignedDataSet) error
+	recastEpochs   uint64
+	regFile        string
+	feeRecipients  map[core.PubKey]string
 }
 
 // Subscribe subscribes to rebroadcasted duties.
@@ -63,32 +116,38 @@ func (r *Recaster) Store(ctx context.Context, duty core.Duty,
 		return nil
 	}
 
+	var stored bool
 	for pubkey, aggData := range set {
-		if err := r.store(ctx, duty, pubkey, aggData); err != nil {
+		ok, err := r.store(duty, pubkey, aggData)
+		if err != nil {
 			return err
 		}
+		stored = stored || ok
+	}
+
+	if stored && r.regFile != "" {
+		r.persist(ctx)
 	}
 
 	return nil
 }
 
 // store stores aggregate signed duty registrations for rebroadcasting.
-func (r *Recaster) store(_ context.Context, duty core.Duty,
-	pubkey core.PubKey, aggData core.SignedData,
-) error {
+// It returns true if the registration was stored.
+func (r *Recaster) store(duty core.Duty, pubkey core.PubKey, aggData core.SignedData) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	tuple, ok := r.tuples[pubkey]
 	if ok && tuple.duty.Slot >= duty.Slot {
 		// Not storing duplicate or older registration.
-		return nil
+		return false, nil
 	}
 
 	// Clone before storing.
 	data, err := aggData.Clone()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	r.tuples[pubkey] = recastTuple{
@@ -99,12 +158,31 @@ func (r *Recaster) store(_ context.Context, duty core.Duty,
 	// Add unique registrations count.
 	recastRegistrationCounter.WithLabelValues(pubkey.String()).Inc()
 
-	return nil
+	return true, nil
+}
+
+// persist writes all stored registrations to the registration file.
+// The file is written outside the tuples lock, while writes are serialised so the latest registrations always win.
+func (r *Recaster) persist(ctx context.Context) {
+	r.fileMu.Lock()
+	defer r.fileMu.Unlock()
+
+	r.mu.Lock()
+	tuples := make(map[core.PubKey]recastTuple, len(r.tuples))
+	for pubkey, tuple := range r.tuples {
+		tuples[pubkey] = tuple
+	}
+	r.mu.Unlock()
+
+	if err := storeRegistrations(r.regFile, tuples); err != nil {
+		// Persisting is best effort, the registrations are still rebroadcast from memory.
+		log.Warn(ctx, "Failed persisting builder registrations", err, z.Str("path", r.regFile))
+	}
 }
 
 // SlotTicked is called when new slots tick.
 func (r *Recaster) SlotTicked(ctx context.Context, slot core.Slot) error {
-	if !slot.FirstInEpoch() {
+	if !slot.FirstInEpoch() || slot.Epoch()%r.recastEpochs != 0 {
 		return nil
 	}
 	ctx = log.WithTopic(ctx, "bcast")
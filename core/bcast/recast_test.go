@@ -0,0 +1,171 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRecasterPersistence(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "registrations.json")
+
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	pubkey := core.PubKeyFrom48Bytes(reg.V1.Message.Pubkey)
+	activeVals := func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+		return map[eth2p0.BLSPubKey]struct{}{reg.V1.Message.Pubkey: {}}, nil
+	}
+
+	recaster, err := bcast.NewRecaster(ctx, activeVals, bcast.WithRegistrationFile(file))
+	require.NoError(t, err)
+
+	duty := core.NewBuilderRegistrationDuty(100)
+	require.NoError(t, recaster.Store(ctx, duty, core.SignedDataSet{pubkey: reg}))
+
+	// Older registrations (for example pregen lock registrations) do not override the persisted one.
+	older := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	older.V1.Message.Pubkey = reg.V1.Message.Pubkey
+
+	// Restart the recaster from the persisted file.
+	recaster, err = bcast.NewRecaster(ctx, activeVals, bcast.WithRegistrationFile(file))
+	require.NoError(t, err)
+	require.NoError(t, recaster.Store(ctx, core.NewBuilderRegistrationDuty(0), core.SignedDataSet{pubkey: older}))
+
+	var recast []core.SignedDataSet
+	recaster.Subscribe(func(_ context.Context, d core.Duty, set core.SignedDataSet) error {
+		require.Equal(t, duty, d)
+		recast = append(recast, set)
+
+		return nil
+	})
+
+	require.NoError(t, recaster.SlotTicked(ctx, core.Slot{Slot: 32, SlotsPerEpoch: 32}))
+	require.Len(t, recast, 1)
+	require.Equal(t, reg, recast[0][pubkey])
+}
+
+func TestRecasterPersistenceFeeRecipients(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "registrations.json")
+
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	pubkey := core.PubKeyFrom48Bytes(reg.V1.Message.Pubkey)
+	unknown := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	activeVals := func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+		return map[eth2p0.BLSPubKey]struct{}{reg.V1.Message.Pubkey: {}, unknown.V1.Message.Pubkey: {}}, nil
+	}
+
+	recaster, err := bcast.NewRecaster(ctx, activeVals, bcast.WithRegistrationFile(file))
+	require.NoError(t, err)
+	require.NoError(t, recaster.Store(ctx, core.NewBuilderRegistrationDuty(100), core.SignedDataSet{
+		pubkey: reg,
+		core.PubKeyFrom48Bytes(unknown.V1.Message.Pubkey): unknown,
+	}))
+
+	restore := func(t *testing.T, feeRecipient string) []core.SignedDataSet {
+		t.Helper()
+
+		recaster, err := bcast.NewRecaster(ctx, activeVals, bcast.WithRegistrationFile(file),
+			bcast.WithFeeRecipients(map[core.PubKey]string{pubkey: feeRecipient}))
+		require.NoError(t, err)
+
+		var recast []core.SignedDataSet
+		recaster.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+			recast = append(recast, set)
+			return nil
+		})
+		require.NoError(t, recaster.SlotTicked(ctx, core.Slot{Slot: 32, SlotsPerEpoch: 32}))
+
+		return recast
+	}
+
+	// Registrations of validators not in the cluster are dropped, fee recipients are compared case-insensitively.
+	recast := restore(t, strings.ToLower(reg.V1.Message.FeeRecipient.String()))
+	require.Len(t, recast, 1)
+	require.Len(t, recast[0], 1)
+	require.Equal(t, reg, recast[0][pubkey])
+
+	// Registrations with outdated fee recipients are dropped, so newer pre-generated registrations aren't shadowed.
+	recast = restore(t, testutil.RandomChecksummedETHAddress(t, 1))
+	require.Empty(t, recast)
+}
+
+func TestRecasterEpochs(t *testing.T) {
+	ctx := context.Background()
+
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	pubkey := core.PubKeyFrom48Bytes(reg.V1.Message.Pubkey)
+	activeVals := func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+		return map[eth2p0.BLSPubKey]struct{}{reg.V1.Message.Pubkey: {}}, nil
+	}
+
+	_, err := bcast.NewRecaster(ctx, activeVals, bcast.WithRecastEpochs(0))
+	require.ErrorContains(t, err, "recast epochs must be greater than zero")
+
+	recaster, err := bcast.NewRecaster(ctx, activeVals, bcast.WithRecastEpochs(4))
+	require.NoError(t, err)
+	require.NoError(t, recaster.Store(ctx, core.NewBuilderRegistrationDuty(1), core.SignedDataSet{pubkey: reg}))
+
+	var count int
+	recaster.Subscribe(func(context.Context, core.Duty, core.SignedDataSet) error {
+		count++
+		return nil
+	})
+
+	const slotsPerEpoch = 4
+	for slot := range uint64(10 * slotsPerEpoch) {
+		require.NoError(t, recaster.SlotTicked(ctx, core.Slot{Slot: slot, SlotsPerEpoch: slotsPerEpoch}))
+	}
+
+	// Epochs 0, 4 and 8.
+	require.Equal(t, 3, count)
+}
+
+func TestRelayBroadcaster(t *testing.T) {
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	pubkey := core.PubKeyFrom48Bytes(reg.V1.Message.Pubkey)
+
+	received := make(chan []*eth2v1.SignedValidatorRegistration, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/builder/validators", r.URL.Path)
+
+		var regs []*eth2v1.SignedValidatorRegistration
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&regs))
+		received <- regs
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	relays, err := bcast.NewRelayBroadcaster([]string{srv.URL}, time.Second)
+	require.NoError(t, err)
+
+	// Non-registration duties are ignored.
+	err = relays.Broadcast(context.Background(), core.NewAttesterDuty(1), core.SignedDataSet{pubkey: reg})
+	require.NoError(t, err)
+
+	err = relays.Broadcast(context.Background(), core.NewBuilderRegistrationDuty(1), core.SignedDataSet{pubkey: reg})
+	require.NoError(t, err)
+
+	regs := <-received
+	require.Len(t, regs, 1)
+	require.Equal(t, reg.V1, regs[0])
+
+	_, err = bcast.NewRelayBroadcaster([]string{"invalid"}, time.Second)
+	require.ErrorContains(t, err, "parse relay url")
+}
@@ -0,0 +1,113 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// storedRegistration is the json format of a persisted aggregate builder registration.
+type storedRegistration struct {
+	Slot         uint64                                    `json:"slot"`
+	Registration core.VersionedSignedValidatorRegistration `json:"registration"`
+}
+
+// loadRegistrations returns the recast tuples persisted in the provided file.
+// If fee recipients are provided, registrations of other validators or with other fee recipients are dropped,
+// so outdated registrations never shadow newer pre-generated ones.
+// It returns an empty map if the file doesn't exist yet.
+func loadRegistrations(ctx context.Context, path string, feeRecipients map[core.PubKey]string) (map[core.PubKey]recastTuple, error) {
+	resp := make(map[core.PubKey]recastTuple)
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return resp, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read registrations file", z.Str("path", path))
+	}
+
+	var stored map[core.PubKey]storedRegistration
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return nil, errors.Wrap(err, "unmarshal registrations file", z.Str("path", path))
+	}
+
+	for pubkey, reg := range stored {
+		regPubkey, err := reg.Registration.PubKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "registration pubkey")
+		}
+
+		if core.PubKeyFrom48Bytes(regPubkey) != pubkey {
+			log.Warn(ctx, "Dropping persisted builder registration with mismatching pubkey", nil, z.Any("pubkey", pubkey))
+			continue
+		}
+
+		if feeRecipients != nil {
+			feeRecipient, ok := feeRecipients[pubkey]
+			if !ok {
+				log.Info(ctx, "Dropping persisted builder registration of unknown validator", z.Any("pubkey", pubkey))
+				continue
+			}
+
+			regFeeRecipient, err := reg.Registration.FeeRecipient()
+			if err != nil {
+				return nil, errors.Wrap(err, "registration fee recipient")
+			}
+
+			if !strings.EqualFold(regFeeRecipient.String(), feeRecipient) {
+				log.Info(ctx, "Dropping persisted builder registration with outdated fee recipient",
+					z.Any("pubkey", pubkey), z.Str("fee_recipient", feeRecipient))
+
+				continue
+			}
+		}
+
+		resp[pubkey] = recastTuple{
+			duty:    core.NewBuilderRegistrationDuty(reg.Slot),
+			aggData: reg.Registration,
+		}
+	}
+
+	return resp, nil
+}
+
+// storeRegistrations atomically persists the provided recast tuples to the provided file.
+func storeRegistrations(path string, tuples map[core.PubKey]recastTuple) error {
+	stored := make(map[core.PubKey]storedRegistration)
+	for pubkey, tuple := range tuples {
+		reg, ok := tuple.aggData.(core.VersionedSignedValidatorRegistration)
+		if !ok {
+			return errors.New("invalid registration type")
+		}
+
+		stored[pubkey] = storedRegistration{
+			Slot:         tuple.duty.Slot,
+			Registration: reg,
+		}
+	}
+
+	b, err := json.MarshalIndent(stored, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal registrations")
+	}
+
+	// Write to a temporary file first and then rename it, so a crash never leaves a corrupt file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil { //nolint:gosec // Registrations are public data.
+		return errors.Wrap(err, "write registrations file")
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(err, "rename registrations file")
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// relayRegistrationPath is the builder API endpoint for submitting validator registrations.
+const relayRegistrationPath = "/eth/v1/builder/validators"
+
+// NewRelayBroadcaster returns a new relay broadcaster that submits aggregate
+// builder registrations directly to the provided MEV relays.
+func NewRelayBroadcaster(relays []string, timeout time.Duration) (RelayBroadcaster, error) {
+	var urls []*url.URL
+	for _, relay := range relays {
+		u, err := url.ParseRequestURI(relay)
+		if err != nil {
+			return RelayBroadcaster{}, errors.Wrap(err, "parse relay url", z.Str("relay", relay))
+		}
+
+		u.Path = relayRegistrationPath
+		urls = append(urls, u)
+	}
+
+	return RelayBroadcaster{
+		urls:    urls,
+		timeout: timeout,
+	}, nil
+}
+
+// RelayBroadcaster submits builder registrations to MEV relays, bypassing the beacon node.
+type RelayBroadcaster struct {
+	urls    []*url.URL
+	timeout time.Duration
+}
+
+// Broadcast submits the aggregated builder registrations to all configured relays.
+// Other duty types are ignored. It tries all relays and returns the last error.
+func (b RelayBroadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+	if duty.Type != core.DutyBuilderRegistration || len(b.urls) == 0 {
+		return nil
+	}
+
+	var regs []*eth2v1.SignedValidatorRegistration
+	for _, aggData := range set {
+		reg, ok := aggData.(core.VersionedSignedValidatorRegistration)
+		if !ok {
+			return errors.New("invalid registration")
+		} else if reg.Version != eth2spec.BuilderVersionV1 {
+			return errors.New("unsupported registration version", z.Any("version", reg.Version))
+		}
+
+		regs = append(regs, reg.V1)
+	}
+
+	body, err := json.Marshal(regs)
+	if err != nil {
+		return errors.Wrap(err, "marshal registrations")
+	}
+
+	var lastErr error
+	for _, u := range b.urls {
+		err := b.post(ctx, u, body)
+		if err != nil {
+			lastErr = err
+			relayErrors.WithLabelValues(u.Host).Inc()
+
+			continue
+		}
+
+		log.Debug(ctx, "Submitted validator registrations to relay",
+			z.Str("relay", u.Host), z.Int("count", len(regs)))
+	}
+
+	return lastErr
+}
+
+// post submits the body to the relay registration endpoint.
+func (b RelayBroadcaster) post(ctx context.Context, u *url.URL, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "new relay request", z.Str("relay", u.Host))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "submit registrations to relay", z.Str("relay", u.Host))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(res.Body)
+		return errors.New("relay rejected registrations",
+			z.Str("relay", u.Host), z.Int("status", res.StatusCode), z.Str("body", string(data)))
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/protocol"
 
@@ -25,6 +26,7 @@ type consensusController struct {
 	p2pKey           *k1.PrivateKey
 	gaterFunc        core.DutyGaterFunc
 	deadlineFunc     core.DeadlineFunc
+	clock            clockwork.Clock
 	debugger         Debugger
 	defaultConsensus core.Consensus
 	wrappedConsensus *consensusWrapper
@@ -38,10 +40,10 @@ type consensusController struct {
 // NewConsensusController creates a new consensus controller with the default consensus protocol.
 func NewConsensusController(ctx context.Context, tcpNode host.Host, sender *p2p.Sender,
 	peers []p2p.Peer, p2pKey *k1.PrivateKey, deadlineFunc core.DeadlineFunc,
-	gaterFunc core.DutyGaterFunc, debugger Debugger,
+	gaterFunc core.DutyGaterFunc, debugger Debugger, clock clockwork.Clock,
 ) (core.ConsensusController, error) {
-	qbftDeadliner := core.NewDeadliner(ctx, "consensus.qbft", deadlineFunc)
-	defaultConsensus, err := qbft.NewConsensus(tcpNode, sender, peers, p2pKey, qbftDeadliner, gaterFunc, debugger.AddInstance)
+	qbftDeadliner := core.NewDeadlinerWithClock(ctx, "consensus.qbft", deadlineFunc, clock)
+	defaultConsensus, err := qbft.NewConsensus(tcpNode, sender, peers, p2pKey, qbftDeadliner, gaterFunc, debugger.AddInstance, clock)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +55,7 @@ func NewConsensusController(ctx context.Context, tcpNode host.Host, sender *p2p.
 		p2pKey:           p2pKey,
 		gaterFunc:        gaterFunc,
 		deadlineFunc:     deadlineFunc,
+		clock:            clock,
 		debugger:         debugger,
 		defaultConsensus: defaultConsensus,
 		wrappedConsensus: newConsensusWrapper(defaultConsensus),
@@ -108,7 +111,7 @@ func (f *consensusController) SetCurrentConsensusForProtocol(_ context.Context,
 			f.mutable.cancelWrappedCtx()
 		}
 
-		xyzDeadliner := core.NewDeadliner(cctx, "consensus.xyz", f.deadlineFunc)
+		xyzDeadliner := core.NewDeadlinerWithClock(cctx, "consensus.xyz", f.deadlineFunc, f.clock)
 		xyzConsensus := xyz.NewConsensus(...)
 
 		f.mutable.cancelWrappedCtx = cancel
@@ -43,6 +43,13 @@ var (
 		Name:      "error_total",
 		Help:      "Total count of consensus errors by protocol",
 	}, []string{"protocol"})
+
+	invalidValueCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "invalid_value_total",
+		Help:      "Total count of rejected invalid values proposed by leaders by protocol and duty",
+	}, []string{"protocol", "duty"})
 )
 
 // ConsensusMetrics defines the interface for consensus metrics.
@@ -61,6 +68,9 @@ type ConsensusMetrics interface {
 
 	// IncConsensusError increments the consensus error counter.
 	IncConsensusError()
+
+	// IncInvalidValue increments the rejected invalid proposed value counter for a given duty.
+	IncInvalidValue(duty string)
 }
 
 type consensusMetrics struct {
@@ -98,3 +108,8 @@ func (m *consensusMetrics) IncConsensusTimeout(duty, timer string) {
 func (m *consensusMetrics) IncConsensusError() {
 	consensusError.WithLabelValues(m.protocolID).Inc()
 }
+
+// IncInvalidValue increments the rejected invalid proposed value counter for a given duty.
+func (m *consensusMetrics) IncInvalidValue(duty string) {
+	invalidValueCounter.WithLabelValues(m.protocolID, duty).Inc()
+}
@@ -69,6 +69,17 @@ func TestConsensusMetrics_IncConsensusError(t *testing.T) {
 	verifyLabel(t, m.GetMetric()[0].GetLabel(), "protocol", "test")
 }
 
+func TestConsensusMetrics_IncInvalidValue(t *testing.T) {
+	cm := metrics.NewConsensusMetrics("test")
+
+	cm.IncInvalidValue("duty")
+
+	m := gatherMetric(t, "core_consensus_invalid_value_total")
+	require.InEpsilon(t, 1, m.GetMetric()[0].GetCounter().GetValue(), 0.0001)
+	verifyLabel(t, m.GetMetric()[0].GetLabel(), "protocol", "test")
+	verifyLabel(t, m.GetMetric()[0].GetLabel(), "duty", "duty")
+}
+
 func gatherMetric(t *testing.T, name string) *pb.MetricFamily {
 	t.Helper()
 
@@ -12,6 +12,14 @@ const (
 	protocolIDPrefix = "/charon/consensus/"
 
 	QBFTv2ProtocolID = "/charon/consensus/qbft/2.0.0"
+
+	// QBFTv2SnappyProtocolID is the snappy compressed wire variant of QBFTv2ProtocolID.
+	// It isn't a separate consensus protocol, so it is excluded from Protocols.
+	QBFTv2SnappyProtocolID = "/charon/consensus/qbft/2.0.0/snappy"
+
+	// QBFTv2GossipProtocolID is the gossip topic of QBFTv2ProtocolID messages.
+	// It is negotiated separately via infosync and doesn't use the consensus prefix since it isn't a consensus protocol.
+	QBFTv2GossipProtocolID = "/charon/gossip/qbft/2.0.0"
 )
 
 // Protocols returns the supported protocols of this package in order of precedence.
@@ -0,0 +1,143 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+const (
+	// minLedRounds is the minimum number of rounds a peer must lead in a period to assess its reliability.
+	minLedRounds = 3
+	// unreliablePeriods is the number of periods a peer remains unreliable if it didn't lead sufficient rounds,
+	// which is typically the case when it is deprioritised as leader.
+	unreliablePeriods = 8
+)
+
+// leader return the deterministic leader index.
+func leader(duty core.Duty, round int64, nodes int) int64 {
+	return (int64(duty.Slot) + int64(duty.Type) + round) % int64(nodes)
+}
+
+// reliableLeader returns the deterministic leader index deprioritising the unreliable peers.
+// Reliable peers lead the first rounds in round-robin order, unreliable peers only lead
+// subsequent rounds, in the provided order. At most the number of faulty peers tolerated by
+// the cluster are deprioritised. It is identical to leader if no peers are unreliable.
+func reliableLeader(duty core.Duty, round int64, nodes int, unreliable []int64) int64 {
+	maxUnreliable := nodes - qbft.Definition[int, int]{Nodes: nodes}.Quorum()
+
+	var (
+		demoted []int64
+		dedup   = make(map[int64]bool)
+	)
+	for _, idx := range unreliable {
+		if len(demoted) == maxUnreliable {
+			break
+		} else if idx < 0 || idx >= int64(nodes) || dedup[idx] {
+			continue
+		}
+
+		dedup[idx] = true
+		demoted = append(demoted, idx)
+	}
+
+	if len(demoted) == 0 {
+		return leader(duty, round, nodes)
+	}
+
+	var reliable []int64
+	for idx := range int64(nodes) {
+		if !dedup[idx] {
+			reliable = append(reliable, idx)
+		}
+	}
+
+	// Rotate through the reliable peers first, then the unreliable peers, every nodes rounds.
+	offset := (round - 1) % int64(nodes)
+	if offset >= int64(len(reliable)) {
+		return demoted[offset-int64(len(reliable))]
+	}
+
+	start := int64(duty.Slot) + int64(duty.Type) + 1 + (round-1)/int64(nodes)
+
+	return reliable[(start+offset)%int64(len(reliable))]
+}
+
+// newLeaderStats returns a new leaderStats.
+func newLeaderStats() *leaderStats {
+	return &leaderStats{
+		led:        make(map[int64]int),
+		failed:     make(map[int64]int),
+		unreliable: make(map[int64]int),
+	}
+}
+
+// leaderStats tracks the rounds led by peers to identify unreliable leaders.
+type leaderStats struct {
+	mu         sync.Mutex
+	led        map[int64]int
+	failed     map[int64]int
+	unreliable map[int64]int // Remaining periods by unreliable peer.
+}
+
+// Decided records the leaders of the rounds of a decided consensus instance by round.
+// The leaders of all but the last round failed to lead the instance to a decision.
+func (s *leaderStats) Decided(leaders []int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, idx := range leaders {
+		s.led[idx]++
+		if i < len(leaders)-1 {
+			s.failed[idx]++
+		}
+	}
+}
+
+// Unreliable returns the peers that failed leading most of their rounds in the period since
+// the previous call, ordered by failed rounds. Peers remain unreliable for subsequent periods
+// until they led sufficient rounds to be reassessed, or unreliablePeriods elapsed.
+func (s *leaderStats) Unreliable() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for idx, led := range s.led {
+		if led < minLedRounds {
+			continue
+		}
+
+		if s.failed[idx]*2 > led {
+			s.unreliable[idx] = unreliablePeriods + 1 // Decremented below.
+		} else {
+			delete(s.unreliable, idx)
+		}
+	}
+
+	var resp []int64
+	for idx := range s.unreliable {
+		s.unreliable[idx]--
+		if s.unreliable[idx] == 0 {
+			delete(s.unreliable, idx)
+			continue
+		}
+
+		resp = append(resp, idx)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		if s.failed[resp[i]] != s.failed[resp[j]] {
+			return s.failed[resp[i]] > s.failed[resp[j]]
+		}
+
+		return resp[i] < resp[j]
+	})
+
+	s.led = make(map[int64]int)
+	s.failed = make(map[int64]int)
+
+	return resp
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestReliableLeader(t *testing.T) {
+	const nodes = 4
+
+	duties := []core.Duty{
+		core.NewAttesterDuty(0),
+		core.NewAttesterDuty(1),
+		core.NewProposerDuty(2),
+		core.NewRandaoDuty(3),
+	}
+
+	t.Run("no unreliable", func(t *testing.T) {
+		for _, duty := range duties {
+			for round := int64(1); round <= 2*nodes; round++ {
+				require.Equal(t, leader(duty, round, nodes), reliableLeader(duty, round, nodes, nil))
+			}
+		}
+	})
+
+	t.Run("unreliable", func(t *testing.T) {
+		for _, duty := range duties {
+			for cycle := range int64(2) {
+				var leaders []int64
+				for round := int64(1); round <= nodes; round++ {
+					leaders = append(leaders, reliableLeader(duty, cycle*nodes+round, nodes, []int64{2}))
+				}
+
+				// All peers lead once per cycle, the unreliable peer last.
+				require.ElementsMatch(t, []int64{0, 1, 2, 3}, leaders)
+				require.EqualValues(t, 2, leaders[nodes-1])
+			}
+		}
+	})
+
+	t.Run("max unreliable", func(t *testing.T) {
+		// Only a single faulty peer is tolerated, invalid and duplicate indexes are ignored.
+		unreliable := []int64{-1, 3, 3, nodes, 2}
+		for _, duty := range duties {
+			require.EqualValues(t, 3, reliableLeader(duty, nodes, nodes, unreliable))
+			require.NotEqualValues(t, 2, reliableLeader(duty, nodes, nodes, unreliable))
+		}
+	})
+}
+
+func TestLeaderStats(t *testing.T) {
+	stats := newLeaderStats()
+
+	for range minLedRounds {
+		stats.Decided([]int64{1, 2}) // Peer 1 failed, peer 2 succeeded.
+		stats.Decided([]int64{0})
+	}
+	stats.Decided([]int64{3, 0}) // Peer 3 failed, but didn't lead sufficient rounds.
+
+	require.Equal(t, []int64{1}, stats.Unreliable())
+
+	// Peer 1 remains unreliable while it doesn't lead sufficient rounds.
+	for range unreliablePeriods - 1 {
+		stats.Decided([]int64{1})
+		require.Equal(t, []int64{1}, stats.Unreliable())
+	}
+	require.Empty(t, stats.Unreliable())
+
+	// Peer 1 is reassessed once it led sufficient rounds.
+	for range minLedRounds {
+		stats.Decided([]int64{1, 2})
+	}
+	require.Equal(t, []int64{1}, stats.Unreliable())
+
+	for range minLedRounds {
+		stats.Decided([]int64{1})
+	}
+	require.Empty(t, stats.Unreliable())
+}
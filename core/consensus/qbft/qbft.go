@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"strings"
 	"sync"
-	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -26,22 +26,26 @@ import (
 	"github.com/obolnetwork/charon/core/consensus/protocols"
 	"github.com/obolnetwork/charon/core/consensus/utils"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/gossip"
 	"github.com/obolnetwork/charon/core/qbft"
 	"github.com/obolnetwork/charon/p2p"
 )
 
 type subscriber func(ctx context.Context, duty core.Duty, value proto.Message) error
 
+// ValidateValueFunc returns an error if the unsigned data set proposed by a leader for the duty is invalid.
+type ValidateValueFunc func(ctx context.Context, duty core.Duty, set core.UnsignedDataSet) error
+
 // newDefinition returns a qbft definition (this is constant across all consensus instances).
 func newDefinition(nodes int, subs func() []subscriber, roundTimer utils.RoundTimer,
-	decideCallback func(qcommit []qbft.Msg[core.Duty, [32]byte]),
+	leaderFunc func(duty core.Duty, round int64) int64, decideCallback func(qcommit []qbft.Msg[core.Duty, [32]byte]),
 ) qbft.Definition[core.Duty, [32]byte] {
 	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
 
 	return qbft.Definition[core.Duty, [32]byte]{
 		// IsLeader is a deterministic leader election function.
 		IsLeader: func(duty core.Duty, round, process int64) bool {
-			return leader(duty, round, nodes) == process
+			return leaderFunc(duty, round) == process
 		},
 
 		// Decide sends consensus output to subscribers.
@@ -93,7 +97,7 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer utils.RoundTi
 				z.I64("new_round", newRound),
 			}
 
-			steps := groupRoundMessages(msgs, nodes, round, int(leader(duty, round, nodes)))
+			steps := groupRoundMessages(msgs, nodes, round, int(leaderFunc(duty, round)))
 			for _, step := range steps {
 				fields = append(fields, z.Str(step.Type.String(), fmtStepPeers(step)))
 			}
@@ -122,6 +126,7 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer utils.RoundTi
 // NewConsensus returns a new consensus QBFT component.
 func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.PrivateKey,
 	deadliner core.Deadliner, gaterFunc core.DutyGaterFunc, snifferFunc func(*pbv1.SniffedConsensusInstance),
+	clock clockwork.Clock,
 ) (*Consensus, error) {
 	// Extract peer pubkeys.
 	keys := make(map[int64]*k1.PublicKey)
@@ -138,18 +143,22 @@ func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKe
 	}
 
 	c := &Consensus{
-		tcpNode:     tcpNode,
-		sender:      sender,
-		peers:       peers,
-		peerLabels:  labels,
-		privkey:     p2pKey,
-		pubkeys:     keys,
-		deadliner:   deadliner,
-		snifferFunc: snifferFunc,
-		gaterFunc:   gaterFunc,
-		dropFilter:  log.Filter(),
-		timerFunc:   utils.GetTimerFunc(),
-		metrics:     metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
+		tcpNode:       tcpNode,
+		sender:        sender,
+		peers:         peers,
+		peerLabels:    labels,
+		privkey:       p2pKey,
+		pubkeys:       keys,
+		deadliner:     deadliner,
+		snifferFunc:   snifferFunc,
+		gaterFunc:     gaterFunc,
+		dropFilter:    log.Filter(),
+		publishFilter: log.Filter(),
+		timerFunc:     utils.GetTimerFuncWithClock(clock),
+		clock:         clock,
+		metrics:       metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
+		leaderStats:   newLeaderStats(),
+		validators:    make(map[core.DutyType]ValidateValueFunc),
 	}
 	c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
 
@@ -159,19 +168,29 @@ func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKe
 // Consensus implements core.Consensus & priority.coreConsensus.
 type Consensus struct {
 	// Immutable state
-	tcpNode     host.Host
-	sender      *p2p.Sender
-	peerLabels  []string
-	peers       []p2p.Peer
-	pubkeys     map[int64]*k1.PublicKey
-	privkey     *k1.PrivateKey
-	subs        []subscriber
-	deadliner   core.Deadliner
-	snifferFunc func(*pbv1.SniffedConsensusInstance)
-	gaterFunc   core.DutyGaterFunc
-	dropFilter  z.Field // Filter buffer overflow errors (possible DDoS)
-	timerFunc   utils.TimerFunc
-	metrics     metrics.ConsensusMetrics
+	tcpNode       host.Host
+	sender        *p2p.Sender
+	peerLabels    []string
+	peers         []p2p.Peer
+	pubkeys       map[int64]*k1.PublicKey
+	privkey       *k1.PrivateKey
+	subs          []subscriber
+	deadliner     core.Deadliner
+	snifferFunc   func(*pbv1.SniffedConsensusInstance)
+	gaterFunc     core.DutyGaterFunc
+	dropFilter    z.Field // Filter buffer overflow errors (possible DDoS)
+	publishFilter z.Field // Filter gossip publish errors of offline peers
+	timerFunc     utils.TimerFunc
+	clock         clockwork.Clock
+	metrics       metrics.ConsensusMetrics
+
+	gossip        *gossip.Gossip
+	gossipEnabled func(slot uint64) bool
+
+	leaderStats    *leaderStats
+	unreliableFunc func(slot uint64) []int64
+
+	validators map[core.DutyType]ValidateValueFunc
 
 	// Mutable state
 	mutable struct {
@@ -180,6 +199,54 @@ type Consensus struct {
 	}
 }
 
+// EnableGossip subscribes to consensus messages published via gossip and publishes consensus messages via gossip
+// for duties of slots for which enabledFunc returns true, typically if the cluster supports QBFTv2GossipProtocolID.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Consensus) EnableGossip(g *gossip.Gossip, enabledFunc func(slot uint64) bool) {
+	c.gossip = g
+	c.gossipEnabled = enabledFunc
+
+	g.Subscribe(protocols.QBFTv2GossipProtocolID, func(ctx context.Context, origin peer.ID, msg proto.Message) error {
+		_, _, err := c.handle(ctx, origin, msg)
+		return err
+	})
+}
+
+// RegisterValidateValue registers a function validating values proposed by leaders for duties of the type.
+// Pre-prepare messages of peers proposing invalid values are rejected, so the round times out
+// and changes to the next leader, preventing byzantine leaders from deciding invalid values.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Consensus) RegisterValidateValue(dutyType core.DutyType, fn ValidateValueFunc) {
+	c.validators[dutyType] = fn
+}
+
+// EnableReliableLeaders deprioritises unreliable peers as leaders of consensus instances using the
+// cluster wide agreed unreliable peer indexes returned by unreliableFunc for the duty slot.
+// All peers must use identical unreliable peers to elect identical leaders.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Consensus) EnableReliableLeaders(unreliableFunc func(slot uint64) []int64) {
+	c.unreliableFunc = unreliableFunc
+}
+
+// UnreliableLeaders returns the indexes of peers observed to be unreliable leaders since the previous call.
+func (c *Consensus) UnreliableLeaders() []int64 {
+	return c.leaderStats.Unreliable()
+}
+
+// leaderFunc returns the deterministic leader election function of the consensus instance.
+func (c *Consensus) leaderFunc(slot uint64) func(duty core.Duty, round int64) int64 {
+	var unreliable []int64
+	if c.unreliableFunc != nil {
+		unreliable = c.unreliableFunc(slot)
+	}
+
+	nodes := len(c.peers)
+
+	return func(duty core.Duty, round int64) int64 {
+		return reliableLeader(duty, round, nodes, unreliable)
+	}
+}
+
 // ProtocolID returns the protocol ID.
 func (*Consensus) ProtocolID() protocol.ID {
 	return protocols.QBFTv2ProtocolID
@@ -225,7 +292,7 @@ func (c *Consensus) SubscribePriority(fn func(ctx context.Context, duty core.Dut
 func (c *Consensus) Start(ctx context.Context) {
 	p2p.RegisterHandler("qbft", c.tcpNode, protocols.QBFTv2ProtocolID,
 		func() proto.Message { return new(pbv1.QBFTConsensusMsg) },
-		c.handle)
+		c.handle, p2p.WithSnappyProtocol(protocols.QBFTv2SnappyProtocolID))
 
 	go func() {
 		for {
@@ -292,7 +359,7 @@ func (c *Consensus) propose(ctx context.Context, duty core.Duty, value proto.Mes
 	}
 
 	// Instrument consensus duration using decidedAt output.
-	proposedAt := time.Now()
+	proposedAt := c.clock.Now()
 	defer func() {
 		select {
 		case decidedAt := <-inst.DecidedAtCh:
@@ -338,13 +405,44 @@ func (c *Consensus) Participate(ctx context.Context, duty core.Duty) error {
 
 // Broadcast implements Broadcaster interface.
 func (c *Consensus) Broadcast(ctx context.Context, msg *pbv1.QBFTConsensusMsg) error {
-	for _, peer := range c.peers {
-		if peer.ID == c.tcpNode.ID() {
+	var peerIDs []peer.ID
+	for _, p := range c.peers {
+		peerIDs = append(peerIDs, p.ID)
+	}
+
+	if c.gossip == nil || !c.gossipEnabled(msg.GetMsg().GetDuty().GetSlot()) {
+		return c.sendAll(ctx, peerIDs, msg)
+	}
+
+	// Publishing via gossip is synchronous, so publish async to not block the instance
+	// and since the context is closed when the instance completes.
+	go func() {
+		ctx := log.CopyFields(context.Background(), ctx)
+
+		// Only peers that don't support gossip are sent the message directly, also if publishing failed.
+		peerIDs, err := c.gossip.Publish(ctx, protocols.QBFTv2GossipProtocolID, msg)
+		if err != nil {
+			log.Warn(ctx, "Failed publishing consensus message via gossip", err, c.publishFilter)
+		}
+
+		if err := c.sendAll(ctx, peerIDs, msg); err != nil {
+			log.Warn(ctx, "Failed sending consensus message", err, c.publishFilter)
+		}
+	}()
+
+	return nil
+}
+
+// sendAll sends the message asynchronously to the peers other than self.
+func (c *Consensus) sendAll(ctx context.Context, peerIDs []peer.ID, msg *pbv1.QBFTConsensusMsg) error {
+	for _, peerID := range peerIDs {
+		if peerID == c.tcpNode.ID() {
 			// Do not broadcast to self
 			continue
 		}
 
-		if err := c.sender.SendAsync(ctx, c.tcpNode, protocols.QBFTv2ProtocolID, peer.ID, msg); err != nil {
+		if err := c.sender.SendAsync(ctx, c.tcpNode, protocols.QBFTv2ProtocolID, peerID, msg,
+			p2p.WithSnappyProtocol(protocols.QBFTv2SnappyProtocolID)); err != nil {
 			return err
 		}
 	}
@@ -384,16 +482,22 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 
 	// Instrument consensus instance.
 	var (
-		decided bool
-		nodes   = len(c.peers)
+		decided    bool
+		leaderFunc = c.leaderFunc(duty.Slot)
 	)
 
 	decideCallback := func(qcommit []qbft.Msg[core.Duty, [32]byte]) {
 		round := qcommit[0].Round()
 		decided = true
-		inst.DecidedAtCh <- time.Now()
+		inst.DecidedAtCh <- c.clock.Now()
+
+		var leaders []int64
+		for r := int64(1); r <= round; r++ {
+			leaders = append(leaders, leaderFunc(duty, r))
+		}
+		c.leaderStats.Decided(leaders)
 
-		leaderIndex := leader(duty, round, nodes)
+		leaderIndex := leaderFunc(duty, round)
 		leaderName := c.peers[leaderIndex].Name
 		log.Debug(ctx, "QBFT consensus decided",
 			z.Str("duty", duty.Type.String()),
@@ -407,7 +511,7 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 	}
 
 	// Create a new qbft definition for this instance.
-	def := newDefinition(len(c.peers), c.subscribers, roundTimer, decideCallback)
+	def := newDefinition(len(c.peers), c.subscribers, roundTimer, leaderFunc, decideCallback)
 
 	// Create a new transport that handles sending and receiving for this instance.
 	t := newTransport(c, c.privkey, inst.ValueCh, make(chan qbft.Msg[core.Duty, [32]byte]), newSniffer(int64(def.Nodes), peerIdx))
@@ -444,7 +548,7 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 
 // handle processes an incoming consensus wire message.
 func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
-	t0 := time.Now()
+	t0 := c.clock.Now()
 
 	pbMsg, ok := req.(*pbv1.QBFTConsensusMsg)
 	if !ok || pbMsg == nil {
@@ -487,10 +591,17 @@ func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 		return nil, false, err
 	}
 
+	if msg.Type() == qbft.MsgPrePrepare {
+		if err := c.validateValue(ctx, duty, values[msg.Value()]); err != nil {
+			c.metrics.IncInvalidValue(duty.Type.String())
+			return nil, false, errors.Wrap(err, "invalid proposed value", z.Any("duty", duty), z.I64("round", msg.Round()))
+		}
+	}
+
 	if ctx.Err() != nil {
 		return nil, false, errors.Wrap(ctx.Err(), "receive cancelled during verification",
 			z.Any("duty", duty),
-			z.Any("after", time.Since(t0)),
+			z.Any("after", c.clock.Since(t0)),
 		)
 	}
 
@@ -503,10 +614,37 @@ func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 		return nil, false, nil
 	case <-ctx.Done():
 		return nil, false, errors.Wrap(ctx.Err(), "timeout enqueuing receive buffer",
-			z.Any("duty", duty), z.Any("after", time.Since(t0)))
+			z.Any("duty", duty), z.Any("after", c.clock.Since(t0)))
 	}
 }
 
+// validateValue returns an error if the proposed value is invalid according to the validator registered for the duty type.
+func (c *Consensus) validateValue(ctx context.Context, duty core.Duty, value *anypb.Any) error {
+	validator, ok := c.validators[duty.Type]
+	if !ok {
+		return nil
+	} else if value == nil {
+		return errors.New("missing proposed value")
+	}
+
+	inner, err := value.UnmarshalNew()
+	if err != nil {
+		return errors.Wrap(err, "unmarshal any")
+	}
+
+	unsignedPB, ok := inner.(*pbv1.UnsignedDataSet)
+	if !ok {
+		return nil // Only unsigned data sets are validated.
+	}
+
+	set, err := core.UnsignedDataSetFromProto(duty.Type, unsignedPB)
+	if err != nil {
+		return err
+	}
+
+	return validator(ctx, duty, set)
+}
+
 // getRecvBuffer returns a receive buffer for the duty.
 func (c *Consensus) getRecvBuffer(duty core.Duty) chan Msg {
 	c.mutable.Lock()
@@ -702,11 +840,6 @@ func fmtStepPeers(step roundStep) string {
 	return strings.Join(resp, "")
 }
 
-// leader return the deterministic leader index.
-func leader(duty core.Duty, round int64, nodes int) int64 {
-	return (int64(duty.Slot) + int64(duty.Type) + round) % int64(nodes)
-}
-
 // valuesByHash returns a map of values by hash.
 func valuesByHash(values []*anypb.Any) (map[[32]byte]*anypb.Any, error) {
 	resp := make(map[[32]byte]*anypb.Any)
@@ -8,12 +8,16 @@ import (
 	"testing"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/metrics"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
 	"github.com/obolnetwork/charon/core/consensus/utils"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	coremocks "github.com/obolnetwork/charon/core/mocks"
@@ -378,6 +382,7 @@ func TestQBFTConsensus_handle(t *testing.T) {
 			tc.deadliner = deadliner
 			tc.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
 			tc.gaterFunc = func(core.Duty) bool { return true }
+			tc.clock = clockwork.NewRealClock()
 
 			msg := &pbv1.QBFTConsensusMsg{
 				Msg: newRandomQBFTMsg(t),
@@ -456,6 +461,7 @@ func TestQBFTConsensusHandle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Consensus{
 				gaterFunc: func(core.Duty) bool { return true },
+				clock:     clockwork.NewRealClock(),
 			}
 
 			_, _, err := c.handle(ctx, "", tt.msg)
@@ -477,6 +483,7 @@ func TestInstanceIO_MaybeStart(t *testing.T) {
 		deadliner.On("Add", mock.Anything).Return(true)
 		c.deadliner = deadliner
 		c.gaterFunc = func(core.Duty) bool { return true }
+		c.clock = clockwork.NewRealClock()
 		c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
 
 		// Generate a p2p private key.
@@ -508,6 +515,7 @@ func TestInstanceIO_MaybeStart(t *testing.T) {
 		deadliner.On("Add", mock.Anything).Return(true)
 		c.deadliner = deadliner
 		c.gaterFunc = func(core.Duty) bool { return true }
+		c.clock = clockwork.NewRealClock()
 		c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
 		c.timerFunc = utils.GetTimerFunc()
 
@@ -539,6 +547,88 @@ func TestInstanceIO_MaybeStart(t *testing.T) {
 	})
 }
 
+func TestQBFTConsensusValidateValue(t *testing.T) {
+	var (
+		duty  = core.NewAttesterDuty(42)
+		valid = testutil.RandomCoreAttestationData(t)
+	)
+
+	newConsensus := func(t *testing.T) (*Consensus, *k1.PrivateKey) {
+		t.Helper()
+
+		deadliner := coremocks.NewDeadliner(t)
+		deadliner.On("Add", mock.Anything).Maybe().Return(true)
+
+		p2pKey := testutil.GenerateInsecureK1Key(t, 0)
+
+		c := &Consensus{
+			deadliner:  deadliner,
+			gaterFunc:  func(core.Duty) bool { return true },
+			clock:      clockwork.NewRealClock(),
+			pubkeys:    map[int64]*k1.PublicKey{0: p2pKey.PubKey()},
+			metrics:    metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
+			validators: make(map[core.DutyType]ValidateValueFunc),
+		}
+		c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
+
+		c.RegisterValidateValue(core.DutyAttester, func(_ context.Context, _ core.Duty, set core.UnsignedDataSet) error {
+			for _, data := range set {
+				if data.(core.AttestationData).Data.Slot != valid.Data.Slot {
+					return errors.New("attestation data slot mismatch")
+				}
+			}
+
+			return nil
+		})
+
+		return c, p2pKey
+	}
+
+	newPrePrepare := func(t *testing.T, p2pKey *k1.PrivateKey, data core.AttestationData) *pbv1.QBFTConsensusMsg {
+		t.Helper()
+
+		set, err := core.UnsignedDataSetToProto(core.UnsignedDataSet{testutil.RandomCorePubKey(t): data})
+		require.NoError(t, err)
+
+		hash, err := hashProto(set)
+		require.NoError(t, err)
+
+		value, err := anypb.New(set)
+		require.NoError(t, err)
+
+		msg := &pbv1.QBFTConsensusMsg{
+			Msg: &pbv1.QBFTMsg{
+				Type:      int64(qbft.MsgPrePrepare),
+				Duty:      core.DutyToProto(duty),
+				Round:     1,
+				ValueHash: hash[:],
+			},
+			Values: []*anypb.Any{value},
+		}
+
+		return signConsensusMsg(t, msg, p2pKey, duty)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		c, p2pKey := newConsensus(t)
+
+		_, _, err := c.handle(context.Background(), "peerID", newPrePrepare(t, p2pKey, valid))
+		require.NoError(t, err)
+		require.Len(t, c.getRecvBuffer(duty), 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c, p2pKey := newConsensus(t)
+
+		invalid := valid
+		invalid.Data.Slot++
+
+		_, _, err := c.handle(context.Background(), "peerID", newPrePrepare(t, p2pKey, invalid))
+		require.ErrorContains(t, err, "invalid proposed value: attestation data slot mismatch")
+		require.Empty(t, c.getRecvBuffer(duty))
+	})
+}
+
 func signConsensusMsg(t *testing.T, msg *pbv1.QBFTConsensusMsg, privKey *k1.PrivateKey, duty core.Duty) *pbv1.QBFTConsensusMsg {
 	t.Helper()
 
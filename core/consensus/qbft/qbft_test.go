@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p"
 	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -21,8 +22,10 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
 	"github.com/obolnetwork/charon/core/consensus/qbft"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/gossip"
 	coremocks "github.com/obolnetwork/charon/core/mocks"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
@@ -34,6 +37,7 @@ func TestQBFTConsensus(t *testing.T) {
 		name      string
 		threshold int
 		nodes     int
+		gossip    bool
 	}{
 		{
 			name:      "2-of-3",
@@ -55,18 +59,31 @@ func TestQBFTConsensus(t *testing.T) {
 			threshold: 4,
 			nodes:     6,
 		},
+		{
+			name:      "3-of-4 gossip",
+			threshold: 3,
+			nodes:     4,
+			gossip:    true,
+		},
+		{
+			name:      "4-of-6 gossip",
+			threshold: 4,
+			nodes:     6,
+			gossip:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testQBFTConsensus(t, tt.threshold, tt.nodes)
+			testQBFTConsensus(t, tt.threshold, tt.nodes, tt.gossip)
 		})
 	}
 }
 
 // testQBFTConsensus tests a consensus instance with size of threshold-of-nodes.
-// Note it only instantiates the minimum amount of peers, ie threshold.
-func testQBFTConsensus(t *testing.T, threshold, nodes int) {
+// Note it only instantiates the minimum amount of peers, ie threshold, the other peers are down.
+// If gossip is enabled, all peers are assumed to support gossip, so down peers may be selected as relays.
+func testQBFTConsensus(t *testing.T, threshold, nodes int, enableGossip bool) {
 	t.Helper()
 	seed := 0
 	random := rand.New(rand.NewSource(int64(seed)))
@@ -84,7 +101,18 @@ func testQBFTConsensus(t *testing.T, threshold, nodes int) {
 	)
 	defer cancel()
 
-	// Create hosts and enrs (ony for threshold).
+	// Create peers for all nodes.
+	for i := range nodes {
+		record, err := enr.Parse(lock.Operators[i].ENR)
+		require.NoError(t, err)
+
+		p, err := p2p.NewPeerFromENR(record, i)
+		require.NoError(t, err)
+
+		peers = append(peers, p)
+	}
+
+	// Create hosts (ony for threshold).
 	for i := range threshold {
 		addr := testutil.AvailableAddr(t)
 		mAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", addr.IP, addr.Port))
@@ -95,14 +123,7 @@ func testQBFTConsensus(t *testing.T, threshold, nodes int) {
 		testutil.SkipIfBindErr(t, err)
 		require.NoError(t, err)
 
-		record, err := enr.Parse(lock.Operators[i].ENR)
-		require.NoError(t, err)
-
-		p, err := p2p.NewPeerFromENR(record, i)
-		require.NoError(t, err)
-
 		hostsInfo = append(hostsInfo, peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
-		peers = append(peers, p)
 		hosts = append(hosts, h)
 	}
 
@@ -124,8 +145,20 @@ func testQBFTConsensus(t *testing.T, threshold, nodes int) {
 		deadliner := coremocks.NewDeadliner(t)
 		deadliner.On("Add", mock.Anything).Return(true)
 		deadliner.On("C").Return(nil)
-		c, err := qbft.NewConsensus(hosts[i], new(p2p.Sender), peers, p2pkeys[i], deadliner, gaterFunc, sniffer)
+		c, err := qbft.NewConsensus(hosts[i], new(p2p.Sender), peers, p2pkeys[i], deadliner, gaterFunc, sniffer, clockwork.NewRealClock())
 		require.NoError(t, err)
+		if enableGossip {
+			var peerIDs []peer.ID
+			for _, p := range peers {
+				peerIDs = append(peerIDs, p.ID)
+				if p.ID != hosts[i].ID() {
+					require.NoError(t, hosts[i].Peerstore().AddProtocols(p.ID, protocols.QBFTv2GossipProtocolID))
+				}
+			}
+
+			g := gossip.New(hosts[i], peerIDs, p2p.Send, p2pkeys[i], gossip.WithRelays(1))
+			c.EnableGossip(g, func(uint64) bool { return true })
+		}
 		c.Subscribe(func(_ context.Context, _ core.Duty, set core.UnsignedDataSet) error {
 			results <- set
 			return nil
@@ -74,6 +74,55 @@ func TestSimulatorOnce(t *testing.T) {
 	require.False(t, isUndecided(results))
 }
 
+func TestSimulatorReliableLeaders(t *testing.T) {
+	const (
+		slots   = 8
+		offline = 3
+	)
+
+	// simulate returns the average duration and max round to decide all slots.
+	simulate := func(unreliable []int64) (time.Duration, int) {
+		var (
+			total    time.Duration
+			maxRound int
+		)
+		for slot := range slots {
+			var buf zaptest.Buffer
+			results := testStrategySimulator(t, ssConfig{
+				seed:          slot,
+				latencyStdDev: ms010,
+				latencyPerPeer: map[int64]time.Duration{
+					0: ms050,
+					1: ms050,
+					2: ms050,
+					3: ms050,
+				},
+				startByPeer:    map[int64]time.Duration{offline: disabled},
+				roundTimerFunc: newInc,
+				unreliable:     unreliable,
+				timeout:        ms5000,
+			}, &buf)
+			require.False(t, isUndecided(results))
+
+			total += quorumDecidedDuration(results)
+			maxRound = max(maxRound, decidedRound(results))
+		}
+
+		return total / slots, maxRound
+	}
+
+	roundRobinDuration, roundRobinRound := simulate(nil)
+	reliableDuration, reliableRound := simulate([]int64{offline})
+
+	t.Logf("round-robin leaders: avg duration=%v max round=%d", roundRobinDuration, roundRobinRound)
+	t.Logf("reliable leaders: avg duration=%v max round=%d", reliableDuration, reliableRound)
+
+	// The offline peer leads the first round of some slots using round-robin leaders, never using reliable leaders.
+	require.Greater(t, roundRobinRound, 1)
+	require.Equal(t, 1, reliableRound)
+	require.Less(t, reliableDuration, roundRobinDuration)
+}
+
 func TestMatrix(t *testing.T) {
 	t.Skip("Skip matrix test") // Comment this to run the test.
 
@@ -325,6 +374,7 @@ type ssConfig struct {
 	latencyPerPeer map[int64]time.Duration
 	startByPeer    map[int64]time.Duration
 	roundTimerFunc func(clockwork.Clock) utils.RoundTimer
+	unreliable     []int64
 	timeout        time.Duration
 }
 
@@ -357,6 +407,7 @@ func testStrategySimulator(t *testing.T, conf ssConfig, syncer zapcore.WriteSync
 		def := newSimDefinition(
 			len(conf.latencyPerPeer),
 			conf.roundTimerFunc(clock),
+			conf.unreliable,
 			func(qcommit []qbft.Msg[core.Duty, [32]byte]) {
 				res = result{
 					PeerIdx:  p.Idx,
@@ -383,6 +434,8 @@ func testStrategySimulator(t *testing.T, conf ssConfig, syncer zapcore.WriteSync
 		delay := conf.startByPeer[p.Idx]
 		if delay == disabled { // If peer disabled, return immediately
 			log.Debug(ctx, "Peer disabled")
+			done()
+
 			return res, nil
 		} else if conf.roundTimerFunc(nil).Type().Eager() { // If timer is eager, delay value asynchronously
 			go after(ctx, clock, delay, enqueueValue)
@@ -458,10 +511,14 @@ func gosched() {
 	}
 }
 
-func newSimDefinition(nodes int, roundTimer utils.RoundTimer,
+func newSimDefinition(nodes int, roundTimer utils.RoundTimer, unreliable []int64,
 	decideCallback func(qcommit []qbft.Msg[core.Duty, [32]byte]),
 ) qbft.Definition[core.Duty, [32]byte] {
 	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
+	leader := func(duty core.Duty, round int64, nodes int) int64 {
+		return reliableLeader(duty, round, nodes, unreliable)
+	}
+
 	return qbft.Definition[core.Duty, [32]byte]{
 		IsLeader: func(duty core.Duty, round, process int64) bool {
 			return leader(duty, round, nodes) == process
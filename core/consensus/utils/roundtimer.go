@@ -24,15 +24,20 @@ type TimerFunc func(core.Duty) RoundTimer
 
 // GetTimerFunc returns a timer function based on the enabled features.
 func GetTimerFunc() TimerFunc {
+	return GetTimerFuncWithClock(clockwork.NewRealClock())
+}
+
+// GetTimerFuncWithClock returns a timer function based on the enabled features with a custom clock.
+func GetTimerFuncWithClock(clock clockwork.Clock) TimerFunc {
 	if featureset.Enabled(featureset.EagerDoubleLinear) {
 		return func(core.Duty) RoundTimer {
-			return NewDoubleEagerLinearRoundTimer()
+			return NewDoubleEagerLinearRoundTimerWithClock(clock)
 		}
 	}
 
 	// Default to increasing round timer.
 	return func(core.Duty) RoundTimer {
-		return NewIncreasingRoundTimer()
+		return NewIncreasingRoundTimerWithClock(clock)
 	}
 }
 
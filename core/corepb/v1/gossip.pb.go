@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: core/corepb/v1/gossip.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GossipMsg defines a message published to a gossip topic and relayed between cluster peers.
+type GossipMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Origin    []byte     `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"` // Peer ID of the publisher.
+	Data      *anypb.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Targets   [][]byte   `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`     // Peer IDs the receiver must relay the message to.
+	Signature []byte     `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"` // Signature of the topic and data by the origin peer.
+}
+
+func (x *GossipMsg) Reset() {
+	*x = GossipMsg{}
+	mi := &file_core_corepb_v1_gossip_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipMsg) ProtoMessage() {}
+
+func (x *GossipMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_gossip_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipMsg.ProtoReflect.Descriptor instead.
+func (*GossipMsg) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_gossip_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GossipMsg) GetOrigin() []byte {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *GossipMsg) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GossipMsg) GetTargets() [][]byte {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *GossipMsg) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_core_corepb_v1_gossip_proto protoreflect.FileDescriptor
+
+var file_core_corepb_v1_gossip_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61,
+	0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x47, 0x6f, 0x73,
+	0x73, 0x69, 0x70, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x28,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
+	0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_core_corepb_v1_gossip_proto_rawDescOnce sync.Once
+	file_core_corepb_v1_gossip_proto_rawDescData = file_core_corepb_v1_gossip_proto_rawDesc
+)
+
+func file_core_corepb_v1_gossip_proto_rawDescGZIP() []byte {
+	file_core_corepb_v1_gossip_proto_rawDescOnce.Do(func() {
+		file_core_corepb_v1_gossip_proto_rawDescData = protoimpl.X.CompressGZIP(file_core_corepb_v1_gossip_proto_rawDescData)
+	})
+	return file_core_corepb_v1_gossip_proto_rawDescData
+}
+
+var file_core_corepb_v1_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_corepb_v1_gossip_proto_goTypes = []any{
+	(*GossipMsg)(nil), // 0: core.corepb.v1.GossipMsg
+	(*anypb.Any)(nil), // 1: google.protobuf.Any
+}
+var file_core_corepb_v1_gossip_proto_depIdxs = []int32{
+	1, // 0: core.corepb.v1.GossipMsg.data:type_name -> google.protobuf.Any
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_core_corepb_v1_gossip_proto_init() }
+func file_core_corepb_v1_gossip_proto_init() {
+	if File_core_corepb_v1_gossip_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_core_corepb_v1_gossip_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_corepb_v1_gossip_proto_goTypes,
+		DependencyIndexes: file_core_corepb_v1_gossip_proto_depIdxs,
+		MessageInfos:      file_core_corepb_v1_gossip_proto_msgTypes,
+	}.Build()
+	File_core_corepb_v1_gossip_proto = out.File
+	file_core_corepb_v1_gossip_proto_rawDesc = nil
+	file_core_corepb_v1_gossip_proto_goTypes = nil
+	file_core_corepb_v1_gossip_proto_depIdxs = nil
+}
@@ -73,6 +73,51 @@ func (x *ParSigExMsg) GetDataSet() *ParSignedDataSet {
 	return nil
 }
 
+type ParSigExBatchMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages []*ParSigExMsg `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *ParSigExBatchMsg) Reset() {
+	*x = ParSigExBatchMsg{}
+	mi := &file_core_corepb_v1_parsigex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParSigExBatchMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParSigExBatchMsg) ProtoMessage() {}
+
+func (x *ParSigExBatchMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_parsigex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParSigExBatchMsg.ProtoReflect.Descriptor instead.
+func (*ParSigExBatchMsg) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_parsigex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParSigExBatchMsg) GetMessages() []*ParSigExMsg {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 var File_core_corepb_v1_parsigex_proto protoreflect.FileDescriptor
 
 var file_core_corepb_v1_parsigex_proto_rawDesc = []byte{
@@ -88,10 +133,15 @@ var file_core_corepb_v1_parsigex_proto_rawDesc = []byte{
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72,
 	0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
 	0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74,
-	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
-	0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f,
-	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x4b, 0x0a, 0x10, 0x50, 0x61, 0x72, 0x53, 0x69, 0x67, 0x45, 0x78, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x4d, 0x73, 0x67, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x53, 0x69, 0x67, 0x45, 0x78,
+	0x4d, 0x73, 0x67, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x42, 0x2e, 0x5a,
+	0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -106,20 +156,22 @@ func file_core_corepb_v1_parsigex_proto_rawDescGZIP() []byte {
 	return file_core_corepb_v1_parsigex_proto_rawDescData
 }
 
-var file_core_corepb_v1_parsigex_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_corepb_v1_parsigex_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_core_corepb_v1_parsigex_proto_goTypes = []any{
 	(*ParSigExMsg)(nil),      // 0: core.corepb.v1.ParSigExMsg
-	(*Duty)(nil),             // 1: core.corepb.v1.Duty
-	(*ParSignedDataSet)(nil), // 2: core.corepb.v1.ParSignedDataSet
+	(*ParSigExBatchMsg)(nil), // 1: core.corepb.v1.ParSigExBatchMsg
+	(*Duty)(nil),             // 2: core.corepb.v1.Duty
+	(*ParSignedDataSet)(nil), // 3: core.corepb.v1.ParSignedDataSet
 }
 var file_core_corepb_v1_parsigex_proto_depIdxs = []int32{
-	1, // 0: core.corepb.v1.ParSigExMsg.duty:type_name -> core.corepb.v1.Duty
-	2, // 1: core.corepb.v1.ParSigExMsg.data_set:type_name -> core.corepb.v1.ParSignedDataSet
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: core.corepb.v1.ParSigExMsg.duty:type_name -> core.corepb.v1.Duty
+	3, // 1: core.corepb.v1.ParSigExMsg.data_set:type_name -> core.corepb.v1.ParSignedDataSet
+	0, // 2: core.corepb.v1.ParSigExBatchMsg.messages:type_name -> core.corepb.v1.ParSigExMsg
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_core_corepb_v1_parsigex_proto_init() }
@@ -134,7 +186,7 @@ func file_core_corepb_v1_parsigex_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_corepb_v1_parsigex_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
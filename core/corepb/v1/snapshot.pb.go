@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: core/corepb/v1/snapshot.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SignedDataSnapshot defines a snapshot of a signature database persisted across restarts.
+type SignedDataSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*SignedDataEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *SignedDataSnapshot) Reset() {
+	*x = SignedDataSnapshot{}
+	mi := &file_core_corepb_v1_snapshot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedDataSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedDataSnapshot) ProtoMessage() {}
+
+func (x *SignedDataSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_snapshot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedDataSnapshot.ProtoReflect.Descriptor instead.
+func (*SignedDataSnapshot) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_snapshot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignedDataSnapshot) GetEntries() []*SignedDataEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// SignedDataEntry defines the signed data of a duty and validator.
+// Aggregate signed data is stored as a single partial signed data with a zero share index.
+type SignedDataEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duty   *Duty            `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	PubKey string           `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"` // core.PubKey
+	Data   []*ParSignedData `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SignedDataEntry) Reset() {
+	*x = SignedDataEntry{}
+	mi := &file_core_corepb_v1_snapshot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedDataEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedDataEntry) ProtoMessage() {}
+
+func (x *SignedDataEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_snapshot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedDataEntry.ProtoReflect.Descriptor instead.
+func (*SignedDataEntry) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_snapshot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignedDataEntry) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *SignedDataEntry) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *SignedDataEntry) GetData() []*ParSignedData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_core_corepb_v1_snapshot_proto protoreflect.FileDescriptor
+
+var file_core_corepb_v1_snapshot_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a,
+	0x19, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x12, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x39, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x0f,
+	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x28, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x75, 0x74, 0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x75, 0x62,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f,
+	0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_core_corepb_v1_snapshot_proto_rawDescOnce sync.Once
+	file_core_corepb_v1_snapshot_proto_rawDescData = file_core_corepb_v1_snapshot_proto_rawDesc
+)
+
+func file_core_corepb_v1_snapshot_proto_rawDescGZIP() []byte {
+	file_core_corepb_v1_snapshot_proto_rawDescOnce.Do(func() {
+		file_core_corepb_v1_snapshot_proto_rawDescData = protoimpl.X.CompressGZIP(file_core_corepb_v1_snapshot_proto_rawDescData)
+	})
+	return file_core_corepb_v1_snapshot_proto_rawDescData
+}
+
+var file_core_corepb_v1_snapshot_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_core_corepb_v1_snapshot_proto_goTypes = []any{
+	(*SignedDataSnapshot)(nil), // 0: core.corepb.v1.SignedDataSnapshot
+	(*SignedDataEntry)(nil),    // 1: core.corepb.v1.SignedDataEntry
+	(*Duty)(nil),               // 2: core.corepb.v1.Duty
+	(*ParSignedData)(nil),      // 3: core.corepb.v1.ParSignedData
+}
+var file_core_corepb_v1_snapshot_proto_depIdxs = []int32{
+	1, // 0: core.corepb.v1.SignedDataSnapshot.entries:type_name -> core.corepb.v1.SignedDataEntry
+	2, // 1: core.corepb.v1.SignedDataEntry.duty:type_name -> core.corepb.v1.Duty
+	3, // 2: core.corepb.v1.SignedDataEntry.data:type_name -> core.corepb.v1.ParSignedData
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_core_corepb_v1_snapshot_proto_init() }
+func file_core_corepb_v1_snapshot_proto_init() {
+	if File_core_corepb_v1_snapshot_proto != nil {
+		return
+	}
+	file_core_corepb_v1_core_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_core_corepb_v1_snapshot_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_corepb_v1_snapshot_proto_goTypes,
+		DependencyIndexes: file_core_corepb_v1_snapshot_proto_depIdxs,
+		MessageInfos:      file_core_corepb_v1_snapshot_proto_msgTypes,
+	}.Build()
+	File_core_corepb_v1_snapshot_proto = out.File
+	file_core_corepb_v1_snapshot_proto_rawDesc = nil
+	file_core_corepb_v1_snapshot_proto_goTypes = nil
+	file_core_corepb_v1_snapshot_proto_depIdxs = nil
+}
@@ -0,0 +1,270 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: core/corepb/v1/statesync.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StateSyncRequest requests the recent state of a peer, typically by a restarted node.
+type StateSyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSlot uint64 `protobuf:"varint,1,opt,name=from_slot,json=fromSlot,proto3" json:"from_slot,omitempty"` // Only state of duties from this slot is returned.
+}
+
+func (x *StateSyncRequest) Reset() {
+	*x = StateSyncRequest{}
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateSyncRequest) ProtoMessage() {}
+
+func (x *StateSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateSyncRequest.ProtoReflect.Descriptor instead.
+func (*StateSyncRequest) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_statesync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StateSyncRequest) GetFromSlot() uint64 {
+	if x != nil {
+		return x.FromSlot
+	}
+	return 0
+}
+
+// StateSyncResponse defines the recent state of a peer.
+type StateSyncResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Aggregates *SignedDataSnapshot `protobuf:"bytes,1,opt,name=aggregates,proto3" json:"aggregates,omitempty"` // Aggregate signed data.
+	Decided    []*DecidedValue     `protobuf:"bytes,2,rep,name=decided,proto3" json:"decided,omitempty"`       // Decided consensus values.
+}
+
+func (x *StateSyncResponse) Reset() {
+	*x = StateSyncResponse{}
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateSyncResponse) ProtoMessage() {}
+
+func (x *StateSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateSyncResponse.ProtoReflect.Descriptor instead.
+func (*StateSyncResponse) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_statesync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StateSyncResponse) GetAggregates() *SignedDataSnapshot {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *StateSyncResponse) GetDecided() []*DecidedValue {
+	if x != nil {
+		return x.Decided
+	}
+	return nil
+}
+
+// DecidedValue defines the value decided by consensus for a duty.
+type DecidedValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duty    *Duty            `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	DataSet *UnsignedDataSet `protobuf:"bytes,2,opt,name=data_set,json=dataSet,proto3" json:"data_set,omitempty"`
+}
+
+func (x *DecidedValue) Reset() {
+	*x = DecidedValue{}
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecidedValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecidedValue) ProtoMessage() {}
+
+func (x *DecidedValue) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_statesync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecidedValue.ProtoReflect.Descriptor instead.
+func (*DecidedValue) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_statesync_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DecidedValue) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *DecidedValue) GetDataSet() *UnsignedDataSet {
+	if x != nil {
+		return x.DataSet
+	}
+	return nil
+}
+
+var File_core_corepb_v1_statesync_proto protoreflect.FileDescriptor
+
+var file_core_corepb_v1_statesync_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x1a, 0x19, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1d, 0x63, 0x6f, 0x72,
+	0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2f, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6c, 0x6f, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x11,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x42, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x07, 0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x22, 0x74, 0x0a,
+	0x0c, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a,
+	0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x74,
+	0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x53, 0x65, 0x74, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68,
+	0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_core_corepb_v1_statesync_proto_rawDescOnce sync.Once
+	file_core_corepb_v1_statesync_proto_rawDescData = file_core_corepb_v1_statesync_proto_rawDesc
+)
+
+func file_core_corepb_v1_statesync_proto_rawDescGZIP() []byte {
+	file_core_corepb_v1_statesync_proto_rawDescOnce.Do(func() {
+		file_core_corepb_v1_statesync_proto_rawDescData = protoimpl.X.CompressGZIP(file_core_corepb_v1_statesync_proto_rawDescData)
+	})
+	return file_core_corepb_v1_statesync_proto_rawDescData
+}
+
+var file_core_corepb_v1_statesync_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_core_corepb_v1_statesync_proto_goTypes = []any{
+	(*StateSyncRequest)(nil),   // 0: core.corepb.v1.StateSyncRequest
+	(*StateSyncResponse)(nil),  // 1: core.corepb.v1.StateSyncResponse
+	(*DecidedValue)(nil),       // 2: core.corepb.v1.DecidedValue
+	(*SignedDataSnapshot)(nil), // 3: core.corepb.v1.SignedDataSnapshot
+	(*Duty)(nil),               // 4: core.corepb.v1.Duty
+	(*UnsignedDataSet)(nil),    // 5: core.corepb.v1.UnsignedDataSet
+}
+var file_core_corepb_v1_statesync_proto_depIdxs = []int32{
+	3, // 0: core.corepb.v1.StateSyncResponse.aggregates:type_name -> core.corepb.v1.SignedDataSnapshot
+	2, // 1: core.corepb.v1.StateSyncResponse.decided:type_name -> core.corepb.v1.DecidedValue
+	4, // 2: core.corepb.v1.DecidedValue.duty:type_name -> core.corepb.v1.Duty
+	5, // 3: core.corepb.v1.DecidedValue.data_set:type_name -> core.corepb.v1.UnsignedDataSet
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_core_corepb_v1_statesync_proto_init() }
+func file_core_corepb_v1_statesync_proto_init() {
+	if File_core_corepb_v1_statesync_proto != nil {
+		return
+	}
+	file_core_corepb_v1_core_proto_init()
+	file_core_corepb_v1_snapshot_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_core_corepb_v1_statesync_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_corepb_v1_statesync_proto_goTypes,
+		DependencyIndexes: file_core_corepb_v1_statesync_proto_depIdxs,
+		MessageInfos:      file_core_corepb_v1_statesync_proto_msgTypes,
+	}.Build()
+	File_core_corepb_v1_statesync_proto = out.File
+	file_core_corepb_v1_statesync_proto_rawDesc = nil
+	file_core_corepb_v1_statesync_proto_goTypes = nil
+	file_core_corepb_v1_statesync_proto_depIdxs = nil
+}
@@ -72,6 +72,11 @@ func NewDeadliner(ctx context.Context, label string, deadlineFunc DeadlineFunc)
 	return newDeadliner(ctx, label, deadlineFunc, clockwork.NewRealClock())
 }
 
+// NewDeadlinerWithClock returns a new instance of Deadline using the provided clock.
+func NewDeadlinerWithClock(ctx context.Context, label string, deadlineFunc DeadlineFunc, clock clockwork.Clock) Deadliner {
+	return newDeadliner(ctx, label, deadlineFunc, clock)
+}
+
 // newDeadliner returns a new Deadliner, this is for internal use only.
 func newDeadliner(ctx context.Context, label string, deadlineFunc DeadlineFunc, clock clockwork.Clock) Deadliner {
 	// outputBuffer big enough to support all duty types, which can expire at the same time
@@ -7,7 +7,10 @@ import (
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/tracer"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
@@ -41,6 +44,63 @@ func VerifyEth2SignedData(ctx context.Context, eth2Cl eth2wrap.Client, data Eth2
 	return signing.Verify(ctx, eth2Cl, data.DomainName(), epoch, sigRoot, data.Signature().ToETH2(), pubkey)
 }
 
+// BatchVerifyEth2SignedData verifies the signatures of the Eth2SignedData by validator using the respective public keys.
+// All signatures are batch verified at once, falling back to verifying them individually to identify the invalid one.
+func BatchVerifyEth2SignedData(ctx context.Context, eth2Cl eth2wrap.Client, datas map[PubKey]Eth2SignedData, pubkeys map[PubKey]tbls.PublicKey) error {
+	ctx, span := tracer.Start(ctx, "core.BatchVerifyEth2SignedData")
+	defer span.End()
+
+	var (
+		keys []PubKey
+		sets []tbls.VerifySet
+	)
+	for key, data := range datas {
+		pubkey, ok := pubkeys[key]
+		if !ok {
+			return errors.New("missing public key", z.Any("pubkey", key))
+		}
+
+		epoch, err := data.Epoch(ctx, eth2Cl)
+		if err != nil {
+			return err
+		}
+
+		sigRoot, err := data.MessageRoot()
+		if err != nil {
+			return err
+		}
+
+		set, err := signing.NewVerifySet(ctx, eth2Cl, data.DomainName(), epoch, sigRoot, data.Signature().ToETH2(), pubkey)
+		if err != nil {
+			return errors.Wrap(err, "signature set", z.Any("pubkey", key))
+		}
+
+		keys = append(keys, key)
+		sets = append(sets, set)
+	}
+
+	if len(sets) == 0 {
+		return nil
+	}
+
+	span.AddEvent("tbls.BatchVerify")
+
+	batchErr := tbls.BatchVerify(sets)
+	if batchErr == nil {
+		return nil
+	}
+
+	span.AddEvent("tbls.Verify")
+
+	for i, set := range sets {
+		if err := tbls.Verify(set.PublicKey, set.Data, set.Signature); err != nil {
+			return errors.Wrap(err, "invalid signature", z.Any("pubkey", keys[i]))
+		}
+	}
+
+	return batchErr
+}
+
 // Implement Eth2SignedData for VersionedSignedProposal.
 
 func (VersionedSignedProposal) DomainName() signing.DomainName {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -21,23 +22,57 @@ import (
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 )
 
+// Option configures a Fetcher.
+type Option func(*Fetcher)
+
+// WithBuilderBoostFactor returns an option that configures the builder_boost_factor
+// passed to the beacon node when builder API is enabled. It defaults to math.MaxUint64
+// which always prefers builder blocks, 100 compares builder and local block values equally.
+func WithBuilderBoostFactor(factor uint64) Option {
+	return func(f *Fetcher) {
+		f.builderBoostFactor = factor
+	}
+}
+
+// WithMinBuilderBid returns an option that configures the minimum builder bid value in wei.
+// Builder proposals with a lower execution value are replaced by locally built proposals.
+func WithMinBuilderBid(wei *big.Int) Option {
+	return func(f *Fetcher) {
+		f.minBuilderBid = wei
+	}
+}
+
 // New returns a new fetcher instance.
-func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderEnabled bool) (*Fetcher, error) {
-	return &Fetcher{
-		eth2Cl:           eth2Cl,
-		feeRecipientFunc: feeRecipientFunc,
-		builderEnabled:   builderEnabled,
-	}, nil
+func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderEnabled bool, opts ...Option) (*Fetcher, error) {
+	f := &Fetcher{
+		eth2Cl:             eth2Cl,
+		feeRecipientFunc:   feeRecipientFunc,
+		builderEnabled:     builderEnabled,
+		builderBoostFactor: math.MaxUint64,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if f.minBuilderBid != nil && f.minBuilderBid.Sign() < 0 {
+		return nil, errors.New("negative minimum builder bid")
+	}
+
+	return f, nil
 }
 
 // Fetcher fetches proposed duty data.
 type Fetcher struct {
-	eth2Cl           eth2wrap.Client
-	feeRecipientFunc func(core.PubKey) string
-	subs             []func(context.Context, core.Duty, core.UnsignedDataSet) error
-	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
-	awaitAttDataFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
-	builderEnabled   bool
+	eth2Cl             eth2wrap.Client
+	feeRecipientFunc   func(core.PubKey) string
+	subs               []func(context.Context, core.Duty, core.UnsignedDataSet) error
+	aggSigDBFunc       func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
+	awaitAttDataFunc   func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
+	manifestAgreed     func(slot uint64) bool
+	builderEnabled     bool
+	builderBoostFactor uint64
+	minBuilderBid      *big.Int
 }
 
 // Subscribe registers a callback for fetched duties.
@@ -110,6 +145,13 @@ func (f *Fetcher) RegisterAwaitAttData(fn func(ctx context.Context, slot uint64,
 	f.awaitAttDataFunc = fn
 }
 
+// RegisterManifestAgreed registers a function returning true if the cluster agrees on the local cluster manifest
+// at the slot, in which case proposals with unexpected fee recipients are rejected instead of only logged.
+// Note: This is not thread safe and should only be called *before* ValidateValue.
+func (f *Fetcher) RegisterManifestAgreed(fn func(slot uint64) bool) {
+	f.manifestAgreed = fn
+}
+
 // fetchAttesterData returns the fetched attestation data set for committees and validators in the arg set.
 func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet,
 ) (core.UnsignedDataSet, error) {
@@ -253,9 +295,9 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 
 		var bbf uint64
 		if f.builderEnabled {
-			// This gives maximum priority to builder blocks:
+			// The default of math.MaxUint64 gives maximum priority to builder blocks:
 			// https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV3
-			bbf = math.MaxUint64
+			bbf = f.builderBoostFactor
 		}
 
 		opts := &eth2api.ProposalOpts{
@@ -270,8 +312,29 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 		}
 		proposal := eth2Resp.Data
 
+		if belowMinBid(proposal, f.minBuilderBid) {
+			log.Info(ctx, "Builder bid below minimum, falling back to local block",
+				z.Any("pubkey", pubkey), z.Str("bid_wei", proposal.ExecutionValue.String()),
+				z.Str("min_bid_wei", f.minBuilderBid.String()))
+
+			var local uint64 // Zero builder boost factor always selects the local block.
+			opts.BuilderBoostFactor = &local
+
+			eth2Resp, err = f.eth2Cl.Proposal(ctx, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "fetch local fallback proposal")
+			}
+			proposal = eth2Resp.Data
+
+			localFallbackCounter.WithLabelValues(pubkey.String()).Inc()
+		}
+
+		instrumentProposal(pubkey, proposal)
+
 		// Ensure fee recipient is correctly populated in proposal.
-		verifyFeeRecipient(ctx, proposal, f.feeRecipientFunc(pubkey))
+		if err := verifyFeeRecipient(proposal, f.feeRecipientFunc(pubkey)); err != nil {
+			log.Warn(ctx, "Proposal with unexpected fee recipient address", err)
+		}
 
 		coreProposal, err := core.NewVersionedProposal(proposal)
 		if err != nil {
@@ -350,8 +413,18 @@ func (f *Fetcher) fetchContributionData(ctx context.Context, slot uint64, defSet
 	return resp, nil
 }
 
-// verifyFeeRecipient logs a warning when fee recipient is not correctly populated in the block.
-func verifyFeeRecipient(ctx context.Context, proposal *eth2api.VersionedProposal, feeRecipientAddress string) {
+// belowMinBid returns true if the proposal is a builder proposal with an execution value below the provided minimum.
+// Proposals without an execution value are assumed to be above the minimum since it cannot be compared.
+func belowMinBid(proposal *eth2api.VersionedProposal, minBid *big.Int) bool {
+	if minBid == nil || minBid.Sign() == 0 || !proposal.Blinded || proposal.ExecutionValue == nil {
+		return false
+	}
+
+	return proposal.ExecutionValue.Cmp(minBid) < 0
+}
+
+// verifyFeeRecipient returns an error when fee recipient is not correctly populated in the block.
+func verifyFeeRecipient(proposal *eth2api.VersionedProposal, feeRecipientAddress string) error {
 	// Note that fee-recipient is not available in forks earlier than bellatrix.
 	var actualAddr string
 
@@ -375,11 +448,13 @@ func verifyFeeRecipient(ctx context.Context, proposal *eth2api.VersionedProposal
 			actualAddr = fmt.Sprintf("%#x", proposal.Deneb.Block.Body.ExecutionPayload.FeeRecipient)
 		}
 	default:
-		return
+		return nil
 	}
 
 	if actualAddr != "" && !strings.EqualFold(actualAddr, feeRecipientAddress) {
-		log.Warn(ctx, "Proposal with unexpected fee recipient address", nil,
+		return errors.New("unexpected fee recipient address",
 			z.Str("expected", feeRecipientAddress), z.Str("actual", actualAddr))
 	}
+
+	return nil
 }
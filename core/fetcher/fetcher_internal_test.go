@@ -3,15 +3,12 @@
 package fetcher
 
 import (
-	"context"
 	"testing"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap/zaptest"
 
-	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/testutil"
 )
 
@@ -71,14 +68,10 @@ func TestVerifyFeeRecipient(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			var buf zaptest.Buffer
-			log.InitLogfmtForT(t, &buf)
+			require.NoError(t, verifyFeeRecipient(&test.proposal, "0x0000000000000000000000000000000000000000"))
 
-			verifyFeeRecipient(context.Background(), &test.proposal, "0x0000000000000000000000000000000000000000")
-			require.Empty(t, buf.String())
-
-			verifyFeeRecipient(context.Background(), &test.proposal, "0xdead")
-			require.Contains(t, buf.String(), "Proposal with unexpected fee recipient address")
+			err := verifyFeeRecipient(&test.proposal, "0xdead")
+			require.ErrorContains(t, err, "unexpected fee recipient address")
 		})
 	}
 }
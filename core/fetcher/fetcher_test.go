@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -313,6 +314,56 @@ func TestFetchBlocks(t *testing.T) {
 	})
 }
 
+func TestFetchBlocksMinBid(t *testing.T) {
+	ctx := context.Background()
+
+	const slot = 1
+	pubkey := testutil.RandomCorePubKey(t)
+	defSet := core.DutyDefinitionSet{
+		pubkey: core.NewProposerDefinition(&eth2v1.ProposerDuty{Slot: slot}),
+	}
+
+	// Beaconmock returns builder proposals with an execution value of 1 wei.
+	tests := []struct {
+		name        string
+		minBid      int64
+		wantBlinded bool
+	}{
+		{name: "no min bid", minBid: 0, wantBlinded: true},
+		{name: "bid above min", minBid: 1, wantBlinded: true},
+		{name: "bid below min", minBid: 2, wantBlinded: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+
+			fetch, err := fetcher.New(bmock, func(core.PubKey) string {
+				return "0x0000000000000000000000000000000000000000"
+			}, true, fetcher.WithMinBuilderBid(big.NewInt(test.minBid)))
+			require.NoError(t, err)
+
+			fetch.RegisterAggSigDB(func(context.Context, core.Duty, core.PubKey) (core.SignedData, error) {
+				return testutil.RandomCoreSignature(), nil
+			})
+
+			var fetched bool
+			fetch.Subscribe(func(_ context.Context, _ core.Duty, set core.UnsignedDataSet) error {
+				proposal, ok := set[pubkey].(core.VersionedProposal)
+				require.True(t, ok)
+				require.Equal(t, test.wantBlinded, proposal.Blinded)
+				fetched = true
+
+				return nil
+			})
+
+			require.NoError(t, fetch.Fetch(ctx, core.NewProposerDuty(slot), defSet))
+			require.True(t, fetched)
+		})
+	}
+}
+
 func TestFetchSyncContribution(t *testing.T) {
 	ctx := context.Background()
 
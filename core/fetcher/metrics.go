@@ -0,0 +1,61 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"math/big"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+	"github.com/obolnetwork/charon/core"
+)
+
+var (
+	proposalValueGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "proposal_value_gwei",
+		Help:      "The execution and consensus value in gwei of the latest fetched proposal by pubkey and type",
+	}, []string{"pubkey", "type"})
+
+	proposalCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "proposal_total",
+		Help:      "The total count of fetched proposals by pubkey and whether they are blinded (builder) or not",
+	}, []string{"pubkey", "blinded"})
+
+	localFallbackCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "proposal_local_fallback_total",
+		Help:      "The total count of builder proposals replaced by local proposals due to a bid below the minimum by pubkey",
+	}, []string{"pubkey"})
+)
+
+// weiPerGwei is the number of wei in a gwei.
+var weiPerGwei = big.NewFloat(1e9)
+
+// instrumentProposal sets the proposal value metrics.
+func instrumentProposal(pubkey core.PubKey, proposal *eth2api.VersionedProposal) {
+	blinded := "false"
+	if proposal.Blinded {
+		blinded = "true"
+	}
+	proposalCounter.WithLabelValues(pubkey.String(), blinded).Inc()
+
+	if proposal.ExecutionValue != nil {
+		proposalValueGauge.WithLabelValues(pubkey.String(), "execution").Set(toGwei(proposal.ExecutionValue))
+	}
+	if proposal.ConsensusValue != nil {
+		proposalValueGauge.WithLabelValues(pubkey.String(), "consensus").Set(toGwei(proposal.ConsensusValue))
+	}
+}
+
+// toGwei returns the wei value as float gwei.
+func toGwei(wei *big.Int) float64 {
+	resp, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), weiPerGwei).Float64()
+	return resp
+}
@@ -0,0 +1,140 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"context"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// ValidateValue returns an error if the unsigned data set proposed by a consensus leader for the duty is invalid.
+// It verifies the slot and target epoch of attestation data, the slot of aggregated attestations and sync committee
+// contributions, and the slot, sanity and fee recipient of proposals.
+// Note that the fee recipient of builder proposals is not enforced, since builders commonly use their own
+// fee recipient and pay the proposer via a transaction. The fee recipient of local proposals is only enforced
+// if the cluster agrees on the local cluster manifest, since fee recipients change with partially applied
+// manifest mutations.
+func (f *Fetcher) ValidateValue(ctx context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+	for pubkey, data := range set {
+		var err error
+		switch duty.Type {
+		case core.DutyAttester:
+			err = f.validateAttestationData(ctx, duty, data)
+		case core.DutyAggregator:
+			err = validateAggregatedAttestation(duty, data)
+		case core.DutySyncContribution:
+			err = validateSyncContribution(duty, data)
+		case core.DutyProposer:
+			err = f.validateProposal(ctx, duty, pubkey, data)
+		default:
+			return errors.New("unsupported duty type", z.Str("type", duty.Type.String()))
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "invalid unsigned data", z.Any("pubkey", pubkey))
+		}
+	}
+
+	return nil
+}
+
+// validateAttestationData returns an error if the attestation data doesn't match the duty slot and its epoch.
+func (f *Fetcher) validateAttestationData(ctx context.Context, duty core.Duty, data core.UnsignedData) error {
+	attData, ok := data.(core.AttestationData)
+	if !ok {
+		return errors.New("invalid attestation data")
+	}
+
+	eth2Resp, err := f.eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return err
+	}
+
+	slotsPerEpoch, ok := eth2Resp.Data["SLOTS_PER_EPOCH"].(uint64)
+	if !ok {
+		return errors.New("fetch slots per epoch")
+	}
+
+	epoch := eth2p0.Epoch(duty.Slot / slotsPerEpoch)
+
+	switch {
+	case uint64(attData.Data.Slot) != duty.Slot || uint64(attData.Duty.Slot) != duty.Slot:
+		return errors.New("attestation data slot mismatch", z.U64("slot", uint64(attData.Data.Slot)))
+	case attData.Data.Index != attData.Duty.CommitteeIndex:
+		return errors.New("attestation data committee index mismatch")
+	case attData.Data.Target == nil || attData.Data.Source == nil:
+		return errors.New("attestation data missing checkpoints")
+	case attData.Data.Target.Epoch != epoch:
+		return errors.New("attestation data target epoch mismatch",
+			z.U64("expected", uint64(epoch)), z.U64("actual", uint64(attData.Data.Target.Epoch)))
+	case attData.Data.Source.Epoch > attData.Data.Target.Epoch:
+		return errors.New("attestation data source epoch after target epoch")
+	}
+
+	return nil
+}
+
+// validateAggregatedAttestation returns an error if the aggregated attestation doesn't match the duty slot.
+func validateAggregatedAttestation(duty core.Duty, data core.UnsignedData) error {
+	aggAtt, ok := data.(core.AggregatedAttestation)
+	if !ok {
+		return errors.New("invalid aggregated attestation")
+	} else if aggAtt.Data == nil {
+		return errors.New("aggregated attestation missing data")
+	} else if uint64(aggAtt.Data.Slot) != duty.Slot {
+		return errors.New("aggregated attestation slot mismatch", z.U64("slot", uint64(aggAtt.Data.Slot)))
+	}
+
+	return nil
+}
+
+// validateSyncContribution returns an error if the sync committee contribution doesn't match the duty slot.
+func validateSyncContribution(duty core.Duty, data core.UnsignedData) error {
+	contrib, ok := data.(core.SyncContribution)
+	if !ok {
+		return errors.New("invalid sync committee contribution")
+	} else if uint64(contrib.Slot) != duty.Slot {
+		return errors.New("sync committee contribution slot mismatch", z.U64("slot", uint64(contrib.Slot)))
+	}
+
+	return nil
+}
+
+// validateProposal returns an error if the proposal doesn't match the duty slot, is malformed or
+// if a local proposal doesn't use the validator's fee recipient while the cluster agrees on the cluster manifest.
+func (f *Fetcher) validateProposal(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.UnsignedData) error {
+	proposal, ok := data.(core.VersionedProposal)
+	if !ok {
+		return errors.New("invalid proposal")
+	}
+
+	slot, err := proposal.Slot()
+	if err != nil {
+		return errors.Wrap(err, "proposal slot")
+	} else if uint64(slot) != duty.Slot {
+		return errors.New("proposal slot mismatch", z.U64("slot", uint64(slot)))
+	}
+
+	if _, err := proposal.BodyRoot(); err != nil {
+		return errors.Wrap(err, "proposal body root")
+	}
+
+	if err := verifyFeeRecipient(&proposal.VersionedProposal, f.feeRecipientFunc(pubkey)); err != nil {
+		if proposal.Blinded {
+			log.Warn(ctx, "Builder proposal with unexpected fee recipient address", err)
+		} else if f.manifestAgreed == nil || !f.manifestAgreed(duty.Slot) {
+			log.Warn(ctx, "Proposal with unexpected fee recipient address, cluster manifest agreement unknown", err)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher_test
+
+import (
+	"context"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestValidateValue(t *testing.T) {
+	const (
+		slot         = 33
+		feeRecipient = "0x0000000000000000000000000000000000000000"
+	)
+
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	eth2Resp, err := bmock.Spec(ctx, &eth2api.SpecOpts{})
+	require.NoError(t, err)
+
+	slotsPerEpoch, ok := eth2Resp.Data["SLOTS_PER_EPOCH"].(uint64)
+	require.True(t, ok)
+
+	fetch := mustCreateFetcherWithAddress(t, bmock, feeRecipient)
+	fetch.RegisterManifestAgreed(func(agreedSlot uint64) bool { return agreedSlot == slot })
+	pubkey := testutil.RandomCorePubKey(t)
+
+	attData := func(mutate func(*core.AttestationData)) core.UnsignedData {
+		data := testutil.RandomCoreAttestationData(t)
+		data.Data.Slot = slot
+		data.Duty.Slot = slot
+		data.Data.Index = data.Duty.CommitteeIndex
+		data.Data.Source.Epoch = eth2p0.Epoch(slot/slotsPerEpoch) - 1
+		data.Data.Target.Epoch = eth2p0.Epoch(slot / slotsPerEpoch)
+		mutate(&data)
+
+		return data
+	}
+
+	aggAtt := func(attSlot eth2p0.Slot) core.UnsignedData {
+		att := testutil.RandomAttestation()
+		att.Data.Slot = attSlot
+
+		return core.AggregatedAttestation{Attestation: *att}
+	}
+
+	contribution := func(contribSlot eth2p0.Slot) core.UnsignedData {
+		contrib := testutil.RandomSyncCommitteeContribution()
+		contrib.Slot = contribSlot
+
+		return core.SyncContribution{SyncCommitteeContribution: *contrib}
+	}
+
+	proposal := func(blockSlot eth2p0.Slot, blinded bool, feeRecipient byte) core.UnsignedData {
+		resp := eth2api.VersionedProposal{Version: eth2spec.DataVersionDeneb, Blinded: blinded}
+		if blinded {
+			resp.DenebBlinded = testutil.RandomDenebBlindedBeaconBlock()
+			resp.DenebBlinded.Slot = blockSlot
+			resp.DenebBlinded.Body.ExecutionPayloadHeader.FeeRecipient[0] = feeRecipient
+		} else {
+			resp.Deneb = testutil.RandomDenebVersionedProposal().Deneb
+			resp.Deneb.Block.Slot = blockSlot
+			resp.Deneb.Block.Body.ExecutionPayload.FeeRecipient[0] = feeRecipient
+		}
+
+		return core.VersionedProposal{VersionedProposal: resp}
+	}
+
+	tests := []struct {
+		name   string
+		duty   core.Duty
+		data   core.UnsignedData
+		errMsg string
+	}{
+		{
+			name: "valid attestation data",
+			duty: core.NewAttesterDuty(slot),
+			data: attData(func(*core.AttestationData) {}),
+		},
+		{
+			name:   "attestation data slot mismatch",
+			duty:   core.NewAttesterDuty(slot),
+			data:   attData(func(data *core.AttestationData) { data.Data.Slot++ }),
+			errMsg: "attestation data slot mismatch",
+		},
+		{
+			name:   "attestation data target epoch mismatch",
+			duty:   core.NewAttesterDuty(slot),
+			data:   attData(func(data *core.AttestationData) { data.Data.Target.Epoch++ }),
+			errMsg: "attestation data target epoch mismatch",
+		},
+		{
+			name:   "attestation data source epoch after target epoch",
+			duty:   core.NewAttesterDuty(slot),
+			data:   attData(func(data *core.AttestationData) { data.Data.Source.Epoch += 2 }),
+			errMsg: "attestation data source epoch after target epoch",
+		},
+		{
+			name: "valid aggregated attestation",
+			duty: core.NewAggregatorDuty(slot),
+			data: aggAtt(slot),
+		},
+		{
+			name:   "aggregated attestation slot mismatch",
+			duty:   core.NewAggregatorDuty(slot),
+			data:   aggAtt(slot - 1),
+			errMsg: "aggregated attestation slot mismatch",
+		},
+		{
+			name: "valid sync contribution",
+			duty: core.NewSyncContributionDuty(slot),
+			data: contribution(slot),
+		},
+		{
+			name:   "sync contribution slot mismatch",
+			duty:   core.NewSyncContributionDuty(slot),
+			data:   contribution(slot + 1),
+			errMsg: "sync committee contribution slot mismatch",
+		},
+		{
+			name: "valid proposal",
+			duty: core.NewProposerDuty(slot),
+			data: proposal(slot, false, 0),
+		},
+		{
+			name:   "proposal slot mismatch",
+			duty:   core.NewProposerDuty(slot),
+			data:   proposal(slot+1, false, 0),
+			errMsg: "proposal slot mismatch",
+		},
+		{
+			name:   "proposal fee recipient mismatch",
+			duty:   core.NewProposerDuty(slot),
+			data:   proposal(slot, false, 1),
+			errMsg: "unexpected fee recipient address",
+		},
+		{
+			name: "proposal fee recipient mismatch without manifest agreement",
+			duty: core.NewProposerDuty(slot + 1),
+			data: proposal(slot+1, false, 1),
+		},
+		{
+			name: "builder proposal fee recipient mismatch",
+			duty: core.NewProposerDuty(slot),
+			data: proposal(slot, true, 1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := fetch.ValidateValue(ctx, test.duty, core.UnsignedDataSet{pubkey: test.data})
+			if test.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.errMsg)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 )
 
 const defaultAllowedFutureEpochs = 2
@@ -78,3 +80,40 @@ func NewDutyGater(ctx context.Context, eth2Cl eth2wrap.Client, opts ...func(*dut
 		return dutyEpoch <= currentEpoch+uint64(o.allowedFutureEpochs)
 	}, nil
 }
+
+// WithDutyGater wraps component input functions with the duty gater, rejecting partial signatures submitted by
+// the validator client or received from peers and preventing aggregation and broadcasting of gated duties.
+func WithDutyGater(gaterFunc DutyGaterFunc) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			if !gaterFunc(duty) {
+				return errors.New("duty disabled", z.Any("duty", duty))
+			}
+
+			return clone.ParSigDBStoreInternal(ctx, duty, set)
+		}
+		w.ParSigDBStoreExternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			if !gaterFunc(duty) {
+				log.Debug(ctx, "Dropping partial signatures of disabled duty", z.Any("duty", duty))
+				return nil
+			}
+
+			return clone.ParSigDBStoreExternal(ctx, duty, set)
+		}
+		w.SigAggAggregate = func(ctx context.Context, duty Duty, set map[PubKey][]ParSignedData) error {
+			if !gaterFunc(duty) {
+				return errors.New("duty disabled", z.Any("duty", duty))
+			}
+
+			return clone.SigAggAggregate(ctx, duty, set)
+		}
+		w.BroadcasterBroadcast = func(ctx context.Context, duty Duty, set SignedDataSet) error {
+			if !gaterFunc(duty) {
+				return errors.New("duty disabled", z.Any("duty", duty))
+			}
+
+			return clone.BroadcasterBroadcast(ctx, duty, set)
+		}
+	}
+}
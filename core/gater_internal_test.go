@@ -0,0 +1,38 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDutyGater(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int
+	w := wireFuncs{
+		ParSigDBStoreInternal: func(context.Context, Duty, ParSignedDataSet) error { calls++; return nil },
+		ParSigDBStoreExternal: func(context.Context, Duty, ParSignedDataSet) error { calls++; return nil },
+		SigAggAggregate:       func(context.Context, Duty, map[PubKey][]ParSignedData) error { calls++; return nil },
+		BroadcasterBroadcast:  func(context.Context, Duty, SignedDataSet) error { calls++; return nil },
+	}
+
+	enabled := NewAttesterDuty(1)
+	WithDutyGater(func(duty Duty) bool { return duty == enabled })(&w)
+
+	require.NoError(t, w.ParSigDBStoreInternal(ctx, enabled, nil))
+	require.NoError(t, w.ParSigDBStoreExternal(ctx, enabled, nil))
+	require.NoError(t, w.SigAggAggregate(ctx, enabled, nil))
+	require.NoError(t, w.BroadcasterBroadcast(ctx, enabled, nil))
+	require.Equal(t, 4, calls)
+
+	disabled := NewAttesterDuty(2)
+	require.ErrorContains(t, w.ParSigDBStoreInternal(ctx, disabled, nil), "duty disabled")
+	require.NoError(t, w.ParSigDBStoreExternal(ctx, disabled, nil)) // Dropped
+	require.ErrorContains(t, w.SigAggAggregate(ctx, disabled, nil), "duty disabled")
+	require.ErrorContains(t, w.BroadcasterBroadcast(ctx, disabled, nil), "duty disabled")
+	require.Equal(t, 4, calls)
+}
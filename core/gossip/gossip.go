@@ -0,0 +1,344 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package gossip provides a relay based dissemination protocol for clusters with many operators.
+// Instead of unicasting a message to every peer, the publisher sends it to a few relays
+// which forward it to the remaining peers, so a single slow link doesn't delay all peers.
+// Messages are signed by the publisher and only relayed once verified, so a peer can't
+// impersonate other peers or amplify invalid messages to the whole cluster.
+package gossip
+
+import (
+	"context"
+	"crypto/sha256"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// defaultRelays is the default number of relays a message is published to.
+	defaultRelays = 3
+	// relayRedundancy is the number of relays each peer is assigned to, so a failed relay doesn't prevent delivery.
+	relayRedundancy = 2
+	// seenTTL is the duration received messages are remembered to deduplicate messages received via multiple relays.
+	seenTTL = time.Minute
+)
+
+// Handler handles a message published to a topic by the origin peer.
+type Handler func(ctx context.Context, origin peer.ID, msg proto.Message) error
+
+// Option configures the gossip component.
+type Option func(*Gossip)
+
+// WithRelays returns an option that sets the number of relays messages are published to.
+func WithRelays(relays int) Option {
+	return func(g *Gossip) {
+		g.relays = relays
+	}
+}
+
+// New returns a new gossip component disseminating messages between the cluster peers.
+// Topics are libp2p protocol IDs, only peers supporting a topic take part in its dissemination.
+// The sendFunc must send synchronously, since peers assigned to a relay that can't be reached are sent the message directly.
+// Published messages are signed with the p2p private key.
+func New(tcpNode host.Host, peers []peer.ID, sendFunc p2p.SendFunc, p2pKey *k1.PrivateKey, opts ...Option) *Gossip {
+	g := &Gossip{
+		tcpNode:  tcpNode,
+		peers:    peers,
+		sendFunc: sendFunc,
+		p2pKey:   p2pKey,
+		relays:   defaultRelays,
+		seen:     make(map[[32]byte]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Gossip disseminates messages between cluster peers via relays.
+type Gossip struct {
+	tcpNode  host.Host
+	peers    []peer.ID
+	sendFunc p2p.SendFunc
+	p2pKey   *k1.PrivateKey
+	relays   int
+
+	mu       sync.Mutex
+	seen     map[[32]byte]time.Time
+	lastTrim time.Time
+}
+
+// Subscribe registers a handler for messages published to the topic.
+// Note this function is not thread safe, it should be called before any messages are published.
+func (g *Gossip) Subscribe(topic protocol.ID, handler Handler) {
+	p2p.RegisterHandler("gossip", g.tcpNode, topic,
+		func() proto.Message { return new(pbv1.GossipMsg) },
+		func(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+			return nil, false, g.handle(ctx, topic, req, handler)
+		},
+	)
+}
+
+// handle verifies the origin's signature and passes the message to the handler which verifies its data.
+// Only messages accepted by the handler are relayed to their targets.
+func (g *Gossip) handle(ctx context.Context, topic protocol.ID, req proto.Message, handler Handler) error {
+	msg, ok := req.(*pbv1.GossipMsg)
+	if !ok || msg == nil {
+		return errors.New("invalid gossip message")
+	}
+
+	origin, err := peer.IDFromBytes(msg.GetOrigin())
+	if err != nil {
+		return errors.Wrap(err, "invalid gossip origin")
+	} else if !g.isPeer(origin) || origin == g.tcpNode.ID() {
+		return errors.New("invalid gossip origin", z.Str("origin", p2p.PeerName(origin)))
+	}
+
+	var targets []peer.ID
+	for _, b := range msg.GetTargets() {
+		target, err := peer.IDFromBytes(b)
+		if err != nil {
+			return errors.Wrap(err, "invalid gossip target")
+		} else if !g.isPeer(target) {
+			return errors.New("gossip target not in cluster", z.Str("target", p2p.PeerName(target)))
+		}
+
+		if target == origin || target == g.tcpNode.ID() {
+			continue
+		}
+
+		targets = append(targets, target)
+	}
+
+	if err := verifySignature(topic, origin, msg); err != nil {
+		return err
+	}
+
+	if !g.markSeen(msg) {
+		// Already received via another relay.
+		return nil
+	}
+
+	data, err := msg.GetData().UnmarshalNew()
+	if err != nil {
+		return errors.Wrap(err, "unmarshal gossip data")
+	}
+
+	if err := handler(ctx, origin, data); err != nil {
+		return err
+	}
+
+	relayed := &pbv1.GossipMsg{Origin: msg.GetOrigin(), Data: msg.GetData(), Signature: msg.GetSignature()}
+	g.sendAll(ctx, topic, targets, relayed, func(target peer.ID, err error) {
+		log.Warn(ctx, "Failed relaying gossip message", err, z.Str("target", p2p.PeerName(target)))
+	})
+
+	return nil
+}
+
+// Publish publishes the message to all peers supporting the topic by sending it to a few relays
+// that forward it to the remaining peers. Peers that are not relays are assigned evenly to multiple relays.
+// If sending to a relay fails, its assigned peers are sent the message directly.
+//
+// Relays are sent to concurrently and Publish blocks until all sends completed.
+//
+// It returns the peers that don't support the topic, which the caller should send the message to directly,
+// also if an error is returned. It returns the first relay or direct send error after attempting to reach all peers,
+// so callers that can't block on offline peers should publish asynchronously and only log the error.
+func (g *Gossip) Publish(ctx context.Context, topic protocol.ID, msg proto.Message) ([]peer.ID, error) {
+	data, err := anypb.New(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal gossip data")
+	}
+
+	sig, err := k1util.Sign(g.p2pKey, hashData(topic, data))
+	if err != nil {
+		return nil, errors.Wrap(err, "sign gossip data")
+	}
+
+	supported, unsupported := g.peersByTopic(topic)
+
+	// Assign the peers to relays round-robin.
+	relays := min(max(g.relays, 1), len(supported))
+	targets := make([][]peer.ID, relays)
+	for i, p := range supported[relays:] {
+		for j := range min(relayRedundancy, relays) {
+			idx := (i + j) % relays
+			targets[idx] = append(targets[idx], p)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	setErr := func(_ peer.ID, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, relay := range supported[:relays] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			relayMsg := &pbv1.GossipMsg{
+				Origin:    []byte(g.tcpNode.ID()),
+				Data:      data,
+				Targets:   peerIDsToBytes(targets[i]),
+				Signature: sig,
+			}
+
+			err := g.sendFunc(ctx, g.tcpNode, topic, relay, relayMsg)
+			if err == nil {
+				return
+			}
+			setErr(relay, err)
+
+			// Fallback to sending directly to the relay's targets.
+			directMsg := &pbv1.GossipMsg{Origin: []byte(g.tcpNode.ID()), Data: data, Signature: sig}
+			g.sendAll(ctx, topic, targets[i], directMsg, setErr)
+		}()
+	}
+	wg.Wait()
+
+	return unsupported, firstErr
+}
+
+// sendAll concurrently sends the message to the peers, calling errFunc for each failed send.
+func (g *Gossip) sendAll(ctx context.Context, topic protocol.ID, peers []peer.ID, msg *pbv1.GossipMsg, errFunc func(peer.ID, error)) {
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := g.sendFunc(ctx, g.tcpNode, topic, p, msg); err != nil {
+				errFunc(p, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// verifySignature returns an error if the message isn't signed by the origin peer.
+func verifySignature(topic protocol.ID, origin peer.ID, msg *pbv1.GossipMsg) error {
+	pubkey, err := p2p.PeerIDToKey(origin)
+	if err != nil {
+		return errors.Wrap(err, "gossip origin public key")
+	}
+
+	ok, err := k1util.Verify65(pubkey, hashData(topic, msg.GetData()), msg.GetSignature())
+	if err != nil {
+		return errors.Wrap(err, "verify gossip signature")
+	} else if !ok {
+		return errors.New("invalid gossip signature", z.Str("origin", p2p.PeerName(origin)))
+	}
+
+	return nil
+}
+
+// hashData returns the hash of the topic and data signed by the origin peer.
+func hashData(topic protocol.ID, data *anypb.Any) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(topic))
+	_, _ = h.Write([]byte(data.GetTypeUrl()))
+	_, _ = h.Write(data.GetValue())
+
+	return h.Sum(nil)
+}
+
+// markSeen returns true if the message wasn't seen before and marks it as seen.
+func (g *Gossip) markSeen(msg *pbv1.GossipMsg) bool {
+	h := sha256.New()
+	_, _ = h.Write(msg.GetOrigin())
+	_, _ = h.Write([]byte(msg.GetData().GetTypeUrl()))
+	_, _ = h.Write(msg.GetData().GetValue())
+
+	var id [32]byte
+	copy(id[:], h.Sum(nil))
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.lastTrim) > seenTTL {
+		for seenID, seenAt := range g.seen {
+			if now.Sub(seenAt) > seenTTL {
+				delete(g.seen, seenID)
+			}
+		}
+		g.lastTrim = now
+	}
+
+	if _, ok := g.seen[id]; ok {
+		return false
+	}
+
+	g.seen[id] = now
+
+	return true
+}
+
+// peersByTopic returns the other peers that support the topic in random order with connected peers first,
+// since connected peers make more reliable relays. It also returns the other peers that don't support the topic.
+func (g *Gossip) peersByTopic(topic protocol.ID) ([]peer.ID, []peer.ID) {
+	var connected, disconnected, unsupported []peer.ID
+	for _, p := range g.peers {
+		if p == g.tcpNode.ID() {
+			continue
+		}
+
+		if protos, err := g.tcpNode.Peerstore().SupportsProtocols(p, topic); err != nil || len(protos) == 0 {
+			unsupported = append(unsupported, p)
+		} else if g.tcpNode.Network().Connectedness(p) == network.Connected {
+			connected = append(connected, p)
+		} else {
+			disconnected = append(disconnected, p)
+		}
+	}
+
+	rand.Shuffle(len(connected), func(i, j int) { connected[i], connected[j] = connected[j], connected[i] })
+	rand.Shuffle(len(disconnected), func(i, j int) { disconnected[i], disconnected[j] = disconnected[j], disconnected[i] })
+
+	return append(connected, disconnected...), unsupported
+}
+
+// isPeer returns true if the peer is part of the cluster.
+func (g *Gossip) isPeer(p peer.ID) bool {
+	return slices.Contains(g.peers, p)
+}
+
+// peerIDsToBytes returns the peer IDs as bytes.
+func peerIDsToBytes(peers []peer.ID) [][]byte {
+	var resp [][]byte
+	for _, p := range peers {
+		resp = append(resp, []byte(p))
+	}
+
+	return resp
+}
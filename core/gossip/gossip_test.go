@@ -0,0 +1,280 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package gossip_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/gossip"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+const topic = "/charon/gossip/test/1.0.0"
+
+func TestGossipChurn(t *testing.T) {
+	const (
+		n      = 12
+		legacy = n - 1 // The last peer doesn't support gossip.
+		rounds = 6
+	)
+
+	ctx := context.Background()
+
+	var (
+		keys    []*k1.PrivateKey
+		peerIDs []peer.ID
+	)
+	for range n {
+		key, err := k1.GeneratePrivateKey()
+		require.NoError(t, err)
+
+		id, err := p2p.PeerIDFromKey(key.PubKey())
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+		peerIDs = append(peerIDs, id)
+	}
+
+	// Hosts are scoped to the cluster via the connection gater.
+	gater, err := p2p.NewConnGater(peerIDs, nil)
+	require.NoError(t, err)
+
+	// Peers that are down neither send nor receive messages.
+	var (
+		mu   sync.Mutex
+		down = make(map[peer.ID]bool)
+	)
+	isDown := func(p peer.ID) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return down[p]
+	}
+	// Messages are sent with the synchronous p2p.Send used in production, so relay failures are detected.
+	sendFunc := func(ctx context.Context, h host.Host, pID protocol.ID, p peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		if isDown(h.ID()) || isDown(p) {
+			return errors.New("peer down")
+		}
+
+		return p2p.Send(ctx, h, pID, p, msg, opts...)
+	}
+
+	var (
+		hosts    []host.Host
+		gossips  []*gossip.Gossip
+		receives []chan *pbv1.Duty
+	)
+	for i := range n {
+		h := testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), keys[i], libp2p.ConnectionGater(gater))
+		hosts = append(hosts, h)
+		gossips = append(gossips, gossip.New(h, peerIDs, sendFunc, keys[i]))
+		receives = append(receives, make(chan *pbv1.Duty, rounds+1))
+	}
+
+	for i, h := range hosts {
+		for j, other := range hosts {
+			if i == j {
+				continue
+			}
+
+			h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			if j != legacy {
+				require.NoError(t, h.Peerstore().AddProtocols(other.ID(), topic))
+			}
+		}
+
+		if i == legacy {
+			continue
+		}
+
+		gossips[i].Subscribe(topic, func(_ context.Context, origin peer.ID, msg proto.Message) error {
+			duty, ok := msg.(*pbv1.Duty)
+			if !ok {
+				return errors.New("invalid message")
+			}
+
+			require.Equal(t, peerIDs[duty.GetSlot()%legacy], origin)
+			receives[i] <- duty
+
+			return nil
+		})
+	}
+
+	// requireDelivered asserts that all peers supporting gossip that are up receive the duty.
+	stopped := make(map[int]bool)
+	requireDelivered := func(t *testing.T, slot uint64) {
+		t.Helper()
+
+		publisher := int(slot % legacy)
+		for i := range legacy {
+			if i == publisher || isDown(peerIDs[i]) || stopped[i] {
+				continue
+			}
+
+			// Peers that were down may still receive messages of previous slots, since relays
+			// forward messages after handling them.
+			for received := false; !received; {
+				select {
+				case duty := <-receives[i]:
+					require.LessOrEqual(t, duty.GetSlot(), slot)
+					received = duty.GetSlot() == slot
+				case <-time.After(5 * time.Second):
+					require.Fail(t, "gossip message not delivered", "peer=%d slot=%d", i, slot)
+				}
+			}
+		}
+	}
+
+	for slot := range uint64(rounds) {
+		// A different set of peers is down each round.
+		mu.Lock()
+		clear(down)
+		for i := range 3 {
+			down[peerIDs[(int(slot)+1+i*4)%legacy]] = true
+		}
+		mu.Unlock()
+
+		// Errors are expected if relays are down.
+		direct, _ := gossips[slot%legacy].Publish(ctx, topic, &pbv1.Duty{Slot: slot})
+		require.Equal(t, []peer.ID{peerIDs[legacy]}, direct)
+
+		requireDelivered(t, slot)
+	}
+
+	// Stop some peers.
+	mu.Lock()
+	clear(down)
+	mu.Unlock()
+
+	for _, i := range []int{2, 5, 8} {
+		require.NoError(t, hosts[i].Close())
+		stopped[i] = true
+
+		// Wait for the publisher to detect the disconnect, since messages sent
+		// to a relay just before it disconnects may be lost.
+		require.Eventually(t, func() bool {
+			return hosts[rounds].Network().Connectedness(peerIDs[i]) != network.Connected
+		}, time.Second, time.Millisecond)
+	}
+
+	// Sending to stopped peers fails, but the rest still receive the message.
+	_, _ = gossips[rounds].Publish(ctx, topic, &pbv1.Duty{Slot: rounds})
+	requireDelivered(t, rounds)
+}
+
+func TestGossipVerify(t *testing.T) {
+	const (
+		n           = 4
+		invalidSlot = 666
+		forgedSlot  = 777
+		validSlot   = 1
+	)
+
+	ctx := context.Background()
+
+	var (
+		keys    []*k1.PrivateKey
+		peerIDs []peer.ID
+		hosts   []host.Host
+	)
+	for range n {
+		key, err := k1.GeneratePrivateKey()
+		require.NoError(t, err)
+
+		h := testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), key)
+
+		keys = append(keys, key)
+		peerIDs = append(peerIDs, h.ID())
+		hosts = append(hosts, h)
+	}
+
+	// The publisher forges the origin of messages of the forged slot as the last peer.
+	sendFunc := func(ctx context.Context, h host.Host, pID protocol.ID, p peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		gossipMsg, ok := msg.(*pbv1.GossipMsg)
+		require.True(t, ok)
+
+		duty := new(pbv1.Duty)
+		require.NoError(t, gossipMsg.GetData().UnmarshalTo(duty))
+
+		if duty.GetSlot() == forgedSlot {
+			gossipMsg = proto.Clone(gossipMsg).(*pbv1.GossipMsg)
+			gossipMsg.Origin = []byte(peerIDs[n-1])
+		}
+
+		return p2p.Send(ctx, h, pID, p, gossipMsg, opts...)
+	}
+
+	var (
+		mu       sync.Mutex
+		received = make(map[uint64]int) // Handled messages by slot.
+	)
+	var gossips []*gossip.Gossip
+	for i, h := range hosts {
+		for j, other := range hosts {
+			if i != j {
+				h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+				require.NoError(t, h.Peerstore().AddProtocols(other.ID(), topic))
+			}
+		}
+
+		g := gossip.New(h, peerIDs, sendFunc, keys[i], gossip.WithRelays(1))
+		g.Subscribe(topic, func(_ context.Context, _ peer.ID, msg proto.Message) error {
+			duty, ok := msg.(*pbv1.Duty)
+			if !ok {
+				return errors.New("invalid message")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			received[duty.GetSlot()]++
+			if duty.GetSlot() == invalidSlot {
+				return errors.New("invalid duty")
+			}
+
+			return nil
+		})
+		gossips = append(gossips, g)
+	}
+
+	count := func(slot uint64) int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return received[slot]
+	}
+
+	// Messages with forged origins are rejected by the relay.
+	_, err := gossips[0].Publish(ctx, topic, &pbv1.Duty{Slot: forgedSlot})
+	require.NoError(t, err)
+
+	// Invalid messages are only handled by the relay, which doesn't relay them.
+	_, err = gossips[0].Publish(ctx, topic, &pbv1.Duty{Slot: invalidSlot})
+	require.NoError(t, err)
+
+	_, err = gossips[0].Publish(ctx, topic, &pbv1.Duty{Slot: validSlot})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return count(validSlot) == n-1 && count(invalidSlot) == 1
+	}, 5*time.Second, time.Millisecond)
+
+	require.Zero(t, count(forgedSlot))
+	require.Equal(t, 1, count(invalidSlot))
+}
@@ -6,6 +6,7 @@ package infosync
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -22,6 +23,9 @@ const (
 	topicVersion  = "version"
 	topicProtocol = "protocol"
 	topicProposal = "proposal"
+	topicLeader   = "unreliable_leader"
+	topicBuilder  = "builder_config"
+	topicManifest = "manifest_hash"
 
 	// maxResults limits the number of results to keep.
 	maxResults = 100
@@ -29,9 +33,37 @@ const (
 	TopicProtocol = topicProtocol
 )
 
+// Option configures the infosync component.
+type Option func(*Component)
+
+// WithUnreliableLeaders returns an option that includes the indexes of peers observed to be unreliable
+// consensus leaders as returned by unreliableFunc, in order to agree on cluster wide unreliable leaders.
+func WithUnreliableLeaders(unreliableFunc func() []int64) Option {
+	return func(c *Component) {
+		c.unreliableFunc = unreliableFunc
+	}
+}
+
+// WithBuilderConfig returns an option that includes the local builder proposal configuration, e.g. the
+// builder boost factor and minimum builder bid, in order to detect peers proposing divergent values.
+// Mismatches with the cluster wide agreed configuration are logged as errors and reported via metrics.
+func WithBuilderConfig(config string) Option {
+	return func(c *Component) {
+		c.builderConfig = config
+	}
+}
+
+// WithManifestHash returns an option that includes the local cluster manifest hash, i.e. the latest mutation hash,
+// in order to detect whether the cluster agrees on the cluster manifest, see ManifestAgreed.
+func WithManifestHash(hash string) Option {
+	return func(c *Component) {
+		c.manifestHash = hash
+	}
+}
+
 // New returns a new infosync component.
 func New(prioritiser *priority.Component, versions []version.SemVer, protocols []protocol.ID,
-	proposals []core.ProposalType,
+	proposals []core.ProposalType, opts ...Option,
 ) *Component {
 	// Add a mock alpha protocol if alpha features enabled in order to test infosync in prod.
 	// TODO(corver): Remove this once we have an actual use case.
@@ -46,10 +78,28 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 		proposals:   proposals,
 	}
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
 		res := result{slot: duty.Slot}
-		var fields []z.Field
+		var (
+			fields       []z.Field
+			builderFound bool
+			builder      []string
+		)
 		for _, result := range results {
+			if result.Topic == topicBuilder {
+				builderFound = true
+				builder = result.PrioritiesOnly()
+			}
+
+			if result.Topic == topicManifest && c.manifestHash != "" {
+				agreed := result.PrioritiesOnly()
+				res.manifestAgreed = len(agreed) == 1 && agreed[0] == c.manifestHash
+			}
+
 			fields = append(fields, z.Any(result.Topic, result.Priorities))
 
 			for _, prio := range result.PrioritiesOnly() {
@@ -60,12 +110,23 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 					res.protocols = append(res.protocols, protocol.ID(prio))
 				case topicProposal:
 					res.proposals = append(res.proposals, core.ProposalType(prio))
+				case topicLeader:
+					idx, err := strconv.ParseInt(prio, 10, 64)
+					if err != nil {
+						log.Warn(ctx, "Ignoring invalid unreliable leader", err, z.Str("leader", prio))
+						continue
+					}
+					res.unreliable = append(res.unreliable, idx)
 				}
 			}
 		}
 
 		log.Debug(ctx, "Infosync completed", fields...)
 
+		if builderFound && c.builderConfig != "" {
+			c.checkBuilderConfig(ctx, builder)
+		}
+
 		if len(res.versions) > 0 {
 			c.addResult(res)
 		}
@@ -82,6 +143,10 @@ type Component struct {
 	protocols   []protocol.ID
 	proposals   []core.ProposalType
 
+	unreliableFunc func() []int64
+	builderConfig  string
+	manifestHash   string
+
 	mu      sync.Mutex
 	results []result
 }
@@ -124,6 +189,55 @@ func (c *Component) Proposals(slot uint64) []core.ProposalType {
 	return resp
 }
 
+// UnreliableLeaders returns the latest cluster wide agreed unreliable leader peer indexes strictly before the slot.
+// Results are only used for subsequent slots to ensure all peers use identical results for the slot.
+// It returns nil if no results before the slot are available.
+func (c *Component) UnreliableLeaders(slot uint64) []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp []int64
+	for _, result := range c.results {
+		if result.slot >= slot {
+			break
+		}
+		resp = result.unreliable
+	}
+
+	return resp
+}
+
+// ManifestAgreed returns true if the latest cluster wide result strictly before the slot agreed on the local
+// cluster manifest hash. It returns false if no results before the slot are available.
+func (c *Component) ManifestAgreed(slot uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp bool
+	for _, result := range c.results {
+		if result.slot >= slot {
+			break
+		}
+		resp = result.manifestAgreed
+	}
+
+	return resp
+}
+
+// checkBuilderConfig logs an error and sets the mismatch gauge if the cluster wide agreed builder
+// configuration isn't identical to the local configuration.
+func (c *Component) checkBuilderConfig(ctx context.Context, agreed []string) {
+	if len(agreed) == 1 && agreed[0] == c.builderConfig {
+		builderConfigMismatchGauge.Set(0)
+		return
+	}
+
+	builderConfigMismatchGauge.Set(1)
+	log.Error(ctx, "Builder proposal configuration differs from cluster, "+
+		"divergent proposals will stall consensus; ensure builder flags are identical on all nodes", nil,
+		z.Str("local", c.builderConfig), z.Any("cluster", agreed))
+}
+
 // addResult adds the result to the results if it is different from the last result.
 func (c *Component) addResult(result result) {
 	c.mu.Lock()
@@ -143,19 +257,43 @@ func (c *Component) addResult(result result) {
 }
 
 func (c *Component) Trigger(ctx context.Context, slot uint64) error {
-	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot),
-		priority.TopicProposal{
+	proposals := []priority.TopicProposal{
+		{
 			Topic:      topicVersion,
 			Priorities: versionsToStrings(c.versions),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProtocol,
 			Priorities: protocolsToStrings(c.protocols),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProposal,
 			Priorities: proposalsToStrings(c.proposals),
+		},
+	}
+
+	if c.builderConfig != "" {
+		proposals = append(proposals, priority.TopicProposal{
+			Topic:      topicBuilder,
+			Priorities: []string{c.builderConfig},
+		})
+	}
+
+	if c.manifestHash != "" {
+		proposals = append(proposals, priority.TopicProposal{
+			Topic:      topicManifest,
+			Priorities: []string{c.manifestHash},
 		})
+	}
+
+	if c.unreliableFunc != nil {
+		proposals = append(proposals, priority.TopicProposal{
+			Topic:      topicLeader,
+			Priorities: indexesToStrings(c.unreliableFunc()),
+		})
+	}
+
+	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot), proposals...)
 }
 
 // versionsToStrings returns the versions as strings.
@@ -188,12 +326,25 @@ func proposalsToStrings(proposals []core.ProposalType) []string {
 	return resp
 }
 
+// indexesToStrings returns the peer indexes as strings.
+func indexesToStrings(indexes []int64) []string {
+	var resp []string
+	for _, idx := range indexes {
+		resp = append(resp, strconv.FormatInt(idx, 10))
+	}
+
+	return resp
+}
+
 // result is a cluster-wide agreed-upon infosync result.
 type result struct {
-	slot      uint64
-	versions  []string
-	protocols []protocol.ID
-	proposals []core.ProposalType
+	slot       uint64
+	versions   []string
+	protocols  []protocol.ID
+	proposals  []core.ProposalType
+	unreliable []int64
+
+	manifestAgreed bool
 }
 
 // Equal returns true if the results are equal.
@@ -201,5 +352,7 @@ func (x result) Equal(y result) bool {
 	return x.slot == y.slot &&
 		fmt.Sprint(x.versions) == fmt.Sprint(y.versions) &&
 		fmt.Sprint(x.protocols) == fmt.Sprint(y.protocols) &&
-		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals)
+		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals) &&
+		fmt.Sprint(x.unreliable) == fmt.Sprint(y.unreliable) &&
+		x.manifestAgreed == y.manifestAgreed
 }
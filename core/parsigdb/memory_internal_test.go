@@ -178,3 +178,52 @@ func (t *testDeadliner) Add(duty core.Duty) bool {
 func (t *testDeadliner) C() <-chan core.Duty {
 	return t.ch
 }
+
+func TestMemDBSnapshot(t *testing.T) {
+	const th = 3
+
+	ctx := context.Background()
+	pubkey := testutil.RandomCorePubKey(t)
+	att := testutil.RandomAttestation()
+	duty := core.NewAttesterDuty(123)
+
+	db := NewMemDB(th, newTestDeadliner())
+	for i := range th - 1 {
+		err := db.StoreExternal(ctx, duty, core.ParSignedDataSet{pubkey: core.NewPartialAttestation(att, i+1)})
+		require.NoError(t, err)
+	}
+
+	snapshot, err := db.Snapshot(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshot.GetEntries(), 1)
+	require.Len(t, snapshot.GetEntries()[0].GetData(), th-1)
+
+	// Partial signatures restored in a new database count towards the threshold.
+	db = NewMemDB(th, newTestDeadliner())
+	require.NoError(t, db.Restore(snapshot))
+
+	var thresholds []map[core.PubKey][]core.ParSignedData
+	db.SubscribeThreshold(func(_ context.Context, _ core.Duty, output map[core.PubKey][]core.ParSignedData) error {
+		thresholds = append(thresholds, output)
+		return nil
+	})
+
+	err = db.StoreExternal(ctx, duty, core.ParSignedDataSet{pubkey: core.NewPartialAttestation(att, th)})
+	require.NoError(t, err)
+	require.Len(t, thresholds, 1)
+	require.Len(t, thresholds[0][pubkey], th)
+
+	// Expired duties are not restored.
+	db = NewMemDB(th, expiredDeadliner{testDeadliner: newTestDeadliner()})
+	require.NoError(t, db.Restore(snapshot))
+	require.Empty(t, db.entries)
+}
+
+// expiredDeadliner is a deadliner that considers all duties expired.
+type expiredDeadliner struct {
+	*testDeadliner
+}
+
+func (expiredDeadliner) Add(core.Duty) bool {
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigdb
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// Snapshot returns a snapshot of the partial signed data of all duties not yet expired.
+func (db *MemDB) Snapshot(context.Context) (*pbv1.SignedDataSnapshot, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	resp := new(pbv1.SignedDataSnapshot)
+	for k, sigs := range db.entries {
+		entry := &pbv1.SignedDataEntry{
+			Duty:   core.DutyToProto(k.Duty),
+			PubKey: string(k.PubKey),
+		}
+
+		for _, sig := range sigs {
+			pb, err := core.ParSignedDataToProto(sig)
+			if err != nil {
+				return nil, err
+			}
+
+			entry.Data = append(entry.Data, pb)
+		}
+
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	return resp, nil
+}
+
+// Restore restores the partial signed data of duties that have not expired from the snapshot.
+// Restored partial signed data counts towards the threshold of subsequently stored partial signed data,
+// but subscribers are not called. It should be called before any data is stored.
+func (db *MemDB) Restore(snapshot *pbv1.SignedDataSnapshot) error {
+	active := make(map[core.Duty]bool)
+	for _, entry := range snapshot.GetEntries() {
+		duty := core.DutyFromProto(entry.GetDuty())
+		if _, ok := active[duty]; !ok {
+			active[duty] = db.deadliner.Add(duty)
+		}
+
+		if !active[duty] {
+			continue // Expired
+		}
+
+		for _, pb := range entry.GetData() {
+			sig, err := core.ParSignedDataFromProto(duty.Type, pb)
+			if err != nil {
+				return err
+			}
+
+			if _, _, err := db.store(key{Duty: duty, PubKey: core.PubKey(entry.GetPubKey())}, sig); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigex
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var batchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "core",
+	Subsystem: "parsigex",
+	Name:      "batch_size",
+	Help:      "Number of duty messages coalesced into a single partial signature exchange message by peer",
+	Buckets:   []float64{1, 2, 4, 8, 16, 32, 64},
+}, []string{"peer"})
@@ -4,6 +4,9 @@ package parsigex
 
 import (
 	"context"
+	"sync"
+	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -16,28 +19,44 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/gossip"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 )
 
-const protocolID2 = "/charon/parsigex/2.0.0"
+const (
+	protocolID2 = "/charon/parsigex/2.0.0"
+	// protocolID3 sends snappy compressed batches of messages.
+	protocolID3 = "/charon/parsigex/3.0.0"
+)
+
+// batchWindow is the duration messages of the same slot are collected before sending them as a single batch to a peer.
+const batchWindow = 10 * time.Millisecond
+
+// GossipProtocolID is the gossip topic of partial signatures, see ParSigEx.EnableGossip.
+const GossipProtocolID = "/charon/gossip/parsigex/1.0.0"
 
 // Protocols returns the supported protocols of this package in order of precedence.
 func Protocols() []protocol.ID {
-	return []protocol.ID{protocolID2}
+	return []protocol.ID{protocolID3, protocolID2}
 }
 
+// NewParSigEx returns a new partial signature exchange. The sendFunc should send asynchronously,
+// since Broadcast doesn't wait for peers and ignores the result of sending to them.
 func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []peer.ID,
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error,
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error,
 	gaterFunc core.DutyGaterFunc, p2pOpts ...p2p.SendRecvOption,
 ) *ParSigEx {
 	parSigEx := &ParSigEx{
-		tcpNode:    tcpNode,
-		sendFunc:   sendFunc,
-		peerIdx:    peerIdx,
-		peers:      peers,
-		verifyFunc: verifyFunc,
-		gaterFunc:  gaterFunc,
+		tcpNode:       tcpNode,
+		sendFunc:      sendFunc,
+		peerIdx:       peerIdx,
+		peers:         peers,
+		verifyFunc:    verifyFunc,
+		gaterFunc:     gaterFunc,
+		batchWindow:   batchWindow,
+		batches:       make(map[batchKey][]*pbv1.ParSigExMsg),
+		publishFilter: log.Filter(),
 	}
 
 	newReq := func() proto.Message { return new(pbv1.ParSigExMsg) }
@@ -50,6 +69,16 @@ func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []
 		p2pOpts...,
 	)
 
+	newBatchReq := func() proto.Message { return new(pbv1.ParSigExBatchMsg) }
+	p2p.RegisterHandler(
+		"parsigex",
+		tcpNode,
+		protocolID3,
+		newBatchReq,
+		parSigEx.handleBatch,
+		append(p2pOpts, p2p.WithSnappyProtocol(protocolID3))...,
+	)
+
 	return parSigEx
 }
 
@@ -60,9 +89,48 @@ type ParSigEx struct {
 	sendFunc   p2p.SendFunc
 	peerIdx    int
 	peers      []peer.ID
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error
 	gaterFunc  core.DutyGaterFunc
 	subs       []func(context.Context, core.Duty, core.ParSignedDataSet) error
+
+	batchWindow time.Duration
+	mu          sync.Mutex
+	batches     map[batchKey][]*pbv1.ParSigExMsg // Batches collecting messages until they are sent.
+
+	publishFilter z.Field
+
+	gossip        *gossip.Gossip
+	gossipEnabled func(slot uint64) bool
+}
+
+// EnableGossip subscribes to partial signatures published via gossip and publishes partial signatures via gossip
+// for duties of slots for which enabledFunc returns true, typically if the cluster supports GossipProtocolID.
+// Note this function is not thread safe, it should be called before Broadcast.
+func (m *ParSigEx) EnableGossip(g *gossip.Gossip, enabledFunc func(slot uint64) bool) {
+	m.gossip = g
+	m.gossipEnabled = enabledFunc
+
+	g.Subscribe(GossipProtocolID, func(ctx context.Context, origin peer.ID, msg proto.Message) error {
+		_, _, err := m.handle(ctx, origin, msg)
+		return err
+	})
+}
+
+// handleBatch handles all messages of a batch, returning the first error.
+func (m *ParSigEx) handleBatch(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
+	pb, ok := req.(*pbv1.ParSigExBatchMsg)
+	if !ok {
+		return nil, false, errors.New("invalid request type")
+	}
+
+	var firstErr error
+	for _, msg := range pb.GetMessages() {
+		if _, _, err := m.handle(ctx, peerID, msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return nil, false, firstErr
 }
 
 func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
@@ -90,11 +158,9 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 	ctx, span := core.StartDutyTrace(ctx, duty, "core/parsigex.Handle")
 	defer span.End()
 
-	// Verify partial signature
-	for pubkey, data := range set {
-		if err = m.verifyFunc(ctx, duty, pubkey, data); err != nil {
-			return nil, false, errors.Wrap(err, "invalid partial signature")
-		}
+	// Verify partial signatures
+	if err = m.verifyFunc(ctx, duty, set); err != nil {
+		return nil, false, errors.Wrap(err, "invalid partial signature")
 	}
 
 	for _, sub := range m.subs {
@@ -109,6 +175,7 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 }
 
 // Broadcast broadcasts the partially signed duty data set to all peers.
+// Peers are sent to asynchronously, so only local errors are returned.
 func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
 	ctx = log.WithTopic(ctx, "parsigex")
 
@@ -117,23 +184,98 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 		return err
 	}
 
-	msg := pbv1.ParSigExMsg{
+	msg := &pbv1.ParSigExMsg{
 		Duty:    core.DutyToProto(duty),
 		DataSet: pb,
 	}
 
-	for i, p := range m.peers {
+	if m.gossip == nil || !m.gossipEnabled(duty.Slot) {
+		m.sendAll(ctx, m.peers, duty.Slot, msg)
+		return nil
+	}
+
+	// Publishing via gossip is synchronous, so publish async since the context may be closed soon.
+	go func() {
+		ctx := log.CopyFields(context.Background(), ctx)
+
+		// Only peers that don't support gossip are sent the message directly, also if publishing failed.
+		peers, err := m.gossip.Publish(ctx, GossipProtocolID, msg)
+		if err != nil {
+			log.Warn(ctx, "Failed publishing partial signatures via gossip", err, m.publishFilter)
+		}
+
+		m.sendAll(ctx, peers, duty.Slot, msg)
+	}()
+
+	return nil
+}
+
+// sendAll sends the message to the peers other than self, adding it to the slot's batch if the peer supports batches.
+func (m *ParSigEx) sendAll(ctx context.Context, peers []peer.ID, slot uint64, msg *pbv1.ParSigExMsg) {
+	for _, p := range peers {
 		// Don't send to self
-		if i == m.peerIdx {
+		if p == m.peers[m.peerIdx] {
 			continue
 		}
 
-		if err := m.sendFunc(ctx, m.tcpNode, protocolID2, p, &msg); err != nil {
-			return err
+		if m.supportsBatches(p) {
+			m.addToBatch(ctx, p, slot, msg)
+			continue
 		}
+
+		// The sendFunc is asynchronous and handles errors itself.
+		_ = m.sendFunc(ctx, m.tcpNode, protocolID2, p, msg)
 	}
+}
 
-	return nil
+// supportsBatches returns true if the peer is known to support snappy compressed batches.
+func (m *ParSigEx) supportsBatches(p peer.ID) bool {
+	supported, err := m.tcpNode.Peerstore().SupportsProtocols(p, protocolID3)
+
+	return err == nil && len(supported) > 0
+}
+
+// batchKey identifies the batch of messages of a slot sent to a peer.
+type batchKey struct {
+	Peer peer.ID
+	Slot uint64
+}
+
+// addToBatch adds the message to the batch of the slot sent to the peer, which is sent once the batch window elapsed.
+func (m *ParSigEx) addToBatch(ctx context.Context, p peer.ID, slot uint64, msg *pbv1.ParSigExMsg) {
+	key := batchKey{Peer: p, Slot: slot}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	msgs, ok := m.batches[key]
+	if !ok {
+		// The batch is sent on behalf of all callers, so don't cancel it if the first caller's context is cancelled.
+		sendCtx := log.CopyFields(context.Background(), ctx)
+		time.AfterFunc(m.batchWindow, func() {
+			m.sendBatch(sendCtx, key)
+		})
+	}
+	m.batches[key] = append(msgs, msg)
+}
+
+// sendBatch sends the batch to the peer.
+func (m *ParSigEx) sendBatch(ctx context.Context, key batchKey) {
+	m.mu.Lock()
+	msgs := m.batches[key]
+	delete(m.batches, key)
+	m.mu.Unlock()
+
+	batchSize.WithLabelValues(p2p.PeerName(key.Peer)).Observe(float64(len(msgs)))
+
+	// The sendFunc is asynchronous and handles errors itself.
+	_ = m.sendFunc(ctx, m.tcpNode, protocolID3, key.Peer, &pbv1.ParSigExBatchMsg{Messages: msgs}, p2p.WithSnappyProtocol(protocolID3))
+}
+
+// SetBatchWindowForT sets the duration messages of the same slot are collected before sending them as a single batch.
+// Note this function is not thread safe, it should be called before Broadcast.
+func (m *ParSigEx) SetBatchWindowForT(_ *testing.T, window time.Duration) {
+	m.batchWindow = window
 }
 
 // Subscribe registers a callback when a partially signed duty set
@@ -143,24 +285,34 @@ func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedD
 }
 
 // NewEth2Verifier returns a partial signature verification function for core workflow eth2 signatures.
-func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
-	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
-		pubshares, ok := pubSharesByKey[pubkey]
-		if !ok {
-			return errors.New("unknown pubkey, not part of cluster lock")
-		}
+// All partial signatures of a set are batch verified.
+func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.ParSignedDataSet) error, error) {
+	return func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		var (
+			datas     = make(map[core.PubKey]core.Eth2SignedData)
+			pubshares = make(map[core.PubKey]tbls.PublicKey)
+		)
+		for pubkey, data := range set {
+			shares, ok := pubSharesByKey[pubkey]
+			if !ok {
+				return errors.New("unknown pubkey, not part of cluster lock")
+			}
 
-		pubshare, ok := pubshares[data.ShareIdx]
-		if !ok {
-			return errors.New("invalid shareIdx")
-		}
+			pubshare, ok := shares[data.ShareIdx]
+			if !ok {
+				return errors.New("invalid shareIdx")
+			}
+
+			eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
+			if !ok {
+				return errors.New("invalid eth2 signed data")
+			}
 
-		eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
-		if !ok {
-			return errors.New("invalid eth2 signed data")
+			datas[pubkey] = eth2Signed
+			pubshares[pubkey] = pubshare
 		}
 
-		err := core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, pubshare)
+		err := core.BatchVerifyEth2SignedData(ctx, eth2Cl, datas, pubshares)
 		if err != nil {
 			return errors.Wrap(err, "invalid signature", z.Str("duty", duty.String()))
 		}
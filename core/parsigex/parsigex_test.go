@@ -6,17 +6,23 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/gossip"
 	"github.com/obolnetwork/charon/core/parsigex"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
@@ -70,7 +76,7 @@ func TestParSigEx(t *testing.T) {
 			hosts[i].Peerstore().AddAddrs(hostsInfo[k].ID, hostsInfo[k].Addrs, peerstore.PermanentAddrTTL)
 		}
 	}
-	verifyFunc := func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error {
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
 		return nil
 	}
 
@@ -114,6 +120,273 @@ func TestParSigEx(t *testing.T) {
 	wg.Wait()
 }
 
+func TestParSigExBatched(t *testing.T) {
+	const (
+		valCount = 5
+		shareIdx = 1
+	)
+
+	ctx := context.Background()
+
+	hosts := []host.Host{
+		testutil.CreateHost(t, testutil.AvailableAddr(t)),
+		testutil.CreateHost(t, testutil.AvailableAddr(t)),
+	}
+	peers := []peer.ID{hosts[0].ID(), hosts[1].ID()}
+
+	// The sender knows the receiver supports batches.
+	hosts[0].Peerstore().AddAddrs(hosts[1].ID(), hosts[1].Addrs(), peerstore.PermanentAddrTTL)
+	require.NoError(t, hosts[0].Peerstore().AddProtocols(hosts[1].ID(), parsigex.Protocols()[0]))
+
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		return nil
+	}
+	gaterFunc := func(core.Duty) bool {
+		return true
+	}
+
+	var (
+		sendMu  sync.Mutex
+		sends   []protocol.ID
+		sendErr error
+	)
+	sendFunc := func(ctx context.Context, h host.Host, pID protocol.ID, p peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		sendMu.Lock()
+		sends = append(sends, pID)
+		err := sendErr
+		sendMu.Unlock()
+
+		if err != nil {
+			return err
+		}
+
+		return p2p.Send(ctx, h, pID, p, msg, opts...)
+	}
+
+	var (
+		mu       sync.Mutex
+		received = make(map[core.PubKey]core.ParSignedData)
+		done     = make(chan struct{})
+	)
+	receiver := parsigex.NewParSigEx(hosts[1], p2p.Send, 1, peers, verifyFunc, gaterFunc)
+	receiver.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for pubkey, data := range set {
+			received[pubkey] = data
+		}
+		if len(received) == valCount {
+			close(done)
+		}
+
+		return nil
+	})
+
+	sender := parsigex.NewParSigEx(hosts[0], sendFunc, 0, peers, verifyFunc, gaterFunc)
+	sender.SetBatchWindowForT(t, 200*time.Millisecond)
+
+	// Partial signatures of different validators for the same duty are broadcast separately.
+	duty := core.NewRandaoDuty(100)
+	expect := make(map[core.PubKey]core.ParSignedData)
+	for i := range valCount {
+		expect[testutil.RandomCorePubKey(t)] = core.NewPartialSignedRandao(eth2p0.Epoch(i), testutil.RandomEth2Signature(), shareIdx)
+	}
+
+	broadcast := func() []error {
+		var (
+			wg      sync.WaitGroup
+			errMu   sync.Mutex
+			results []error
+		)
+		for pubkey, data := range expect {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := sender.Broadcast(ctx, duty, core.ParSignedDataSet{pubkey: data})
+
+				errMu.Lock()
+				results = append(results, err)
+				errMu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		return results
+	}
+
+	// Messages of the same slot broadcast within the batch window are sent as a single batch.
+	for _, err := range broadcast() {
+		require.NoError(t, err)
+	}
+	<-done
+
+	require.Equal(t, []protocol.ID{parsigex.Protocols()[0]}, sends)
+	require.Equal(t, expect, received)
+
+	// Failing to send a batch to a peer isn't returned to callers.
+	sendMu.Lock()
+	sendErr = errors.New("send failed")
+	sendMu.Unlock()
+
+	for _, err := range broadcast() {
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		return len(sends) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestParSigExGossip(t *testing.T) {
+	const n = 4
+
+	ctx := context.Background()
+	duty := core.NewRandaoDuty(123)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	var (
+		hosts []host.Host
+		peers []peer.ID
+	)
+	var keys []*k1.PrivateKey
+	for range n {
+		key, err := k1.GeneratePrivateKey()
+		require.NoError(t, err)
+
+		h := testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), key)
+		hosts = append(hosts, h)
+		peers = append(peers, h.ID())
+		keys = append(keys, key)
+	}
+
+	// The last peer doesn't support gossip, so it is sent messages directly.
+	for i, h := range hosts {
+		for j, other := range hosts {
+			if i == j {
+				continue
+			}
+
+			h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			if j != n-1 {
+				require.NoError(t, h.Peerstore().AddProtocols(other.ID(), parsigex.GossipProtocolID))
+			}
+		}
+	}
+
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		return nil
+	}
+	gaterFunc := func(core.Duty) bool {
+		return true
+	}
+	gossipEnabled := func(slot uint64) bool {
+		return slot == duty.Slot
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sigexs   []*parsigex.ParSigEx
+		received = make(map[int][]int) // Received share indexes by peer index.
+	)
+	for i := range n {
+		sigex := parsigex.NewParSigEx(hosts[i], p2p.Send, i, peers, verifyFunc, gaterFunc)
+		if i != n-1 {
+			sigex.EnableGossip(gossip.New(hosts[i], peers, p2p.Send, keys[i], gossip.WithRelays(1)), gossipEnabled)
+		}
+
+		wg.Add(n - 1)
+		sigex.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+			defer wg.Done()
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			received[i] = append(received[i], set[pubkey].ShareIdx)
+
+			return nil
+		})
+		sigexs = append(sigexs, sigex)
+	}
+
+	for i, sigex := range sigexs {
+		set := core.ParSignedDataSet{
+			pubkey: core.NewPartialSignedRandao(1, testutil.RandomEth2Signature(), i+1),
+		}
+		require.NoError(t, sigex.Broadcast(ctx, duty, set))
+	}
+
+	wg.Wait()
+
+	for i := range n {
+		var expect []int
+		for j := range n {
+			if j != i {
+				expect = append(expect, j+1)
+			}
+		}
+		require.ElementsMatch(t, expect, received[i])
+	}
+}
+
+func TestParSigExGossipFailure(t *testing.T) {
+	ctx := context.Background()
+	duty := core.NewRandaoDuty(123)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	key, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	hosts := []host.Host{
+		testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), key),
+		testutil.CreateHost(t, testutil.AvailableAddr(t)),
+		testutil.CreateHost(t, testutil.AvailableAddr(t)),
+	}
+	peers := []peer.ID{hosts[0].ID(), hosts[1].ID(), hosts[2].ID()}
+
+	// The second peer supports gossip, the last peer doesn't.
+	for _, other := range hosts[1:] {
+		hosts[0].Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+	}
+	require.NoError(t, hosts[0].Peerstore().AddProtocols(hosts[1].ID(), parsigex.GossipProtocolID))
+
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		return nil
+	}
+	gaterFunc := func(core.Duty) bool {
+		return true
+	}
+
+	received := make(chan core.ParSignedDataSet, 1)
+	receiver := parsigex.NewParSigEx(hosts[2], p2p.Send, 2, peers, verifyFunc, gaterFunc)
+	receiver.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		received <- set
+		return nil
+	})
+
+	// Publishing via gossip fails.
+	failSend := func(context.Context, host.Host, protocol.ID, peer.ID, proto.Message, ...p2p.SendRecvOption) error {
+		return errors.New("relay down")
+	}
+
+	sender := parsigex.NewParSigEx(hosts[0], p2p.Send, 0, peers, verifyFunc, gaterFunc)
+	sender.EnableGossip(gossip.New(hosts[0], peers, failSend, key), func(uint64) bool { return true })
+
+	set := core.ParSignedDataSet{
+		pubkey: core.NewPartialSignedRandao(1, testutil.RandomEth2Signature(), 1),
+	}
+	// Peer errors aren't returned.
+	require.NoError(t, sender.Broadcast(ctx, duty, set))
+
+	// The peer that doesn't support gossip is still sent the message directly.
+	require.Equal(t, set, <-received)
+}
+
 func TestParSigExVerifier(t *testing.T) {
 	ctx := context.Background()
 
@@ -162,7 +435,7 @@ func TestParSigExVerifier(t *testing.T) {
 		require.NoError(t, err)
 		att.Signature = sign(sigData[:])
 		data := core.NewPartialAttestation(att, shareIdx)
-		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify proposal", func(t *testing.T) {
@@ -176,7 +449,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedProposal(proposal, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify blinded proposal", func(t *testing.T) {
@@ -194,7 +467,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedBlindedProposal(&eth2apiBlinded, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify Randao", func(t *testing.T) {
@@ -206,7 +479,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		randao := core.NewPartialSignedRandao(epoch, sign(sigData[:]), shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), pubkey, randao))
+		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), core.ParSignedDataSet{pubkey: randao}))
 	})
 
 	t.Run("Verify Voluntary Exit", func(t *testing.T) {
@@ -220,7 +493,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data := core.NewPartialSignedVoluntaryExit(exit, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify validator registration", func(t *testing.T) {
@@ -236,7 +509,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedValidatorRegistration(&reg.VersionedSignedValidatorRegistration, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify beacon committee selection", func(t *testing.T) {
@@ -249,7 +522,7 @@ func TestParSigExVerifier(t *testing.T) {
 		selection.SelectionProof = sign(sigData[:])
 		data := core.NewPartialSignedBeaconCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify aggregate and proof", func(t *testing.T) {
@@ -268,7 +541,7 @@ func TestParSigExVerifier(t *testing.T) {
 		agg.Signature = sign(sigData[:])
 		data := core.NewPartialSignedAggregateAndProof(agg, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("verify sync committee message", func(t *testing.T) {
@@ -280,11 +553,11 @@ func TestParSigExVerifier(t *testing.T) {
 		msg.Signature = sign(sigData[:])
 
 		data := core.NewPartialSignedSyncMessage(msg, shareIdx)
-		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data}))
 
 		// Invalid sync committee message.
 		data = core.NewPartialSignedRandao(epoch, testutil.RandomEth2Signature(), shareIdx)
-		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data)
+		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data})
 		require.Error(t, err)
 		require.ErrorContains(t, err, "invalid signature")
 	})
@@ -306,7 +579,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 
 	t.Run("verify sync committee contribution and proof", func(t *testing.T) {
@@ -322,10 +595,66 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncContributionAndProof(proof, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 }
 
+func TestParSigExBatchVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		slot     = 123
+		shareIdx = 1
+		numVals  = 20
+	)
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var (
+		set     = make(core.ParSignedDataSet)
+		mp      = make(map[core.PubKey]map[int]tbls.PublicKey)
+		pubkeys []core.PubKey
+	)
+	for range numVals {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pk, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		pubkey, err := core.PubKeyFromBytes(pk[:])
+		require.NoError(t, err)
+
+		att := testutil.RandomAttestation()
+		sigRoot, err := att.Data.HashTreeRoot()
+		require.NoError(t, err)
+		sigData, err := signing.GetDataRoot(ctx, bmock, signing.DomainBeaconAttester, att.Data.Target.Epoch, sigRoot)
+		require.NoError(t, err)
+		sig, err := tbls.Sign(secret, sigData[:])
+		require.NoError(t, err)
+		att.Signature = eth2p0.BLSSignature(sig)
+
+		mp[pubkey] = map[int]tbls.PublicKey{shareIdx: pk}
+		set[pubkey] = core.NewPartialAttestation(att, shareIdx)
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	verifyFunc, err := parsigex.NewEth2Verifier(bmock, mp)
+	require.NoError(t, err)
+
+	require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), set))
+
+	// A valid signature of another validator fails batch verification.
+	invalid := pubkeys[numVals/2]
+	signed, err := set[invalid].SetSignature(set[pubkeys[0]].Signature())
+	require.NoError(t, err)
+	set[invalid] = core.ParSignedData{SignedData: signed, ShareIdx: shareIdx}
+
+	err = verifyFunc(ctx, core.NewAttesterDuty(slot), set)
+	require.ErrorContains(t, err, "invalid signature")
+}
+
 func versionedSignedProposalRoot(t *testing.T, p *eth2api.VersionedSignedProposal) (eth2p0.Root, error) {
 	t.Helper()
 
@@ -0,0 +1,179 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// livenessDuties are the duty types that result in validator liveness on the beacon chain.
+var livenessDuties = map[core.DutyType]bool{
+	core.DutyAttester:         true,
+	core.DutyProposer:         true,
+	core.DutyAggregator:       true,
+	core.DutySyncMessage:      true,
+	core.DutySyncContribution: true,
+}
+
+// NewDoppelganger returns a new doppelganger detector that watches the provided number
+// of complete epochs for validator activity not produced by this cluster before enabling duties.
+// The shareIdx is this node's share index, partial signatures received from peers for it
+// indicate another instance of this node.
+func NewDoppelganger(eth2Cl eth2wrap.Client, shareIdx int, epochs uint64) *Doppelganger {
+	return &Doppelganger{
+		eth2Cl:        eth2Cl,
+		shareIdx:      shareIdx,
+		epochs:        epochs,
+		clusterActive: make(map[uint64]map[core.PubKey]bool),
+	}
+}
+
+// Doppelganger detects validator activity not produced by this cluster.
+// Duties are only enabled once the configured number of epochs were checked without detections.
+type Doppelganger struct {
+	eth2Cl   eth2wrap.Client
+	shareIdx int
+	epochs   uint64
+
+	mu            sync.Mutex
+	started       bool
+	startEpoch    uint64
+	checked       uint64
+	detected      bool
+	clusterActive map[uint64]map[core.PubKey]bool // Pubkeys with cluster partial signatures by epoch.
+}
+
+// DutyGater returns true if duties are enabled; the detection completed without detecting doppelgangers.
+// It implements core.DutyGaterFunc.
+func (d *Doppelganger) DutyGater(core.Duty) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return !d.detected && d.checked >= d.epochs
+}
+
+// ParSigReceived records partial signatures received from peers as cluster activity.
+// It detects partial signatures for this node's own share index, since this node didn't produce them.
+func (d *Doppelganger) ParSigReceived(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	if !livenessDuties[duty.Type] {
+		return nil
+	}
+
+	slotsPerEpoch, err := d.eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	epoch := duty.Slot / slotsPerEpoch
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.checked >= d.epochs {
+		return nil // Detection completed.
+	}
+
+	active, ok := d.clusterActive[epoch]
+	if !ok {
+		active = make(map[core.PubKey]bool)
+		d.clusterActive[epoch] = active
+	}
+
+	for pubkey, parSig := range set {
+		active[pubkey] = true
+
+		if parSig.ShareIdx == d.shareIdx {
+			d.setDetected(ctx, pubkey, "partial signature for own share index received from peer")
+		}
+	}
+
+	return nil
+}
+
+// SlotTicked checks the liveness of all active validators for the previous epoch
+// on the first slot of each epoch until the detection completes.
+// It implements a scheduler slot subscriber.
+func (d *Doppelganger) SlotTicked(ctx context.Context, slot core.Slot) error {
+	d.mu.Lock()
+	if !d.started {
+		// The start epoch is incomplete, so only check subsequent epochs.
+		d.started = true
+		d.startEpoch = slot.Epoch()
+		log.Info(ctx, "Doppelganger detection started, duties disabled until completed",
+			z.U64("epochs", d.epochs), z.U64("start_epoch", d.startEpoch))
+	}
+	done := d.detected || d.checked >= d.epochs
+	d.mu.Unlock()
+
+	if done || !slot.FirstInEpoch() || slot.Epoch() <= d.startEpoch+1 {
+		return nil
+	}
+
+	epoch := slot.Epoch() - 1
+
+	vals, err := d.eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	var indices []eth2p0.ValidatorIndex
+	for index := range vals {
+		indices = append(indices, index)
+	}
+
+	var liveness []*eth2wrap.ValidatorLiveness
+	if len(indices) > 0 {
+		liveness, err = d.eth2Cl.ValidatorLiveness(ctx, eth2p0.Epoch(epoch), indices)
+		if err != nil {
+			return errors.Wrap(err, "fetch validator liveness")
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, live := range liveness {
+		pubkey, ok := vals[live.Index]
+		if !ok || !live.IsLive {
+			continue
+		}
+
+		corePubkey := core.PubKeyFrom48Bytes(pubkey)
+		if !d.clusterActive[epoch][corePubkey] {
+			d.setDetected(ctx, corePubkey, "validator live without cluster partial signatures")
+		}
+	}
+
+	delete(d.clusterActive, epoch)
+	d.checked++
+
+	if d.detected {
+		return nil
+	}
+
+	if d.checked >= d.epochs {
+		log.Info(ctx, "Doppelganger detection completed, enabling duties", z.U64("epoch", epoch))
+	} else {
+		log.Info(ctx, "No doppelganger detected in epoch", z.U64("epoch", epoch),
+			z.U64("remaining", d.epochs-d.checked))
+	}
+
+	return nil
+}
+
+// setDetected marks a doppelganger as detected which permanently disables duties.
+// It must be called while holding the lock.
+func (d *Doppelganger) setDetected(ctx context.Context, pubkey core.PubKey, reason string) {
+	d.detected = true
+	doppelgangerGauge.Set(1)
+	log.Error(ctx, "Doppelganger detected, duties disabled. Ensure no other instance of this node is running and restart", nil,
+		z.Any("pubkey", pubkey), z.Str("reason", reason))
+}
@@ -0,0 +1,96 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/scheduler"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestDoppelganger(t *testing.T) {
+	const (
+		epochs     = 2
+		shareIdx   = 1
+		startEpoch = 10
+		liveIndex  = 2
+	)
+
+	valSet := beaconmock.ValidatorSetA
+	livePubkey := core.PubKeyFrom48Bytes(valSet[liveIndex].Validator.PublicKey)
+
+	tests := []struct {
+		name         string
+		live         bool
+		clusterSig   bool
+		parSigIdx    int
+		wantDetected bool
+	}{
+		{name: "no activity"},
+		{name: "cluster activity", live: true, clusterSig: true, parSigIdx: shareIdx + 1},
+		{name: "unexpected activity", live: true, wantDetected: true},
+		{name: "own share index", clusterSig: true, parSigIdx: shareIdx, wantDetected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			bmock, err := beaconmock.New(beaconmock.WithValidatorSet(valSet))
+			require.NoError(t, err)
+
+			var checkedEpochs []eth2p0.Epoch
+			bmock.ValidatorLivenessFunc = func(_ context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+				checkedEpochs = append(checkedEpochs, epoch)
+
+				var resp []*eth2wrap.ValidatorLiveness
+				for _, index := range indices {
+					resp = append(resp, &eth2wrap.ValidatorLiveness{
+						Index:  index,
+						IsLive: test.live && index == liveIndex,
+					})
+				}
+
+				return resp, nil
+			}
+
+			slotsPerEpoch, err := bmock.SlotsPerEpoch(ctx)
+			require.NoError(t, err)
+
+			firstSlot := func(epoch uint64) core.Slot {
+				return core.Slot{Slot: epoch * slotsPerEpoch, SlotsPerEpoch: slotsPerEpoch}
+			}
+
+			d := scheduler.NewDoppelganger(bmock, shareIdx, epochs)
+
+			// Start mid-epoch.
+			require.NoError(t, d.SlotTicked(ctx, core.Slot{Slot: startEpoch*slotsPerEpoch + 1, SlotsPerEpoch: slotsPerEpoch}))
+			require.False(t, d.DutyGater(core.NewAttesterDuty(0)))
+
+			for epoch := uint64(startEpoch + 1); epoch <= startEpoch+epochs+1; epoch++ {
+				if test.clusterSig {
+					duty := core.NewAttesterDuty(epoch*slotsPerEpoch + 1)
+					set := core.ParSignedDataSet{livePubkey: core.NewPartialSignature(testutil.RandomCoreSignature(), test.parSigIdx)}
+					require.NoError(t, d.ParSigReceived(ctx, duty, set))
+				}
+
+				require.NoError(t, d.SlotTicked(ctx, firstSlot(epoch+1)))
+			}
+
+			require.Equal(t, !test.wantDetected, d.DutyGater(core.NewAttesterDuty(0)))
+
+			if !test.wantDetected {
+				// Only complete epochs after the start epoch are checked.
+				require.Equal(t, []eth2p0.Epoch{startEpoch + 1, startEpoch + 2}, checkedEpochs)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@
 package scheduler
 
 import (
+	"time"
+
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -42,6 +44,20 @@ var (
 		Help:      "Number of active validators",
 	})
 
+	gatedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "scheduler",
+		Name:      "duty_gated_total",
+		Help:      "The total count of duties not triggered since they were disabled by type",
+	}, []string{"duty"})
+
+	doppelgangerGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "scheduler",
+		Name:      "doppelganger_detected",
+		Help:      "Set to 1 if validator activity not produced by this cluster was detected, disabling all duties",
+	})
+
 	balanceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "core",
 		Subsystem: "scheduler",
@@ -56,6 +72,13 @@ var (
 		Help:      "Gauge with validator pubkey and status as labels, value=1 is current status, value=0 is previous.",
 	}, []string{"pubkey_full", "pubkey", "status"})
 
+	offsetGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "scheduler",
+		Name:      "duty_offset_seconds",
+		Help:      "The slot offset in seconds at which duties are triggered by type",
+	}, []string{"duty"})
+
 	skipCounter = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "scheduler",
@@ -75,6 +98,11 @@ func instrumentDuty(duty core.Duty, defSet core.DutyDefinitionSet) {
 	dutyCounter.WithLabelValues(duty.Type.String()).Add(float64(len(defSet)))
 }
 
+// instrumentOffset sets the slot offset metric of the duty type.
+func instrumentOffset(dutyType core.DutyType, offset time.Duration) {
+	offsetGauge.WithLabelValues(dutyType.String()).Set(offset.Seconds())
+}
+
 // newMetricSubmitter returns a function that sets validator balance and status metric.
 func newMetricSubmitter() func(pubkey core.PubKey, totalBal eth2p0.Gwei, status string) {
 	return func(pubkey core.PubKey, totalBal eth2p0.Gwei, status string) {
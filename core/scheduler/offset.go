@@ -3,11 +3,24 @@
 package scheduler
 
 import (
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 )
 
+const (
+	// adaptiveStepDivisor defines the adaptive offset step as a fraction of the slot duration, 0.5s for 12s slots.
+	adaptiveStepDivisor = 24
+	// adaptiveMaxSteps bounds the adaptive offset shift to 1/6 of the slot duration, 2s for 12s slots.
+	adaptiveMaxSteps = 4
+	// adaptiveRecoverDuties is the number of consecutive on-time duties after which the adaptive shift is reduced by a step.
+	adaptiveRecoverDuties = 32
+)
+
 // slotOffsets defines the offsets at which the duties should be triggered.
 var slotOffsets = map[core.DutyType]func(time.Duration) time.Duration{
 	core.DutyAttester:         fraction(1, 3), // 1/3 slot duration
@@ -15,9 +28,154 @@ var slotOffsets = map[core.DutyType]func(time.Duration) time.Duration{
 	core.DutySyncContribution: fraction(2, 3),
 }
 
+// adaptiveFloors defines the earliest offsets adaptive shifts may trigger duties at, since duties triggered too early
+// in the slot produce invalid data, e.g. attestations voting for the previous head before the block arrived, or
+// aggregations before attestations were broadcast. Explicitly configured earlier offsets are not affected.
+var adaptiveFloors = map[core.DutyType]func(time.Duration) time.Duration{
+	core.DutyAttester:         fraction(1, 3), // 1/3 slot duration
+	core.DutyAggregator:       fraction(1, 2), // 1/2 slot duration
+	core.DutySyncContribution: fraction(1, 2),
+}
+
+// networkOffsets defines per-network overrides of the default slot offsets.
+// Gnosis chains have 5s slots which leaves less time for aggregation after consensus,
+// so aggregation duties are triggered slightly earlier.
+var networkOffsets = map[string]map[core.DutyType]func(time.Duration) time.Duration{
+	"gnosis": {
+		core.DutyAggregator:       fraction(3, 5), // 3/5 slot duration
+		core.DutySyncContribution: fraction(3, 5),
+	},
+	"chiado": {
+		core.DutyAggregator:       fraction(3, 5),
+		core.DutySyncContribution: fraction(3, 5),
+	},
+}
+
 // fraction returns a function that calculates slot offset based on the fraction x/y of total slot duration.
 func fraction(x, y int64) func(time.Duration) time.Duration {
 	return func(total time.Duration) time.Duration {
 		return (total * time.Duration(x)) / time.Duration(y)
 	}
 }
+
+// absolute returns a function that returns a fixed slot offset.
+func absolute(offset time.Duration) func(time.Duration) time.Duration {
+	return func(time.Duration) time.Duration {
+		return offset
+	}
+}
+
+// ParseOffsets parses slot offset overrides formatted as "<duty>=<duration>", e.g. "attester=3s".
+func ParseOffsets(offsets []string) (map[core.DutyType]time.Duration, error) {
+	dutyTypes := make(map[string]core.DutyType)
+	for _, dutyType := range core.AllDutyTypes() {
+		dutyTypes[dutyType.String()] = dutyType
+	}
+
+	resp := make(map[core.DutyType]time.Duration)
+	for _, offset := range offsets {
+		name, value, ok := strings.Cut(offset, "=")
+		if !ok {
+			return nil, errors.New("invalid slot offset, expected <duty>=<duration>", z.Str("offset", offset))
+		}
+
+		dutyType, ok := dutyTypes[strings.TrimSpace(name)]
+		if !ok {
+			return nil, errors.New("invalid slot offset duty type", z.Str("duty", name))
+		} else if _, ok := resp[dutyType]; ok {
+			return nil, errors.New("duplicate slot offset duty type", z.Str("duty", name))
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid slot offset duration", z.Str("offset", offset))
+		} else if duration < 0 {
+			return nil, errors.New("negative slot offset duration", z.Str("offset", offset))
+		}
+
+		resp[dutyType] = duration
+	}
+
+	return resp, nil
+}
+
+// newOffsets returns the default slot offsets.
+func newOffsets() *offsets {
+	funcs := make(map[core.DutyType]func(time.Duration) time.Duration)
+	for dutyType, fn := range slotOffsets {
+		funcs[dutyType] = fn
+	}
+
+	return &offsets{
+		funcs:    funcs,
+		steps:    make(map[core.DutyType]int),
+		onTime:   make(map[core.DutyType]int),
+		lastLate: make(map[core.DutyType]uint64),
+	}
+}
+
+// offsets calculates the slot offsets at which duties are triggered.
+// In adaptive mode, offsets are shifted earlier when duties are late,
+// and shifted back once duties are consistently on-time again.
+type offsets struct {
+	funcs    map[core.DutyType]func(time.Duration) time.Duration
+	adaptive bool
+
+	mu       sync.Mutex
+	steps    map[core.DutyType]int    // Number of adaptive steps shifted earlier.
+	onTime   map[core.DutyType]int    // Number of consecutive on-time duties.
+	lastLate map[core.DutyType]uint64 // Slot of the last late duty that shifted the offset.
+}
+
+// Offset returns the slot offset of the duty type and true or false if the duty type has no offset.
+// The offset is bounded between zero and the slot duration, adaptive shifts are bounded by the duty type's floor.
+func (o *offsets) Offset(dutyType core.DutyType, slotDuration time.Duration) (time.Duration, bool) {
+	fn, ok := o.funcs[dutyType]
+	if !ok {
+		return 0, false
+	}
+
+	o.mu.Lock()
+	steps := o.steps[dutyType]
+	o.mu.Unlock()
+
+	base := fn(slotDuration)
+	offset := base - time.Duration(steps)*slotDuration/adaptiveStepDivisor
+	if floor, ok := adaptiveFloors[dutyType]; ok {
+		offset = max(offset, min(base, floor(slotDuration)))
+	}
+
+	return min(max(offset, 0), slotDuration), true
+}
+
+// Report updates the adaptive offset of the duty type given whether the duty was late.
+// Multiple late duties of the same or earlier slots only shift the offset once.
+func (o *offsets) Report(duty core.Duty, late bool) {
+	if !o.adaptive {
+		return
+	} else if _, ok := o.funcs[duty.Type]; !ok {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !late {
+		o.onTime[duty.Type]++
+		if o.onTime[duty.Type] >= adaptiveRecoverDuties && o.steps[duty.Type] > 0 {
+			o.steps[duty.Type]--
+			o.onTime[duty.Type] = 0
+		}
+
+		return
+	}
+
+	o.onTime[duty.Type] = 0
+
+	if last, ok := o.lastLate[duty.Type]; ok && duty.Slot <= last {
+		return // Offset already shifted for this slot.
+	}
+
+	o.lastLate[duty.Type] = duty.Slot
+	o.steps[duty.Type] = min(o.steps[duty.Type]+1, adaptiveMaxSteps)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestParseOffsets(t *testing.T) {
+	offsets, err := ParseOffsets([]string{"attester=3s", " aggregator = 7.5s"})
+	require.NoError(t, err)
+	require.Equal(t, map[core.DutyType]time.Duration{
+		core.DutyAttester:   3 * time.Second,
+		core.DutyAggregator: 7500 * time.Millisecond,
+	}, offsets)
+
+	tests := []struct {
+		offset string
+		errMsg string
+	}{
+		{offset: "attester", errMsg: "invalid slot offset"},
+		{offset: "unknown=1s", errMsg: "invalid slot offset duty type"},
+		{offset: "attester=1", errMsg: "invalid slot offset duration"},
+		{offset: "attester=-1s", errMsg: "negative slot offset duration"},
+	}
+	for _, test := range tests {
+		t.Run(test.offset, func(t *testing.T) {
+			_, err := ParseOffsets([]string{test.offset})
+			require.ErrorContains(t, err, test.errMsg)
+		})
+	}
+
+	_, err = ParseOffsets([]string{"attester=1s", "attester=2s"})
+	require.ErrorContains(t, err, "duplicate slot offset duty type")
+}
+
+func TestOffsets(t *testing.T) {
+	const slotDuration = 12 * time.Second
+
+	newScheduler := func(t *testing.T, opts ...Option) *Scheduler {
+		t.Helper()
+
+		s, err := New(nil, nil, false, opts...)
+		require.NoError(t, err)
+
+		return s
+	}
+
+	requireOffset := func(t *testing.T, s *Scheduler, dutyType core.DutyType, expect time.Duration) {
+		t.Helper()
+
+		offset, ok := s.offsets.Offset(dutyType, slotDuration)
+		require.True(t, ok)
+		require.Equal(t, expect, offset)
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		s := newScheduler(t, WithNetworkOffsets("mainnet"))
+		requireOffset(t, s, core.DutyAttester, 4*time.Second)
+		requireOffset(t, s, core.DutyAggregator, 8*time.Second)
+
+		_, ok := s.offsets.Offset(core.DutyProposer, slotDuration)
+		require.False(t, ok)
+	})
+
+	t.Run("network profile", func(t *testing.T) {
+		s := newScheduler(t, WithNetworkOffsets("gnosis"))
+		offset, ok := s.offsets.Offset(core.DutyAggregator, 5*time.Second)
+		require.True(t, ok)
+		require.Equal(t, 3*time.Second, offset)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		s := newScheduler(t, WithNetworkOffsets("gnosis"), WithOffsets(map[core.DutyType]time.Duration{
+			core.DutyAggregator: 7 * time.Second,
+			core.DutyProposer:   time.Second,
+			core.DutyAttester:   time.Minute,
+		}))
+		requireOffset(t, s, core.DutyAggregator, 7*time.Second)
+		requireOffset(t, s, core.DutyProposer, time.Second)
+		requireOffset(t, s, core.DutyAttester, slotDuration) // Bounded by slot duration.
+	})
+
+	t.Run("not adaptive", func(t *testing.T) {
+		s := newScheduler(t)
+		s.ReportDuty(core.NewAttesterDuty(1), true)
+		requireOffset(t, s, core.DutyAttester, 4*time.Second)
+	})
+
+	t.Run("adaptive", func(t *testing.T) {
+		s := newScheduler(t, WithAdaptiveOffsets())
+
+		// Multiple late duties of the same slot shift the offset once.
+		s.ReportDuty(core.NewAggregatorDuty(1), true)
+		s.ReportDuty(core.NewAggregatorDuty(1), true)
+		requireOffset(t, s, core.DutyAggregator, 7500*time.Millisecond)
+		requireOffset(t, s, core.DutyAttester, 4*time.Second)
+
+		// Shifts are bounded.
+		for slot := uint64(2); slot < 10; slot++ {
+			s.ReportDuty(core.NewAggregatorDuty(slot), true)
+		}
+		requireOffset(t, s, core.DutyAggregator, 6*time.Second)
+
+		// On-time duties shift the offset back.
+		for slot := uint64(10); slot < 10+adaptiveRecoverDuties; slot++ {
+			s.ReportDuty(core.NewAggregatorDuty(slot), false)
+		}
+		requireOffset(t, s, core.DutyAggregator, 6500*time.Millisecond)
+
+		// Offsets are never negative.
+		s = newScheduler(t, WithAdaptiveOffsets(), WithOffsets(map[core.DutyType]time.Duration{core.DutyProposer: time.Second}))
+		for slot := uint64(1); slot < 10; slot++ {
+			s.ReportDuty(core.NewProposerDuty(slot), true)
+		}
+		requireOffset(t, s, core.DutyProposer, 0)
+	})
+
+	t.Run("adaptive floors", func(t *testing.T) {
+		s := newScheduler(t, WithAdaptiveOffsets(), WithOffsets(map[core.DutyType]time.Duration{
+			core.DutyAttester:         5 * time.Second,
+			core.DutySyncContribution: 5 * time.Second,
+		}))
+		for slot := uint64(1); slot < 10; slot++ {
+			s.ReportDuty(core.NewAttesterDuty(slot), true)
+			s.ReportDuty(core.NewSyncContributionDuty(slot), true)
+		}
+
+		// Attestations are never triggered earlier than 1/3 slot, before the block is likely to arrive.
+		requireOffset(t, s, core.DutyAttester, 4*time.Second)
+		// Explicitly configured offsets earlier than the floor aren't shifted.
+		requireOffset(t, s, core.DutySyncContribution, 5*time.Second)
+
+		// Default attester offsets are at the floor, so they aren't shifted.
+		s = newScheduler(t, WithAdaptiveOffsets())
+		for slot := uint64(1); slot < 10; slot++ {
+			s.ReportDuty(core.NewAttesterDuty(slot), true)
+		}
+		requireOffset(t, s, core.DutyAttester, 4*time.Second)
+	})
+}
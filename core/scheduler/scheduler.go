@@ -29,11 +29,11 @@ type delayFunc func(duty core.Duty, deadline time.Time) <-chan time.Time
 
 // NewForT returns a new scheduler for testing using a fake clock.
 func NewForT(t *testing.T, clock clockwork.Clock, delayFunc delayFunc, pubkeys []core.PubKey,
-	eth2Cl eth2wrap.Client, builderAPI bool,
+	eth2Cl eth2wrap.Client, builderAPI bool, opts ...Option,
 ) *Scheduler {
 	t.Helper()
 
-	s, err := New(pubkeys, eth2Cl, builderAPI)
+	s, err := New(pubkeys, eth2Cl, builderAPI, opts...)
 	require.NoError(t, err)
 
 	s.clock = clock
@@ -42,22 +42,65 @@ func NewForT(t *testing.T, clock clockwork.Clock, delayFunc delayFunc, pubkeys [
 	return s
 }
 
+// Option configures the scheduler.
+type Option func(*Scheduler)
+
+// WithNetworkOffsets returns an option that applies the slot offset profile of the network if any.
+func WithNetworkOffsets(network string) Option {
+	return func(s *Scheduler) {
+		for dutyType, fn := range networkOffsets[network] {
+			s.offsets.funcs[dutyType] = fn
+		}
+	}
+}
+
+// WithOffsets returns an option that overrides the slot offsets of the provided duty types.
+func WithOffsets(offsets map[core.DutyType]time.Duration) Option {
+	return func(s *Scheduler) {
+		for dutyType, offset := range offsets {
+			s.offsets.funcs[dutyType] = absolute(offset)
+		}
+	}
+}
+
+// WithClock returns an option that sets the clock deriving slots and delaying duties, e.g. to replay recordings.
+func WithClock(clock clockwork.Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// WithAdaptiveOffsets returns an option that enables adaptive slot offsets.
+// Duties are triggered earlier, within safe bounds, when duties are reported late via ReportDuty.
+func WithAdaptiveOffsets() Option {
+	return func(s *Scheduler) {
+		s.offsets.adaptive = true
+	}
+}
+
 // New returns a new scheduler.
-func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled bool) (*Scheduler, error) {
-	return &Scheduler{
-		eth2Cl:        eth2Cl,
-		pubkeys:       pubkeys,
-		quit:          make(chan struct{}),
-		duties:        make(map[core.Duty]core.DutyDefinitionSet),
-		dutiesByEpoch: make(map[uint64][]core.Duty),
-		clock:         clockwork.NewRealClock(),
-		delayFunc: func(_ core.Duty, deadline time.Time) <-chan time.Time {
-			return time.After(time.Until(deadline))
-		},
+func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled bool, opts ...Option) (*Scheduler, error) {
+	s := &Scheduler{
+		eth2Cl:          eth2Cl,
+		pubkeys:         pubkeys,
+		quit:            make(chan struct{}),
+		duties:          make(map[core.Duty]core.DutyDefinitionSet),
+		dutiesByEpoch:   make(map[uint64][]core.Duty),
+		clock:           clockwork.NewRealClock(),
 		metricSubmitter: newMetricSubmitter(),
 		resolvedEpoch:   math.MaxInt64,
 		builderEnabled:  builderEnabled,
-	}, nil
+		offsets:         newOffsets(),
+	}
+	s.delayFunc = func(_ core.Duty, deadline time.Time) <-chan time.Time {
+		return s.clock.After(deadline.Sub(s.clock.Now()))
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
 type Scheduler struct {
@@ -73,7 +116,9 @@ type Scheduler struct {
 	dutiesMutex     sync.Mutex
 	dutySubs        []func(context.Context, core.Duty, core.DutyDefinitionSet) error
 	slotSubs        []func(context.Context, core.Slot) error
+	dutyGater       core.DutyGaterFunc
 	builderEnabled  bool
+	offsets         *offsets
 }
 
 // SubscribeDuties subscribes a callback function for triggered duties.
@@ -89,6 +134,18 @@ func (s *Scheduler) SubscribeSlots(fn func(context.Context, core.Slot) error) {
 	s.slotSubs = append(s.slotSubs, fn)
 }
 
+// RegisterDutyGater registers a function that gates triggering of duties, duties are skipped if it returns false.
+// Note this should be called *before* Start.
+func (s *Scheduler) RegisterDutyGater(fn core.DutyGaterFunc) {
+	s.dutyGater = fn
+}
+
+// ReportDuty reports whether a duty was late, i.e., consensus or inclusion was delayed.
+// It shifts the slot offset of the duty type if adaptive offsets are enabled.
+func (s *Scheduler) ReportDuty(duty core.Duty, late bool) {
+	s.offsets.Report(duty, late)
+}
+
 func (s *Scheduler) Stop() {
 	close(s.quit)
 }
@@ -191,10 +248,17 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 
 		// Trigger duty async
 		go func() {
-			if !delaySlotOffset(ctx, slot, duty, s.delayFunc) {
+			if !delaySlotOffset(ctx, slot, duty, s.offsets, s.delayFunc) {
 				return // context cancelled
 			}
 
+			if s.dutyGater != nil && !s.dutyGater(duty) {
+				log.Warn(ctx, "Skipping disabled duty", nil, z.Any("duty", duty))
+				gatedCounter.WithLabelValues(duty.Type.String()).Inc()
+
+				return
+			}
+
 			instrumentDuty(duty, defSet)
 			dutyCtx := log.WithCtx(ctx, z.Any("duty", duty))
 			dutyCtx, span := core.StartDutyTrace(dutyCtx, duty, "core/scheduler.scheduleSlot")
@@ -224,14 +288,15 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 
 // delaySlotOffset blocks until the slot offset for the duty has been reached and return true.
 // It returns false if the context is cancelled.
-func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, delayFunc delayFunc) bool {
-	fn, ok := slotOffsets[duty.Type]
+func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, offsets *offsets, delayFunc delayFunc) bool {
+	offset, ok := offsets.Offset(duty.Type, slot.SlotDuration)
 	if !ok {
 		return true
 	}
 
+	instrumentOffset(duty.Type, offset)
+
 	// Calculate delay until slot offset
-	offset := fn(slot.SlotDuration)
 	deadline := slot.Time.Add(offset)
 
 	select {
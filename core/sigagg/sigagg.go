@@ -20,7 +20,7 @@ import (
 )
 
 // New returns a new aggregator instance.
-func New(threshold int, verifyFunc func(context.Context, core.PubKey, core.SignedData) error) (*Aggregator, error) {
+func New(threshold int, verifyFunc func(context.Context, core.SignedDataSet) error) (*Aggregator, error) {
 	if threshold <= 0 {
 		return nil, errors.New("invalid threshold", z.Int("threshold", threshold))
 	}
@@ -35,7 +35,7 @@ func New(threshold int, verifyFunc func(context.Context, core.PubKey, core.Signe
 // into an aggregated signed duty data object ready to be broadcasted.
 type Aggregator struct {
 	threshold  int
-	verifyFunc func(context.Context, core.PubKey, core.SignedData) error
+	verifyFunc func(context.Context, core.SignedDataSet) error
 	subs       []func(context.Context, core.Duty, core.SignedDataSet) error
 }
 
@@ -54,7 +54,7 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 
 	output := make(core.SignedDataSet)
 	for pubkey, parSigs := range set {
-		signed, err := a.aggregate(ctx, pubkey, parSigs)
+		signed, err := a.aggregate(ctx, parSigs)
 		if err != nil {
 			return errors.Wrap(err, "threshold aggregate", z.Any("pubkey", pubkey))
 		}
@@ -62,6 +62,11 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 		output[pubkey] = signed
 	}
 
+	// Batch verify all aggregate signatures.
+	if err := a.verifyFunc(ctx, output); err != nil {
+		return err
+	}
+
 	log.Debug(ctx, "Threshold aggregated partial signatures")
 
 	// Call subscriptions.
@@ -81,7 +86,7 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 }
 
 // aggregate threshold aggregates the partial signed data for a provided DV.
-func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, error) {
+func (a *Aggregator) aggregate(ctx context.Context, parSigs []core.ParSignedData) (core.SignedData, error) {
 	if len(parSigs) < a.threshold {
 		return nil, errors.New("require threshold signatures")
 	}
@@ -109,32 +114,33 @@ func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs
 	}
 
 	// Inject signature into one of the parSigs resulting in aggregate signed data.
-	aggSig, err := parSigs[0].SetSignature(tblsconv.SigToCore(sig))
-	if err != nil {
-		return nil, err
-	}
-
-	if err := a.verifyFunc(ctx, pubkey, aggSig); err != nil {
-		return nil, err
-	}
-
-	return aggSig, nil
+	return parSigs[0].SetSignature(tblsconv.SigToCore(sig))
 }
 
 // NewVerifier returns a signature verification function for aggregated signatures.
-func NewVerifier(eth2Cl eth2wrap.Client) func(context.Context, core.PubKey, core.SignedData) error {
-	return func(ctx context.Context, pubkey core.PubKey, data core.SignedData) error {
-		tblsPubkey, err := tblsconv.PubkeyFromCore(pubkey)
-		if err != nil {
-			return errors.Wrap(err, "pubkey from core")
-		}
-
-		eth2Signed, ok := data.(core.Eth2SignedData)
-		if !ok {
-			return errors.New("invalid eth2 signed data")
+// All aggregated signatures of a set are batch verified.
+func NewVerifier(eth2Cl eth2wrap.Client) func(context.Context, core.SignedDataSet) error {
+	return func(ctx context.Context, set core.SignedDataSet) error {
+		var (
+			datas   = make(map[core.PubKey]core.Eth2SignedData)
+			pubkeys = make(map[core.PubKey]tbls.PublicKey)
+		)
+		for pubkey, data := range set {
+			tblsPubkey, err := tblsconv.PubkeyFromCore(pubkey)
+			if err != nil {
+				return errors.Wrap(err, "pubkey from core")
+			}
+
+			eth2Signed, ok := data.(core.Eth2SignedData)
+			if !ok {
+				return errors.New("invalid eth2 signed data")
+			}
+
+			datas[pubkey] = eth2Signed
+			pubkeys[pubkey] = tblsPubkey
 		}
 
-		err = core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, tblsPubkey)
+		err := core.BatchVerifyEth2SignedData(ctx, eth2Cl, datas, pubkeys)
 		if err != nil {
 			return errors.Wrap(err, "aggregate signature verification failed")
 		}
@@ -0,0 +1,16 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package statesync
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var syncedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "core",
+	Subsystem: "statesync",
+	Name:      "synced_total",
+	Help:      "Total number of aggregate signed data and decided values synced from peers by type",
+}, []string{"type"})
@@ -0,0 +1,360 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package statesync provides a protocol allowing a restarted node to sync recent state from its peers.
+// Without it, a node restarted mid-epoch lacks the randao reveals, aggregation selections and decided
+// consensus values that its peers already hold, and can only participate in some duties from the next epoch.
+package statesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	protocolID protocol.ID = "/charon/statesync/1.0.0"
+
+	// syncTimeout is the maximum duration to sync state from peers.
+	syncTimeout = time.Minute
+	// retryPeriod is the period between requests to peers that are not reachable yet.
+	retryPeriod = time.Second
+)
+
+// syncedTypes are the duty types of aggregate signed data synced between peers.
+// These are submitted by validator clients in advance and required by later duties.
+var syncedTypes = map[core.DutyType]bool{
+	core.DutyRandao:                  true,
+	core.DutyPrepareAggregator:       true,
+	core.DutyPrepareSyncContribution: true,
+}
+
+// Protocols returns the supported protocols of this package in order of precedence.
+func Protocols() []protocol.ID {
+	return []protocol.ID{protocolID}
+}
+
+// AggSigDB is the aggregate signature database synced between peers.
+type AggSigDB interface {
+	// Store stores aggregate signed duty data.
+	Store(ctx context.Context, duty core.Duty, set core.SignedDataSet) error
+	// Snapshot returns a snapshot of the aggregate signed data of all duties not yet expired.
+	Snapshot(ctx context.Context) (*pbv1.SignedDataSnapshot, error)
+}
+
+// New returns a new state sync component and registers its protocol handler.
+// Synced aggregate signed data is only stored if signed by the cluster's DV public keys and verified by verifyFunc.
+// Since decided consensus values are not signed, they are only stored if returned by sufficient peers
+// to ensure at least one of them is honest.
+func New(tcpNode host.Host, peers []peer.ID, threshold int, pubkeys []core.PubKey, sendFunc p2p.SendReceiveFunc,
+	aggSigDB AggSigDB, dutyDB core.DutyDB, verifyFunc func(context.Context, core.SignedDataSet) error, deadliner core.Deadliner,
+) *Component {
+	pubkeysByKey := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		pubkeysByKey[pubkey] = true
+	}
+
+	c := &Component{
+		tcpNode:    tcpNode,
+		peers:      peers,
+		quorum:     len(peers) - threshold + 1, // One more than the number of faulty peers tolerated.
+		pubkeys:    pubkeysByKey,
+		sendFunc:   sendFunc,
+		aggSigDB:   aggSigDB,
+		dutyDB:     dutyDB,
+		verifyFunc: verifyFunc,
+		deadliner:  deadliner,
+		decided:    make(map[core.Duty]core.UnsignedDataSet),
+	}
+
+	p2p.RegisterHandler("statesync", tcpNode, protocolID,
+		func() proto.Message { return new(pbv1.StateSyncRequest) },
+		c.handle,
+		p2p.WithSnappyProtocol(protocolID),
+	)
+
+	return c
+}
+
+// Component syncs recent state between peers.
+type Component struct {
+	tcpNode    host.Host
+	peers      []peer.ID
+	quorum     int
+	pubkeys    map[core.PubKey]bool
+	sendFunc   p2p.SendReceiveFunc
+	aggSigDB   AggSigDB
+	dutyDB     core.DutyDB
+	verifyFunc func(context.Context, core.SignedDataSet) error
+	deadliner  core.Deadliner
+
+	mu      sync.Mutex
+	decided map[core.Duty]core.UnsignedDataSet
+}
+
+// Decided stores the decided consensus value of the duty to provide to peers.
+// It is a consensus subscriber.
+func (c *Component) Decided(_ context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+	if !c.deadliner.Add(duty) {
+		return nil // Expired or never expiring duties are not synced.
+	}
+
+	clone, err := set.Clone()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.decided[duty] = clone
+
+	return nil
+}
+
+// Run blocks and trims expired decided values until the context is closed.
+func (c *Component) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case duty := <-c.deadliner.C():
+			c.mu.Lock()
+			delete(c.decided, duty)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// handle returns the local state of duties from the requested slot.
+func (c *Component) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+	syncReq, ok := req.(*pbv1.StateSyncRequest)
+	if !ok {
+		return nil, false, errors.New("invalid state sync request")
+	}
+
+	snapshot, err := c.aggSigDB.Snapshot(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	aggregates := new(pbv1.SignedDataSnapshot)
+	for _, entry := range snapshot.GetEntries() {
+		duty := core.DutyFromProto(entry.GetDuty())
+		if duty.Slot < syncReq.GetFromSlot() || !syncedTypes[duty.Type] {
+			continue
+		}
+
+		aggregates.Entries = append(aggregates.Entries, entry)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &pbv1.StateSyncResponse{Aggregates: aggregates}
+	for duty, set := range c.decided {
+		if duty.Slot < syncReq.GetFromSlot() {
+			continue
+		}
+
+		pb, err := core.UnsignedDataSetToProto(set)
+		if err != nil {
+			return nil, false, err
+		}
+
+		resp.Decided = append(resp.Decided, &pbv1.DecidedValue{
+			Duty:    core.DutyToProto(duty),
+			DataSet: pb,
+		})
+	}
+
+	return resp, true, nil
+}
+
+// Sync requests the state of duties from the provided slot from all peers, retrying unreachable peers until
+// all peers responded or the sync timed out. Verified aggregate signed data is stored in the aggregate signature
+// database as soon as it is received, decided values are stored in the duty database once returned by a quorum of peers.
+func (c *Component) Sync(ctx context.Context, fromSlot uint64) {
+	ctx = log.WithTopic(ctx, "statesync")
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	responses := make(chan *pbv1.StateSyncResponse)
+
+	var requested int
+	for _, p := range c.peers {
+		if p == c.tcpNode.ID() {
+			continue
+		}
+
+		requested++
+		go func() {
+			resp, err := c.request(ctx, p, fromSlot)
+			if err != nil {
+				log.Warn(ctx, "Failed syncing state from peer", err, z.Str("peer", p2p.PeerName(p)))
+			}
+
+			select {
+			case <-ctx.Done():
+			case responses <- resp: // Nil if failed.
+			}
+		}()
+	}
+
+	var (
+		stored        = make(map[core.Duty]map[core.PubKey]bool)
+		decidedCounts = make(map[core.Duty]map[[32]byte]int)
+		aggregates    int
+		decided       int
+	)
+	for range requested {
+		var resp *pbv1.StateSyncResponse
+		select {
+		case <-ctx.Done():
+			log.Warn(ctx, "State sync timed out", ctx.Err())
+			return
+		case resp = <-responses:
+		}
+
+		if resp == nil {
+			continue
+		}
+
+		aggregates += c.storeAggregates(ctx, resp.GetAggregates(), stored)
+		decided += c.storeDecided(ctx, resp.GetDecided(), decidedCounts)
+	}
+
+	log.Info(ctx, "Synced state from peers",
+		z.U64("from_slot", fromSlot),
+		z.Int("aggregates", aggregates),
+		z.Int("decided", decided),
+	)
+}
+
+// request requests the state from the peer, retrying until the peer is reachable or the context is closed.
+func (c *Component) request(ctx context.Context, p peer.ID, fromSlot uint64) (*pbv1.StateSyncResponse, error) {
+	for {
+		resp := new(pbv1.StateSyncResponse)
+		err := c.sendFunc(ctx, c.tcpNode, p, &pbv1.StateSyncRequest{FromSlot: fromSlot}, resp, protocolID,
+			p2p.WithSnappyProtocol(protocolID))
+		if err == nil {
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryPeriod):
+		}
+	}
+}
+
+// storeAggregates verifies and stores the aggregate signed data not stored yet, returning the number stored.
+func (c *Component) storeAggregates(ctx context.Context, snapshot *pbv1.SignedDataSnapshot, stored map[core.Duty]map[core.PubKey]bool) int {
+	sets := make(map[core.Duty]core.SignedDataSet)
+	for _, entry := range snapshot.GetEntries() {
+		duty := core.DutyFromProto(entry.GetDuty())
+		pubkey := core.PubKey(entry.GetPubKey())
+		if !syncedTypes[duty.Type] || !c.pubkeys[pubkey] || stored[duty][pubkey] || len(entry.GetData()) != 1 {
+			continue
+		}
+
+		data, err := core.ParSignedDataFromProto(duty.Type, entry.GetData()[0])
+		if err != nil {
+			log.Warn(ctx, "Invalid synced aggregate signed data", err, z.Any("duty", duty))
+			continue
+		}
+
+		if sets[duty] == nil {
+			sets[duty] = make(core.SignedDataSet)
+		}
+		sets[duty][pubkey] = data.SignedData
+	}
+
+	var count int
+	for duty, set := range sets {
+		if err := c.verifyFunc(ctx, set); err != nil {
+			log.Warn(ctx, "Failed verifying synced aggregate signed data", err, z.Any("duty", duty))
+			continue
+		}
+
+		if err := c.aggSigDB.Store(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Failed storing synced aggregate signed data", err, z.Any("duty", duty))
+			continue
+		}
+
+		if stored[duty] == nil {
+			stored[duty] = make(map[core.PubKey]bool)
+		}
+		for pubkey := range set {
+			stored[duty][pubkey] = true
+		}
+
+		count += len(set)
+		syncedCounter.WithLabelValues("aggregate").Add(float64(len(set)))
+	}
+
+	return count
+}
+
+// storeDecided counts the decided values of a peer and stores those returned by a quorum of peers, returning the number stored.
+func (c *Component) storeDecided(ctx context.Context, values []*pbv1.DecidedValue, counts map[core.Duty]map[[32]byte]int) int {
+	var count int
+	dedup := make(map[core.Duty]bool) // Only count a single value per duty per peer.
+	for _, value := range values {
+		duty := core.DutyFromProto(value.GetDuty())
+		if dedup[duty] {
+			continue
+		}
+		dedup[duty] = true
+
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(value.GetDataSet())
+		if err != nil {
+			log.Warn(ctx, "Invalid synced decided value", err, z.Any("duty", duty))
+			continue
+		}
+
+		if counts[duty] == nil {
+			counts[duty] = make(map[[32]byte]int)
+		}
+
+		hash := sha256.Sum256(b)
+		counts[duty][hash]++
+		if counts[duty][hash] != c.quorum {
+			continue
+		}
+
+		set, err := core.UnsignedDataSetFromProto(duty.Type, value.GetDataSet())
+		if err != nil {
+			log.Warn(ctx, "Invalid synced decided value", err, z.Any("duty", duty))
+			continue
+		}
+
+		if err := c.dutyDB.Store(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Failed storing synced decided value", err, z.Any("duty", duty))
+			continue
+		}
+
+		// Also provide the synced value to other peers.
+		if err := c.Decided(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Failed caching synced decided value", err, z.Any("duty", duty))
+		}
+
+		count++
+		syncedCounter.WithLabelValues("decided").Inc()
+	}
+
+	return count
+}
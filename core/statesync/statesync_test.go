@@ -0,0 +1,158 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package statesync_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/aggsigdb"
+	"github.com/obolnetwork/charon/core/statesync"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestSync(t *testing.T) {
+	const (
+		n         = 4
+		threshold = 3
+		fromSlot  = 8
+		restarted = 0
+		forger    = n - 1 // The last peer returns invalid data.
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		pubkey  = testutil.RandomCorePubKey(t)
+		forged  = testutil.RandomCorePubKey(t)
+		unknown = testutil.RandomCorePubKey(t)
+		randao  = core.NewPartialSignedRandao(1, testutil.RandomEth2Signature(), 0).SignedData
+
+		current  = core.NewRandaoDuty(10)
+		previous = core.NewRandaoDuty(5) // Before fromSlot.
+		attester = core.NewAttesterDuty(10)
+		value    = core.UnsignedDataSet{pubkey: testutil.RandomCoreAttestationData(t)}
+		minority = core.NewAttesterDuty(11)
+	)
+
+	// The verify function rejects the forged pubkey's signatures.
+	verifyFunc := func(_ context.Context, set core.SignedDataSet) error {
+		if _, ok := set[forged]; ok {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+
+	var (
+		hosts     []host.Host
+		peerIDs   []peer.ID
+		aggSigDBs []*aggsigdb.MemDBV2
+		dutyDBs   []*testDutyDB
+		syncs     []*statesync.Component
+	)
+	for range n {
+		h := testutil.CreateHost(t, testutil.AvailableAddr(t))
+		hosts = append(hosts, h)
+		peerIDs = append(peerIDs, h.ID())
+	}
+
+	for i, h := range hosts {
+		for j, other := range hosts {
+			if i != j {
+				h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			}
+		}
+
+		aggSigDB := aggsigdb.NewMemDBV2(testDeadliner{})
+		go aggSigDB.Run(ctx)
+
+		dutyDB := new(testDutyDB)
+		stateSync := statesync.New(h, peerIDs, threshold, []core.PubKey{pubkey, forged}, p2p.SendReceive,
+			aggSigDB, dutyDB, verifyFunc, testDeadliner{})
+
+		aggSigDBs = append(aggSigDBs, aggSigDB)
+		dutyDBs = append(dutyDBs, dutyDB)
+		syncs = append(syncs, stateSync)
+
+		if i == restarted {
+			continue
+		}
+
+		for _, duty := range []core.Duty{current, previous} {
+			require.NoError(t, aggSigDB.Store(ctx, duty, core.SignedDataSet{pubkey: randao, unknown: randao}))
+		}
+
+		decided := value
+		if i == forger {
+			require.NoError(t, aggSigDB.Store(ctx, current, core.SignedDataSet{forged: randao}))
+			decided = core.UnsignedDataSet{pubkey: testutil.RandomCoreAttestationData(t)}
+			require.NoError(t, stateSync.Decided(ctx, minority, decided))
+		}
+		require.NoError(t, stateSync.Decided(ctx, attester, decided))
+	}
+
+	syncs[restarted].Sync(ctx, fromSlot)
+
+	// Only verified aggregate signed data of the cluster's validators from the slot is synced.
+	resp, err := aggSigDBs[restarted].Await(ctx, current, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, randao, resp)
+
+	snapshot, err := aggSigDBs[restarted].Snapshot(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshot.GetEntries(), 1)
+
+	// Only decided values returned by a quorum of peers are synced.
+	stored := dutyDBs[restarted].Stored()
+	require.Len(t, stored, 1)
+	require.Equal(t, value, stored[attester])
+}
+
+// testDutyDB is a duty database that records stored decided values.
+type testDutyDB struct {
+	core.DutyDB
+
+	mu     sync.Mutex
+	stored map[core.Duty]core.UnsignedDataSet
+}
+
+func (db *testDutyDB) Store(_ context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.stored == nil {
+		db.stored = make(map[core.Duty]core.UnsignedDataSet)
+	}
+	db.stored[duty] = set
+
+	return nil
+}
+
+func (db *testDutyDB) Stored() map[core.Duty]core.UnsignedDataSet {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.stored
+}
+
+// testDeadliner is a deadliner that never expires duties.
+type testDeadliner struct{}
+
+func (testDeadliner) Add(core.Duty) bool {
+	return true
+}
+
+func (testDeadliner) C() <-chan core.Duty {
+	return make(chan core.Duty)
+}
@@ -343,8 +343,22 @@ func reportAttInclusion(ctx context.Context, sub submission, block block) {
 	inclusionDelay.Set(float64(blockSlot - attSlot))
 }
 
+// InclusionOption configures the inclusion checker.
+type InclusionOption func(*inclusionCore)
+
+// WithInclusionDelayFunc returns an option that reports the inclusion delay in slots of included attestations and aggregates.
+func WithInclusionDelayFunc(fn func(duty core.Duty, delay uint64)) InclusionOption {
+	return func(i *inclusionCore) {
+		reportFunc := i.attIncludedFunc
+		i.attIncludedFunc = func(ctx context.Context, sub submission, block block) {
+			reportFunc(ctx, sub, block)
+			fn(sub.Duty, block.Slot-sub.Duty.Slot)
+		}
+	}
+}
+
 // NewInclusion returns a new InclusionChecker.
-func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc trackerInclFunc) (*InclusionChecker, error) {
+func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc trackerInclFunc, opts ...InclusionOption) (*InclusionChecker, error) {
 	genesis, err := eth2Cl.GenesisTime(ctx)
 	if err != nil {
 		return nil, err
@@ -367,6 +381,10 @@ func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc t
 		submissions:     make(map[subkey]submission),
 	}
 
+	for _, opt := range opts {
+		opt(inclCore)
+	}
+
 	return &InclusionChecker{
 		core:           inclCore,
 		eth2Cl:         eth2Cl,
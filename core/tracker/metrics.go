@@ -61,6 +61,13 @@ var (
 		Help:      "Total number of failed duties by type and reason code",
 	}, []string{"duty", "reason"})
 
+	vcMissedSignatures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "vc_missed_signatures_total",
+		Help:      "Total number of validators for which the authorised validator client did not submit partial signatures by duty type and validator client",
+	}, []string{"duty", "vc"})
+
 	dutySuccess = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "tracker",
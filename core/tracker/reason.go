@@ -121,6 +121,12 @@ var (
 		Long:  "Reason `no_local_vc_signature` indicates that partial signature we never submitted by the local validator client. This could indicate that the local validator client is offline, or has connection problems with charon, or has some other problem. See validator client logs for more details.",
 	}
 
+	reasonNoVCSignature = reason{
+		Code:  "no_vc_signature",
+		Short: "signed duty not submitted by one of multiple validator clients",
+		Long:  "Reason `no_vc_signature` indicates that partial signatures for some validators were never submitted by the validator client authorised for them, when multiple validator clients are attached to the node. The `vc` log field and metric label identify the validator client. This could indicate that the validator client is offline, or has connection problems with charon, or has some other problem. See that validator client's logs for more details.",
+	}
+
 	reasonNoPeerSignatures = reason{
 		Code:  "no_peer_signatures",
 		Short: "no partial signatures received from peers",
@@ -104,10 +104,37 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]int, unexpectedPeers map[int]int, expectedPerPeer int)
+
+	// vcs contains the names of the validator clients authorised for each validator, empty if not configured.
+	vcs map[core.PubKey]string
+	// vcReporter instruments validators missed by validator clients.
+	vcReporter func(ctx context.Context, duty core.Duty, missed map[string]int)
+
+	// lateDutyFunc is called with duties that succeeded or were late, nil if not configured.
+	lateDutyFunc func(duty core.Duty, late bool)
+}
+
+// Option configures the tracker.
+type Option func(*Tracker)
+
+// WithValidatorClients returns an option that identifies validator clients that
+// did not submit partial signatures for the validators they are authorised for.
+func WithValidatorClients(vcs map[core.PubKey]string) Option {
+	return func(t *Tracker) {
+		t.vcs = vcs
+	}
+}
+
+// WithLateDutyFunc returns an option that reports whether analysed duties were late,
+// i.e., failed at the consensus or chain inclusion step. Duties failing at other steps are not reported.
+func WithLateDutyFunc(fn func(duty core.Duty, late bool)) Option {
+	return func(t *Tracker) {
+		t.lateDutyFunc = fn
+	}
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
-func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64) *Tracker {
+func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64, opts ...Option) *Tracker {
 	t := &Tracker{
 		input:                 make(chan event),
 		events:                make(map[core.Duty][]event),
@@ -118,6 +145,11 @@ func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, from
 		parSigReporter:        reportParSigs,
 		failedDutyReporter:    newFailedDutyReporter(),
 		participationReporter: newParticipationReporter(peers),
+		vcReporter:            reportVCs,
+	}
+
+	for _, opt := range opts {
+		opt(t)
 	}
 
 	return t
@@ -152,15 +184,31 @@ func (t *Tracker) Run(ctx context.Context) error {
 
 			// Analyse failed duties
 			failed, failedStep, reason, failedErr := analyseDutyFailed(duty, t.events, parsigs.MsgRootsConsistent())
+
+			var missedVCs map[string]int
+			if len(t.vcs) > 0 {
+				missedVCs = analyseVCs(duty, t.events, t.vcs)
+				reason = vcReason(reason, missedVCs)
+			}
+
 			if ignoreUnsupported(ctx, duty, failed, failedStep, reason) {
 				continue // Ignore unsupported duties
 			}
 
 			t.failedDutyReporter(ctx, duty, failed, failedStep, reason, failedErr)
 
+			if late := failedStep == consensus || failedStep == chainInclusion; t.lateDutyFunc != nil && (!failed || late) {
+				t.lateDutyFunc(duty, late)
+			}
+
 			// Analyse peer participation
 			participatedShares, unexpectedShares, expectedPerPeer := analyseParticipation(duty, t.events)
 			t.participationReporter(ctx, duty, failed, participatedShares, unexpectedShares, expectedPerPeer)
+
+			// Analyse validator clients
+			if len(t.vcs) > 0 {
+				t.vcReporter(ctx, duty, missedVCs)
+			}
 		case duty := <-t.deleter.C():
 			delete(t.events, duty)
 		}
@@ -491,6 +539,58 @@ func newFailedDutyReporter() func(ctx context.Context, duty core.Duty, failed bo
 	}
 }
 
+// analyseVCs returns the number of validators by validator client name for which duty data was stored in
+// the DutyDB but no partial signatures were submitted by the authorised validator client.
+func analyseVCs(duty core.Duty, allEvents map[core.Duty][]event, vcs map[core.PubKey]string) map[string]int {
+	var (
+		expected  = make(map[core.PubKey]bool)
+		submitted = make(map[core.PubKey]bool)
+	)
+	for _, e := range allEvents[duty] {
+		if e.step == dutyDB && e.stepErr == nil {
+			expected[e.pubkey] = true
+		} else if e.step == parSigDBInternal {
+			submitted[e.pubkey] = true
+		}
+	}
+
+	resp := make(map[string]int)
+	for pubkey := range expected {
+		name, ok := vcs[pubkey]
+		if !ok || submitted[pubkey] {
+			continue
+		}
+
+		resp[name]++
+	}
+
+	return resp
+}
+
+// vcReason returns reasonNoVCSignature instead of reasonNoLocalVCSignature if validator clients
+// attached to the node didn't submit partial signatures for the validators they are authorised for.
+func vcReason(reason reason, missed map[string]int) reason {
+	if reason != reasonNoLocalVCSignature || len(missed) == 0 {
+		return reason
+	}
+
+	return reasonNoVCSignature
+}
+
+// reportVCs instruments validators missed by validator clients.
+func reportVCs(ctx context.Context, duty core.Duty, missed map[string]int) {
+	for name, count := range missed {
+		log.Warn(ctx, "Validator client did not submit partial signatures", nil,
+			z.Str("vc", name),
+			z.Int("validators", count),
+			z.Str("reason", reasonNoVCSignature.Short),
+			z.Str("reason_code", reasonNoVCSignature.Code),
+		)
+
+		vcMissedSignatures.WithLabelValues(duty.Type.String(), name).Add(float64(count))
+	}
+}
+
 // newUnsupportedIgnorer returns a filter that ignores duties that are not inclSupported by the node.
 func newUnsupportedIgnorer() func(ctx context.Context, duty core.Duty, failed bool, step step, reason reason) bool {
 	var (
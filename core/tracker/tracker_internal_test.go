@@ -53,7 +53,10 @@ func TestTrackerFailedDuty(t *testing.T) {
 			}
 		}
 
-		tr := New(analyser, deleter, []p2p.Peer{}, 0)
+		var lates []bool
+		tr := New(analyser, deleter, []p2p.Peer{}, 0, WithLateDutyFunc(func(_ core.Duty, late bool) {
+			lates = append(lates, late)
+		}))
 		tr.failedDutyReporter = failedDutyReporter
 		tr.participationReporter = func(_ context.Context, _ core.Duty, failed bool, _ map[int]int, _ map[int]int, _ int) {
 			require.True(t, failed)
@@ -73,6 +76,7 @@ func TestTrackerFailedDuty(t *testing.T) {
 		}()
 
 		require.ErrorIs(t, tr.Run(ctx), context.Canceled)
+		require.Equal(t, []bool{true}, lates)
 	})
 
 	t.Run("Success", func(t *testing.T) {
@@ -93,7 +97,10 @@ func TestTrackerFailedDuty(t *testing.T) {
 			}
 		}
 
-		tr := New(analyser, deleter, []p2p.Peer{}, 0)
+		var lates []bool
+		tr := New(analyser, deleter, []p2p.Peer{}, 0, WithLateDutyFunc(func(_ core.Duty, late bool) {
+			lates = append(lates, late)
+		}))
 		tr.failedDutyReporter = failedDutyReporter
 		tr.participationReporter = func(_ context.Context, _ core.Duty, failed bool, _ map[int]int, _ map[int]int, _ int) {
 			require.False(t, failed)
@@ -123,6 +130,7 @@ func TestTrackerFailedDuty(t *testing.T) {
 		}()
 
 		require.ErrorIs(t, tr.Run(ctx), context.Canceled)
+		require.Equal(t, []bool{false}, lates)
 	})
 }
 
@@ -1117,6 +1125,39 @@ func TestIsParSigEventExpected(t *testing.T) {
 	}
 }
 
+func TestAnalyseVCs(t *testing.T) {
+	var (
+		duty    = core.NewAttesterDuty(123)
+		pubkeyA = testutil.RandomCorePubKey(t)
+		pubkeyB = testutil.RandomCorePubKey(t)
+		pubkeyC = testutil.RandomCorePubKey(t)
+		pubkeyD = testutil.RandomCorePubKey(t)
+		vcs     = map[core.PubKey]string{
+			pubkeyA: "lighthouse",
+			pubkeyB: "teku",
+			pubkeyC: "teku",
+		}
+	)
+
+	events := map[core.Duty][]event{
+		duty: {
+			{duty: duty, step: dutyDB, pubkey: pubkeyA},
+			{duty: duty, step: dutyDB, pubkey: pubkeyB},
+			{duty: duty, step: dutyDB, pubkey: pubkeyC},
+			{duty: duty, step: dutyDB, pubkey: pubkeyD}, // Not authorised for a specific validator client.
+			{duty: duty, step: parSigDBInternal, pubkey: pubkeyA},
+		},
+	}
+
+	missed := analyseVCs(duty, events, vcs)
+	require.Equal(t, map[string]int{"teku": 2}, missed)
+
+	// Duties failing due to missing validator client signatures identify the validator client.
+	require.Equal(t, reasonNoVCSignature, vcReason(reasonNoLocalVCSignature, missed))
+	require.Equal(t, reasonNoLocalVCSignature, vcReason(reasonNoLocalVCSignature, nil))
+	require.Equal(t, reasonNoConsensus, vcReason(reasonNoConsensus, missed))
+}
+
 func TestAnalyseParSigs(t *testing.T) {
 	t.Run("full block", func(t *testing.T) {
 		analyseParSigs(t, func() core.SignedData {
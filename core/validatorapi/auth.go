@@ -0,0 +1,217 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// Token is a bearer token authorising validator client requests.
+type Token struct {
+	// Name identifies the validator client using the token in metrics and logs, defaults to "token_<index>".
+	Name string `json:"name,omitempty"`
+	// Token is the secret bearer token value.
+	Token string `json:"token"`
+	// PubKeys are the DV root public keys the token is authorised for, empty authorises all validators.
+	PubKeys []core.PubKey `json:"pubkeys,omitempty"`
+}
+
+// LoadTokens returns the bearer tokens defined in the provided json file.
+func LoadTokens(path string) ([]Token, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read tokens file", z.Str("path", path))
+	}
+
+	var tokens []Token
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, errors.Wrap(err, "unmarshal tokens file", z.Str("path", path))
+	}
+
+	var (
+		dedup = make(map[string]bool)
+		names = make(map[string]bool)
+	)
+	for i, token := range tokens {
+		if token.Token == "" {
+			return nil, errors.New("empty token", z.Int("index", i))
+		} else if dedup[token.Token] {
+			return nil, errors.New("duplicate token", z.Int("index", i))
+		}
+		dedup[token.Token] = true
+
+		if token.Name == "" {
+			tokens[i].Name = fmt.Sprintf("token_%d", i)
+		}
+		if names[tokens[i].Name] {
+			return nil, errors.New("duplicate token name", z.Str("name", tokens[i].Name))
+		}
+		names[tokens[i].Name] = true
+
+		for _, pubkey := range token.PubKeys {
+			if _, err := pubkey.Bytes(); err != nil {
+				return nil, errors.Wrap(err, "invalid token pubkey", z.Int("index", i))
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// RouterOption configures the validator API router.
+type RouterOption func(*routerOpts)
+
+// routerOpts contains the optional router configuration.
+type routerOpts struct {
+	tokens         []Token
+	clientCertAuth bool
+	recorder       *recorder.Recorder
+}
+
+// WithTokens returns an option that requires requests to be authenticated
+// with one of the provided bearer tokens.
+func WithTokens(tokens []Token) RouterOption {
+	return func(o *routerOpts) {
+		o.tokens = tokens
+	}
+}
+
+// WithClientCertAuth returns an option that authenticates requests with verified TLS client
+// certificates (mTLS). These clients are authorised for all validators and identified by the certificate common name.
+func WithClientCertAuth() RouterOption {
+	return func(o *routerOpts) {
+		o.clientCertAuth = true
+	}
+}
+
+// WithRecorder returns an option that records all validator client requests
+// and their responses for later replay.
+func WithRecorder(rec *recorder.Recorder) RouterOption {
+	return func(o *routerOpts) {
+		o.recorder = rec
+	}
+}
+
+// authMiddleware returns a middleware that rejects requests that are not authenticated by either
+// a bearer token or a verified TLS client certificate. It populates the request context with the
+// validator client name and its authorised validators. Authentication is disabled if neither is configured.
+func authMiddleware(opts routerOpts) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if len(opts.tokens) == 0 && !opts.clientCertAuth {
+			return next
+		}
+
+		scopes := make([]map[core.PubKey]bool, len(opts.tokens))
+		for i, token := range opts.tokens {
+			if len(token.PubKeys) == 0 {
+				continue // Nil scope authorises all validators.
+			}
+
+			scopes[i] = make(map[core.PubKey]bool)
+			for _, pubkey := range token.PubKeys {
+				scopes[i][pubkey] = true
+			}
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if opts.clientCertAuth && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+				name := r.TLS.VerifiedChains[0][0].Subject.CommonName
+				next.ServeHTTP(w, r.WithContext(withVC(ctx, name)))
+
+				return
+			}
+
+			bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if ok && bearer != "" {
+				for i, token := range opts.tokens {
+					if subtle.ConstantTimeCompare([]byte(bearer), []byte(token.Token)) != 1 {
+						continue
+					}
+
+					ctx = withVC(ctx, token.Name)
+					next.ServeHTTP(w, r.WithContext(withAuthorised(ctx, scopes[i])))
+
+					return
+				}
+			}
+
+			ctx = log.WithTopic(ctx, "vapi")
+			writeError(ctx, w, "authenticate", apiError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "unauthorised",
+			})
+		})
+	}
+}
+
+type (
+	authorisedKey struct{}
+	vcKey         struct{}
+)
+
+// defaultVC is the name of the validator client if requests are not authenticated.
+const defaultVC = "default"
+
+// withVC returns a copy of the context with the authenticated validator client name.
+func withVC(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, vcKey{}, name)
+}
+
+// vcName returns the name of the validator client that submitted the request.
+func vcName(ctx context.Context) string {
+	name, ok := ctx.Value(vcKey{}).(string)
+	if !ok {
+		return defaultVC
+	}
+
+	return name
+}
+
+// withAuthorised returns a copy of the context with the provided validators authorised.
+// A nil scope authorises all validators.
+func withAuthorised(ctx context.Context, scope map[core.PubKey]bool) context.Context {
+	if scope == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, authorisedKey{}, scope)
+}
+
+// isAuthorised returns true if the request context is authorised for the provided validator.
+func isAuthorised(ctx context.Context, pubkey core.PubKey) bool {
+	scope, ok := ctx.Value(authorisedKey{}).(map[core.PubKey]bool)
+	if !ok {
+		return true
+	}
+
+	return scope[pubkey]
+}
+
+// errUnauthorised returns an api error for requests not authorised for the provided validator.
+func errUnauthorised(pubkey core.PubKey) error {
+	return apiError{
+		StatusCode: http.StatusForbidden,
+		Message:    "validator not authorised",
+		Err:        errors.New("validator not authorised", z.Any("pubkey", pubkey)),
+	}
+}
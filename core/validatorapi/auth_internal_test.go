@@ -0,0 +1,147 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRouterAuth(t *testing.T) {
+	var (
+		pubkeyA = testutil.RandomCorePubKey(t)
+		pubkeyB = testutil.RandomCorePubKey(t)
+		tokens  = []Token{
+			{Name: "vc_all", Token: "all"},
+			{Name: "vc_scoped", Token: "scoped", PubKeys: []core.PubKey{pubkeyA}},
+		}
+	)
+
+	type authorised struct {
+		A, B bool
+		VC   string
+	}
+
+	handler := testHandler{
+		NodeVersionFunc: func(ctx context.Context, _ *eth2api.NodeVersionOpts) (*eth2api.Response[string], error) {
+			b, err := json.Marshal(authorised{A: isAuthorised(ctx, pubkeyA), B: isAuthorised(ctx, pubkeyB), VC: vcName(ctx)})
+			if err != nil {
+				return nil, err
+			}
+
+			return wrapResponse(string(b)), nil
+		},
+	}
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		proxied.Add(1)
+	}))
+	defer proxy.Close()
+
+	r, err := NewRouter(context.Background(), handler, testBeaconAddr{addr: proxy.URL}, true, WithTokens(tokens))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	get := func(t *testing.T, path string, token string) (int, authorised) {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := new(http.Client).Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var resp struct {
+			Data struct {
+				Version string `json:"version"`
+			} `json:"data"`
+		}
+		var auth authorised
+		if res.StatusCode == http.StatusOK && path == "/eth/v1/node/version" {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			require.NoError(t, json.Unmarshal([]byte(resp.Data.Version), &auth))
+		}
+
+		return res.StatusCode, auth
+	}
+
+	status, _ := get(t, "/eth/v1/node/version", "")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = get(t, "/eth/v1/node/version", "invalid")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status, auth := get(t, "/eth/v1/node/version", "all")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, authorised{A: true, B: true, VC: "vc_all"}, auth)
+
+	status, auth = get(t, "/eth/v1/node/version", "scoped")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, authorised{A: true, B: false, VC: "vc_scoped"}, auth)
+
+	// Proxied requests are also authenticated.
+	status, _ = get(t, "/eth/v1/node/syncing", "")
+	require.Equal(t, http.StatusUnauthorized, status)
+	require.Zero(t, proxied.Load())
+
+	status, _ = get(t, "/eth/v1/node/syncing", "scoped")
+	require.Equal(t, http.StatusOK, status)
+	require.EqualValues(t, 1, proxied.Load())
+}
+
+func TestVerifyPartialSigUnauthorised(t *testing.T) {
+	pubkeyA := testutil.RandomCorePubKey(t)
+	pubkeyB := testutil.RandomCorePubKey(t)
+
+	ctx := withAuthorised(context.Background(), map[core.PubKey]bool{pubkeyA: true})
+	c := Component{insecureTest: true}
+
+	require.NoError(t, c.verifyPartialSig(ctx, core.ParSignedData{}, pubkeyA))
+
+	err := c.verifyPartialSig(ctx, core.ParSignedData{}, pubkeyB)
+	var aerr apiError
+	require.True(t, errors.As(err, &aerr))
+	require.Equal(t, http.StatusForbidden, aerr.StatusCode)
+}
+
+func TestLoadTokens(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tokens.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	require.NoError(t, os.WriteFile(file, []byte(`[{"token":"a"},{"name":"lighthouse","token":"b","pubkeys":["`+string(pubkey)+`"]}]`), 0o600))
+	tokens, err := LoadTokens(file)
+	require.NoError(t, err)
+	require.Equal(t, []Token{{Name: "token_0", Token: "a"}, {Name: "lighthouse", Token: "b", PubKeys: []core.PubKey{pubkey}}}, tokens)
+
+	require.NoError(t, os.WriteFile(file, []byte(`[{"name":"vc","token":"a"},{"name":"vc","token":"b"}]`), 0o600))
+	_, err = LoadTokens(file)
+	require.ErrorContains(t, err, "duplicate token name")
+
+	require.NoError(t, os.WriteFile(file, []byte(`[{"token":"a"},{"token":"a"}]`), 0o600))
+	_, err = LoadTokens(file)
+	require.ErrorContains(t, err, "duplicate token")
+
+	require.NoError(t, os.WriteFile(file, []byte(`[{"token":"a","pubkeys":["0x1234"]}]`), 0o600))
+	_, err = LoadTokens(file)
+	require.ErrorContains(t, err, "invalid token pubkey")
+}
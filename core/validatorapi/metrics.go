@@ -39,6 +39,27 @@ var (
 		Name:      "vc_user_agent",
 		Help:      "Gauge with label set to user agent string of requests made by VC",
 	}, []string{"user_agent"})
+
+	vcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_request_total",
+		Help:      "The total number of requests per validator client and endpoint",
+	}, []string{"vc", "endpoint"})
+
+	vcSubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_submission_total",
+		Help:      "The total number of partial signatures submitted per validator client and duty",
+	}, []string{"vc", "duty"})
+
+	vcDuplicates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_duplicate_submission_total",
+		Help:      "The total number of partial signatures submitted by a validator client for a duty and validator already submitted by another validator client",
+	}, []string{"vc", "duty"})
 )
 
 func incAPIErrors(endpoint string, statusCode int) {
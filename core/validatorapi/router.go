@@ -38,6 +38,7 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/recorder"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
@@ -84,7 +85,13 @@ type Handler interface {
 // NewRouter returns a new validator http server router. The http router
 // translates http requests related to the distributed validator to the Handler.
 // All other requests are reverse-proxied to the beacon-node address.
-func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled bool) (*mux.Router, error) {
+// Requests are only authenticated if bearer tokens or client certificate authentication are configured.
+func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled bool, opts ...RouterOption) (*mux.Router, error) {
+	var o routerOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Register subset of distributed validator related endpoints.
 	endpoints := []struct {
 		Name    string
@@ -257,6 +264,12 @@ func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEn
 	}
 
 	r := mux.NewRouter()
+	if o.recorder != nil {
+		r.Use(func(next http.Handler) http.Handler {
+			return recorder.NewHTTPHandler(o.recorder, recorder.SourceVC, next)
+		})
+	}
+	r.Use(authMiddleware(o))
 	for _, e := range endpoints {
 		handler := r.Handle(e.Path, wrap(e.Name, e.Handler))
 		if len(e.Methods) != 0 {
@@ -314,6 +327,7 @@ func wrap(endpoint string, handler handlerFunc) http.Handler {
 			return
 		}
 		vcContentType.WithLabelValues(endpoint, string(typ)).Inc()
+		vcRequests.WithLabelValues(vcName(ctx), endpoint).Inc()
 
 		userAgent := r.Header.Get("User-Agent")
 		if userAgent != "" {
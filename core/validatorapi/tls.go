@@ -0,0 +1,155 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// selfSignedValidity is the validity period of generated self-signed certificates.
+const selfSignedValidity = 10 * 365 * 24 * time.Hour
+
+// TLSConfig defines the validator API TLS configuration.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded server certificate and private key files.
+	CertFile string
+	KeyFile  string
+	// SelfSigned generates a self-signed certificate and key to CertFile and KeyFile if they do not exist.
+	SelfSigned bool
+	// ClientCAFile is the PEM encoded CA certificate file used to verify client certificates (mTLS).
+	ClientCAFile string
+	// ClientCertOptional only verifies client certificates if provided, since clients may authenticate otherwise.
+	ClientCertOptional bool
+}
+
+// NewServerTLSConfig returns the validator API server TLS config.
+// It logs the SHA256 fingerprint of the server certificate so validator clients can pin it.
+func NewServerTLSConfig(ctx context.Context, conf TLSConfig) (*tls.Config, error) {
+	if conf.CertFile == "" || conf.KeyFile == "" {
+		return nil, errors.New("validator api tls certificate and key files required")
+	}
+
+	if conf.SelfSigned {
+		if err := maybeGenSelfSigned(ctx, conf.CertFile, conf.KeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load validator api tls key pair")
+	}
+
+	fingerprint := sha256.Sum256(cert.Certificate[0])
+	log.Info(ctx, "Validator API TLS enabled", z.Str("cert_sha256_fingerprint", hex.EncodeToString(fingerprint[:])))
+
+	resp := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if conf.ClientCAFile == "" {
+		return resp, nil
+	}
+
+	caPEM, err := os.ReadFile(conf.ClientCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read client ca file", z.Str("path", conf.ClientCAFile))
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no certificates in client ca file", z.Str("path", conf.ClientCAFile))
+	}
+
+	resp.ClientCAs = pool
+	resp.ClientAuth = tls.RequireAndVerifyClientCert
+	if conf.ClientCertOptional {
+		resp.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return resp, nil
+}
+
+// maybeGenSelfSigned generates a self-signed certificate and private key to the provided files if they do not exist.
+// Existing files are reused so the certificate fingerprint remains stable across restarts.
+func maybeGenSelfSigned(ctx context.Context, certFile, keyFile string) error {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if certErr == nil && keyErr == nil {
+		return nil
+	} else if !errors.Is(certErr, os.ErrNotExist) || !errors.Is(keyErr, os.ErrNotExist) {
+		return errors.New("only one of validator api tls certificate or key file exists",
+			z.Str("cert_file", certFile), z.Str("key_file", keyFile))
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "generate tls key")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Wrap(err, "generate serial number")
+	}
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "charon-validator-api"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return errors.Wrap(err, "create self-signed certificate")
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "marshal tls key")
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil { //nolint:gosec // Certificates are public.
+		return errors.Wrap(err, "write certificate file")
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return errors.Wrap(err, "write key file")
+	}
+
+	log.Info(ctx, "Generated self-signed validator API TLS certificate",
+		z.Str("cert_file", certFile), z.Str("key_file", keyFile))
+
+	return nil
+}
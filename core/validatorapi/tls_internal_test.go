@@ -0,0 +1,69 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestSelfSignedTLS(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	conf := TLSConfig{
+		CertFile:   filepath.Join(dir, "tls.crt"),
+		KeyFile:    filepath.Join(dir, "tls.key"),
+		SelfSigned: true,
+	}
+
+	tlsConf, err := NewServerTLSConfig(ctx, conf)
+	require.NoError(t, err)
+
+	// Restarting reuses the existing certificate.
+	tlsConf2, err := NewServerTLSConfig(ctx, conf)
+	require.NoError(t, err)
+	require.Equal(t, tlsConf.Certificates[0].Certificate, tlsConf2.Certificates[0].Certificate)
+
+	info, err := os.Stat(conf.KeyFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = tlsConf
+	server.StartTLS()
+	defer server.Close()
+
+	// Clients pin the server certificate.
+	cl := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec // Certificate is pinned below.
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if !bytes.Equal(rawCerts[0], tlsConf.Certificates[0].Certificate[0]) {
+				return errors.New("certificate not pinned")
+			}
+
+			return nil
+		},
+	}}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	res, err := cl.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	// Only one of the files existing is an error.
+	require.NoError(t, os.Remove(conf.KeyFile))
+	_, err = NewServerTLSConfig(ctx, conf)
+	require.ErrorContains(t, err, "only one of validator api tls certificate or key file exists")
+}
@@ -169,6 +169,8 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		feeRecipientFunc:   feeRecipientFunc,
 		builderEnabled:     builderEnabled,
 		swallowRegFilter:   log.Filter(),
+		// The submission tracker is the first subscriber, instrumenting all partial signatures submitted by validator clients.
+		subs: []func(context.Context, core.Duty, core.ParSignedDataSet) error{newSubmissionTracker().Submitted},
 	}, nil
 }
 
@@ -1013,21 +1015,26 @@ func (c Component) ProposerDuties(ctx context.Context, opts *eth2api.ProposerDut
 	}
 	duties := eth2Resp.Data
 
-	// Replace root public keys with public shares
-	for i := range len(duties) {
-		if duties[i] == nil {
+	// Replace root public keys with public shares, excluding validators not authorised for this request.
+	resp := make([]*eth2v1.ProposerDuty, 0, len(duties))
+	for _, duty := range duties {
+		if duty == nil {
 			return nil, errors.New("proposer duty cannot be nil")
 		}
 
-		pubshare, ok := c.getPubShareFunc(duties[i].PubKey)
-		if !ok {
-			// Ignore unknown validators since ProposerDuties returns ALL proposers for the epoch if validatorIndices is empty.
+		if !isAuthorised(ctx, core.PubKeyFrom48Bytes(duty.PubKey)) {
 			continue
 		}
-		duties[i].PubKey = pubshare
+
+		pubshare, ok := c.getPubShareFunc(duty.PubKey)
+		if ok {
+			duty.PubKey = pubshare
+		} // Else ignore unknown validators since ProposerDuties returns ALL proposers for the epoch if validatorIndices is empty.
+
+		resp = append(resp, duty)
 	}
 
-	return wrapResponseWithMetadata(duties, eth2Resp.Metadata), nil
+	return wrapResponseWithMetadata(resp, eth2Resp.Metadata), nil
 }
 
 func (c Component) AttesterDuties(ctx context.Context, opts *eth2api.AttesterDutiesOpts) (*eth2api.Response[[]*eth2v1.AttesterDuty], error) {
@@ -1037,20 +1044,27 @@ func (c Component) AttesterDuties(ctx context.Context, opts *eth2api.AttesterDut
 	}
 	duties := eth2Resp.Data
 
-	// Replace root public keys with public shares.
-	for i := range len(duties) {
-		if duties[i] == nil {
+	// Replace root public keys with public shares, excluding validators not authorised for this request.
+	resp := make([]*eth2v1.AttesterDuty, 0, len(duties))
+	for _, duty := range duties {
+		if duty == nil {
 			return nil, errors.New("attester duty cannot be nil")
 		}
 
-		pubshare, ok := c.getPubShareFunc(duties[i].PubKey)
+		if !isAuthorised(ctx, core.PubKeyFrom48Bytes(duty.PubKey)) {
+			continue
+		}
+
+		pubshare, ok := c.getPubShareFunc(duty.PubKey)
 		if !ok {
 			return nil, errors.New("pubshare not found")
 		}
-		duties[i].PubKey = pubshare
+		duty.PubKey = pubshare
+
+		resp = append(resp, duty)
 	}
 
-	return wrapResponseWithMetadata(duties, eth2Resp.Metadata), nil
+	return wrapResponseWithMetadata(resp, eth2Resp.Metadata), nil
 }
 
 // SyncCommitteeDuties obtains sync committee duties. If validatorIndices is nil it will return all duties for the given epoch.
@@ -1061,20 +1075,27 @@ func (c Component) SyncCommitteeDuties(ctx context.Context, opts *eth2api.SyncCo
 	}
 	duties := eth2Resp.Data
 
-	// Replace root public keys with public shares.
-	for i := range len(duties) {
-		if duties[i] == nil {
+	// Replace root public keys with public shares, excluding validators not authorised for this request.
+	resp := make([]*eth2v1.SyncCommitteeDuty, 0, len(duties))
+	for _, duty := range duties {
+		if duty == nil {
 			return nil, errors.New("sync committee duty cannot be nil")
 		}
 
-		pubshare, ok := c.getPubShareFunc(duties[i].PubKey)
+		if !isAuthorised(ctx, core.PubKeyFrom48Bytes(duty.PubKey)) {
+			continue
+		}
+
+		pubshare, ok := c.getPubShareFunc(duty.PubKey)
 		if !ok {
 			return nil, errors.New("pubshare not found")
 		}
-		duties[i].PubKey = pubshare
+		duty.PubKey = pubshare
+
+		resp = append(resp, duty)
 	}
 
-	return wrapResponse(duties), nil
+	return wrapResponse(resp), nil
 }
 
 func (c Component) Validators(ctx context.Context, opts *eth2api.ValidatorsOpts) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
@@ -1211,6 +1232,10 @@ func (c Component) getProposerPubkey(ctx context.Context, duty core.Duty) (core.
 }
 
 func (c Component) verifyPartialSig(ctx context.Context, parSig core.ParSignedData, pubkey core.PubKey) error {
+	if !isAuthorised(ctx, pubkey) {
+		return errUnauthorised(pubkey)
+	}
+
 	if c.insecureTest {
 		return nil
 	}
@@ -0,0 +1,80 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// submissionTrimSlots is the number of slots after which submissions are no longer tracked.
+const submissionTrimSlots = 64
+
+// newSubmissionTracker returns a new submission tracker.
+func newSubmissionTracker() *submissionTracker {
+	return &submissionTracker{
+		vcs: make(map[core.Duty]map[core.PubKey]string),
+	}
+}
+
+// submissionTracker instruments partial signature submissions per validator client
+// and detects validators signed by multiple validator clients.
+type submissionTracker struct {
+	mu      sync.Mutex
+	maxSlot uint64
+	vcs     map[core.Duty]map[core.PubKey]string // Validator client names by pubkey by duty.
+}
+
+// Submitted records the partial signatures submitted by the validator client identified by the context.
+// It always returns nil, since duplicate submissions are only detected, not rejected.
+// It implements the component subscriber function signature.
+func (s *submissionTracker) Submitted(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	name := vcName(ctx)
+	vcSubmissions.WithLabelValues(name, duty.Type.String()).Add(float64(len(set)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trim(duty.Slot)
+
+	vcs, ok := s.vcs[duty]
+	if !ok {
+		vcs = make(map[core.PubKey]string)
+		s.vcs[duty] = vcs
+	}
+
+	for pubkey := range set {
+		prev, ok := vcs[pubkey]
+		if !ok {
+			vcs[pubkey] = name
+			continue
+		} else if prev == name {
+			continue
+		}
+
+		vcDuplicates.WithLabelValues(name, duty.Type.String()).Inc()
+		log.Warn(ctx, "Validator signed by multiple validator clients, ensure each validator is only configured in one validator client", nil,
+			z.Any("duty", duty), z.Any("pubkey", pubkey), z.Str("vc", name), z.Str("previous_vc", prev))
+	}
+
+	return nil
+}
+
+// trim deletes submissions of duties older than submissionTrimSlots before the latest slot.
+// It must be called while holding the lock.
+func (s *submissionTracker) trim(slot uint64) {
+	if slot <= s.maxSlot {
+		return
+	}
+	s.maxSlot = slot
+
+	for duty := range s.vcs {
+		if duty.Slot+submissionTrimSlots < s.maxSlot {
+			delete(s.vcs, duty)
+		}
+	}
+}
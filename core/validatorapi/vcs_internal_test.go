@@ -0,0 +1,41 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestSubmissionTracker(t *testing.T) {
+	var (
+		ctxA    = withVC(context.Background(), "vc_a")
+		ctxB    = withVC(context.Background(), "vc_b")
+		pubkeyA = testutil.RandomCorePubKey(t)
+		pubkeyB = testutil.RandomCorePubKey(t)
+		duty    = core.NewAttesterDuty(1)
+		parSig  = core.NewPartialSignature(testutil.RandomCoreSignature(), 1)
+		dupes   = vcDuplicates.WithLabelValues("vc_b", duty.Type.String())
+	)
+
+	before := promtestutil.ToFloat64(dupes)
+
+	s := newSubmissionTracker()
+	require.NoError(t, s.Submitted(ctxA, duty, core.ParSignedDataSet{pubkeyA: parSig}))
+	require.NoError(t, s.Submitted(ctxA, duty, core.ParSignedDataSet{pubkeyA: parSig})) // Same VC resubmitting.
+	require.NoError(t, s.Submitted(ctxB, duty, core.ParSignedDataSet{pubkeyB: parSig}))
+	require.Zero(t, promtestutil.ToFloat64(dupes)-before)
+
+	require.NoError(t, s.Submitted(ctxB, duty, core.ParSignedDataSet{pubkeyA: parSig}))
+	require.InDelta(t, 1, promtestutil.ToFloat64(dupes)-before, 0)
+
+	// Old duties are trimmed.
+	require.NoError(t, s.Submitted(ctxA, core.NewAttesterDuty(2+submissionTrimSlots), core.ParSignedDataSet{pubkeyA: parSig}))
+	require.Len(t, s.vcs, 1)
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
-	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
 	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/tbls"
@@ -71,7 +70,7 @@ func loadDefinition(ctx context.Context, conf Config) (cluster.Definition, error
 		log.Warn(ctx, "Ignoring failed cluster definition hashes verification due to --no-verify flag", err)
 	}
 
-	if err := def.VerifySignatures(); err != nil && !conf.NoVerify {
+	if err := def.VerifySignatures(conf.verifyOptions(ctx)...); err != nil && !conf.NoVerify {
 		return cluster.Definition{}, errors.Wrap(err, "cluster definition signature verification failed. Run with --no-verify to bypass verification at own risk")
 	} else if err != nil && conf.NoVerify {
 		log.Warn(ctx, "Ignoring failed cluster definition signature verification due to --no-verify flag", err)
@@ -86,7 +85,7 @@ func loadDefinition(ctx context.Context, conf Config) (cluster.Definition, error
 		}
 	}
 
-	if err := deposit.VerifyDepositAmounts(def.DepositAmounts); err != nil {
+	if err := def.VerifyDepositAmounts(); err != nil {
 		return cluster.Definition{}, err
 	}
 
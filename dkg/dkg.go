@@ -20,6 +20,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/peerinfo"
@@ -40,13 +41,14 @@ import (
 )
 
 type Config struct {
-	DefFile       string
-	NoVerify      bool
-	DataDir       string
-	P2P           p2p.Config
-	Log           log.Config
-	ShutdownDelay time.Duration
-	Timeout       time.Duration
+	DefFile            string
+	NoVerify           bool
+	ExecutionClientRPC string
+	DataDir            string
+	P2P                p2p.Config
+	Log                log.Config
+	ShutdownDelay      time.Duration
+	Timeout            time.Duration
 
 	KeymanagerAddr      string
 	KeymanagerAuthToken string
@@ -76,6 +78,16 @@ func (c Config) HasTestConfig() bool {
 	return c.TestConfig.StoreKeysFunc != nil || c.TestConfig.SyncCallback != nil || c.TestConfig.Def != nil || c.TestConfig.TCPNodeCallback != nil
 }
 
+// verifyOptions returns the cluster signature verification options, enabling ERC-1271 contract signature
+// verification if an execution client JSON-RPC endpoint is configured.
+func (c Config) verifyOptions(ctx context.Context) []cluster.VerifyOption {
+	if c.ExecutionClientRPC == "" {
+		return nil
+	}
+
+	return []cluster.VerifyOption{cluster.WithContractSigVerifier(ctx, eth1wrap.NewClient(c.ExecutionClientRPC))}
+}
+
 // Run executes a dkg ceremony and writes secret share keystore and cluster lock files as output to disk.
 //
 //nolint:maintidx // Refactor into smaller steps.
@@ -250,7 +262,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	} else {
 		depositAmounts = deposit.DedupAmounts(depositAmounts)
 	}
-	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx, depositAmounts)
+	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.ValidatorAddresses, network, nodeIdx, depositAmounts)
 	if err != nil {
 		return err
 	}
@@ -310,7 +322,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	if !conf.NoVerify {
-		if err := lock.VerifySignatures(); err != nil {
+		if err := lock.VerifySignatures(conf.verifyOptions(ctx)...); err != nil {
 			return errors.Wrap(err, "invalid lock file")
 		}
 	}
@@ -604,13 +616,13 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 
 // signAndAggDepositData returns the deposit datas for each DV after signing, exchange and aggregation of partial signatures.
 func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share,
-	withdrawalAddresses []string, network string,
+	vaddrs []cluster.ValidatorAddresses, network string,
 	nodeIdx cluster.NodeIdx, depositAmounts []eth2p0.Gwei,
 ) ([][]eth2p0.DepositData, error) {
 	var depositDataForAmounts [][]eth2p0.DepositData
 
 	for i, amount := range depositAmounts {
-		parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, withdrawalAddresses, network, amount)
+		parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, vaddrs, network, amount)
 		if err != nil {
 			return nil, err
 		}
@@ -722,11 +734,12 @@ func signLockHash(shareIdx int, shares []share, hash []byte) (core.ParSignedData
 }
 
 // signDepositMsgs returns a partially signed dataset containing signatures of the deposit message signing root.
-func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string, network string, amount eth2p0.Gwei) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
+// Compounding validators sign deposit messages with '0x02' withdrawal credentials.
+func signDepositMsgs(shares []share, shareIdx int, vaddrs []cluster.ValidatorAddresses, network string, amount eth2p0.Gwei) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
 	msgs := make(map[core.PubKey]eth2p0.DepositMessage)
 	set := make(core.ParSignedDataSet)
 	for i, share := range shares {
-		withdrawalHex, err := eth2util.ChecksumAddress(withdrawalAddresses[i])
+		withdrawalHex, err := eth2util.ChecksumAddress(vaddrs[i].WithdrawalAddress)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -740,7 +753,7 @@ func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string,
 			return nil, nil, err
 		}
 
-		msg, err := deposit.NewMessage(pubkey, withdrawalHex, amount)
+		msg, err := deposit.NewMessage(pubkey, withdrawalHex, amount, vaddrs[i].Compounding)
 		if err != nil {
 			return nil, nil, err
 		}
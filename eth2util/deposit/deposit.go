@@ -34,9 +34,15 @@ var (
 	// Maximum allowed deposit amount (32ETH).
 	MaxDepositAmount = eth2p0.Gwei(32000000000)
 
+	// Maximum allowed deposit amount for compounding validators (2048ETH).
+	MaxCompoundingDepositAmount = eth2p0.Gwei(2048000000000)
+
 	// https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/validator.md#eth1_address_withdrawal_prefix
 	eth1AddressWithdrawalPrefix = []byte{0x01}
 
+	// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#withdrawal-prefixes
+	compoundingWithdrawalPrefix = []byte{0x02}
+
 	// DOMAIN_DEPOSIT. See spec: https://benjaminion.xyz/eth2-annotated-spec/phase0/beacon-chain/#domain-types
 	depositDomainType = eth2p0.DomainType([4]byte{0x03, 0x00, 0x00, 0x00})
 
@@ -45,8 +51,9 @@ var (
 )
 
 // NewMessage returns a deposit message created using the provided parameters.
-func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gwei) (eth2p0.DepositMessage, error) {
-	creds, err := withdrawalCredsFromAddr(withdrawalAddr)
+// Compounding validators use '0x02' withdrawal credentials and allow amounts up to 2048ETH.
+func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gwei, compounding bool) (eth2p0.DepositMessage, error) {
+	creds, err := WithdrawalCredsFromAddr(withdrawalAddr, compounding)
 	if err != nil {
 		return eth2p0.DepositMessage{}, err
 	}
@@ -55,7 +62,9 @@ func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gw
 		return eth2p0.DepositMessage{}, errors.New("deposit message minimum amount must be >= 1ETH", z.U64("amount", uint64(amount)))
 	}
 
-	if amount > MaxDepositAmount {
+	if compounding && amount > MaxCompoundingDepositAmount {
+		return eth2p0.DepositMessage{}, errors.New("compounding deposit message maximum amount must <= 2048ETH", z.U64("amount", uint64(amount)))
+	} else if !compounding && amount > MaxDepositAmount {
 		return eth2p0.DepositMessage{}, errors.New("deposit message maximum amount must <= 32ETH", z.U64("amount", uint64(amount)))
 	}
 
@@ -149,11 +158,6 @@ func getDepositDomain(forkVersion eth2p0.Version) (eth2p0.Domain, error) {
 
 // GetMessageSigningRoot returns the deposit message signing root created by the provided parameters.
 func GetMessageSigningRoot(msg eth2p0.DepositMessage, network string) ([32]byte, error) {
-	msgRoot, err := msg.HashTreeRoot()
-	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "deposit message root")
-	}
-
 	fv, err := eth2util.NetworkToForkVersionBytes(network)
 	if err != nil {
 		return [32]byte{}, err
@@ -162,6 +166,17 @@ func GetMessageSigningRoot(msg eth2p0.DepositMessage, network string) ([32]byte,
 	var forkVersion eth2p0.Version
 	copy(forkVersion[:], fv)
 
+	return GetMessageSigningRootByForkVersion(msg, forkVersion)
+}
+
+// GetMessageSigningRootByForkVersion returns the deposit message signing root for the provided genesis fork version.
+// Unlike GetMessageSigningRoot, it supports custom networks, e.g. devnets, not known by name.
+func GetMessageSigningRootByForkVersion(msg eth2p0.DepositMessage, forkVersion eth2p0.Version) ([32]byte, error) {
+	msgRoot, err := msg.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "deposit message root")
+	}
+
 	domain, err := getDepositDomain(forkVersion)
 	if err != nil {
 		return [32]byte{}, err
@@ -175,8 +190,9 @@ func GetMessageSigningRoot(msg eth2p0.DepositMessage, network string) ([32]byte,
 	return resp, nil
 }
 
-// withdrawalCredsFromAddr returns the Withdrawal Credentials corresponding to a '0x01' Ethereum withdrawal address.
-func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
+// WithdrawalCredsFromAddr returns the Withdrawal Credentials corresponding to a '0x01' Ethereum withdrawal address,
+// or to a '0x02' Ethereum withdrawal address if compounding is true.
+func WithdrawalCredsFromAddr(addr string, compounding bool) ([32]byte, error) {
 	// Check for validity of address.
 	if _, err := eth2util.ChecksumAddress(addr); err != nil {
 		return [32]byte{}, errors.Wrap(err, "invalid withdrawal address", z.Str("addr", addr))
@@ -187,9 +203,14 @@ func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
 		return [32]byte{}, errors.Wrap(err, "decode address")
 	}
 
+	prefix := eth1AddressWithdrawalPrefix
+	if compounding {
+		prefix = compoundingWithdrawalPrefix
+	}
+
 	var creds [32]byte
-	copy(creds[0:], eth1AddressWithdrawalPrefix) // Add 1 byte prefix.
-	copy(creds[12:], addrBytes)                  // Add 20 bytes of ethereum address suffix.
+	copy(creds[0:], prefix)     // Add 1 byte prefix.
+	copy(creds[12:], addrBytes) // Add 20 bytes of ethereum address suffix.
 
 	return creds, nil
 }
@@ -208,7 +229,8 @@ type depositDataJSON struct {
 }
 
 // VerifyDepositAmounts verifies various conditions about partial deposits rules.
-func VerifyDepositAmounts(amounts []eth2p0.Gwei) error {
+// Partial deposit amounts must sum up to 32ETH, or to between 32ETH and 2048ETH for compounding validators.
+func VerifyDepositAmounts(amounts []eth2p0.Gwei, compounding bool) error {
 	if len(amounts) == 0 {
 		// If no partial amounts specified, the implementation shall default to 32ETH.
 		return nil
@@ -223,7 +245,11 @@ func VerifyDepositAmounts(amounts []eth2p0.Gwei) error {
 		sum += amount
 	}
 
-	if sum != MaxDepositAmount {
+	if compounding {
+		if sum < MaxDepositAmount || sum > MaxCompoundingDepositAmount {
+			return errors.New("sum of compounding partial deposit amounts must be between 32ETH and 2048ETH", z.U64("sum", uint64(sum)))
+		}
+	} else if sum != MaxDepositAmount {
 		return errors.New("sum of partial deposit amounts must sum up to 32ETH", z.U64("sum", uint64(sum)))
 	}
 
@@ -11,10 +11,16 @@ import (
 
 func TestWithdrawalCredentials(t *testing.T) {
 	expectedWithdrawalCreds := "010000000000000000000000c0404ed740a69d11201f5ed297c5732f562c6e4e"
-	creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e")
+	creds, err := WithdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", false)
 	require.NoError(t, err)
 
 	credsHex := hex.EncodeToString(creds[:])
 
 	require.Equal(t, expectedWithdrawalCreds, credsHex)
+
+	expectedCompoundingCreds := "020000000000000000000000c0404ed740a69d11201f5ed297c5732f562c6e4e"
+	creds, err = WithdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", true)
+	require.NoError(t, err)
+
+	require.Equal(t, expectedCompoundingCreds, hex.EncodeToString(creds[:]))
 }
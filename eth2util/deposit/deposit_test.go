@@ -27,23 +27,35 @@ func TestNewMessage(t *testing.T) {
 	amount := deposit.MaxDepositAmount
 	_, pubKey := GetKeys(t, privKey)
 
-	msg, err := deposit.NewMessage(pubKey, addr, amount)
+	msg, err := deposit.NewMessage(pubKey, addr, amount, false)
 
 	require.NoError(t, err)
 	require.Equal(t, pubKey, msg.PublicKey)
 	require.Equal(t, amount, msg.Amount)
 
 	t.Run("amount below minimum", func(t *testing.T) {
-		_, err := deposit.NewMessage(pubKey, addr, deposit.MinDepositAmount-1)
+		_, err := deposit.NewMessage(pubKey, addr, deposit.MinDepositAmount-1, false)
 
 		require.ErrorContains(t, err, "deposit message minimum amount must be >= 1ETH")
 	})
 
 	t.Run("amount above maximum", func(t *testing.T) {
-		_, err := deposit.NewMessage(pubKey, addr, deposit.MaxDepositAmount+1)
+		_, err := deposit.NewMessage(pubKey, addr, deposit.MaxDepositAmount+1, false)
 
 		require.ErrorContains(t, err, "deposit message maximum amount must <= 32ETH")
 	})
+
+	t.Run("compounding", func(t *testing.T) {
+		msg, err := deposit.NewMessage(pubKey, addr, deposit.MaxCompoundingDepositAmount, true)
+
+		require.NoError(t, err)
+		require.Equal(t, byte(0x02), msg.WithdrawalCredentials[0])
+		require.Equal(t, deposit.MaxCompoundingDepositAmount, msg.Amount)
+
+		_, err = deposit.NewMessage(pubKey, addr, deposit.MaxCompoundingDepositAmount+1, true)
+
+		require.ErrorContains(t, err, "compounding deposit message maximum amount must <= 2048ETH")
+	})
 }
 
 func TestMarshalDepositData(t *testing.T) {
@@ -76,7 +88,7 @@ func GetKeys(t *testing.T, privKey string) (tbls.PrivateKey, eth2p0.BLSPubKey) {
 
 func TestVerifyDepositAmounts(t *testing.T) {
 	t.Run("empty slice", func(t *testing.T) {
-		err := deposit.VerifyDepositAmounts(nil)
+		err := deposit.VerifyDepositAmounts(nil, false)
 
 		require.NoError(t, err)
 	})
@@ -87,7 +99,7 @@ func TestVerifyDepositAmounts(t *testing.T) {
 			eth2p0.Gwei(16000000000),
 		}
 
-		err := deposit.VerifyDepositAmounts(amounts)
+		err := deposit.VerifyDepositAmounts(amounts, false)
 
 		require.NoError(t, err)
 	})
@@ -98,7 +110,7 @@ func TestVerifyDepositAmounts(t *testing.T) {
 			eth2p0.Gwei(31500000000), // 31.5ETH
 		}
 
-		err := deposit.VerifyDepositAmounts(amounts)
+		err := deposit.VerifyDepositAmounts(amounts, false)
 
 		require.ErrorContains(t, err, "each partial deposit amount must be greater than 1ETH")
 	})
@@ -109,7 +121,7 @@ func TestVerifyDepositAmounts(t *testing.T) {
 			eth2p0.Gwei(32000000000),
 		}
 
-		err := deposit.VerifyDepositAmounts(amounts)
+		err := deposit.VerifyDepositAmounts(amounts, false)
 
 		require.ErrorContains(t, err, "sum of partial deposit amounts must sum up to 32ETH")
 
@@ -118,10 +130,38 @@ func TestVerifyDepositAmounts(t *testing.T) {
 			eth2p0.Gwei(16000000000),
 		}
 
-		err = deposit.VerifyDepositAmounts(amounts)
+		err = deposit.VerifyDepositAmounts(amounts, false)
 
 		require.ErrorContains(t, err, "sum of partial deposit amounts must sum up to 32ETH")
 	})
+
+	t.Run("compounding", func(t *testing.T) {
+		amounts := []eth2p0.Gwei{
+			eth2p0.Gwei(32000000000),
+			eth2p0.Gwei(1000000000000),
+		}
+
+		err := deposit.VerifyDepositAmounts(amounts, true)
+
+		require.NoError(t, err)
+
+		amounts = []eth2p0.Gwei{
+			eth2p0.Gwei(16000000000),
+		}
+
+		err = deposit.VerifyDepositAmounts(amounts, true)
+
+		require.ErrorContains(t, err, "sum of compounding partial deposit amounts must be between 32ETH and 2048ETH")
+
+		amounts = []eth2p0.Gwei{
+			eth2p0.Gwei(2048000000000),
+			eth2p0.Gwei(1000000000),
+		}
+
+		err = deposit.VerifyDepositAmounts(amounts, true)
+
+		require.ErrorContains(t, err, "sum of compounding partial deposit amounts must be between 32ETH and 2048ETH")
+	})
 }
 
 func TestEthsToGweis(t *testing.T) {
@@ -237,7 +277,7 @@ func mustGenerateDepositDatas(t *testing.T, amount eth2p0.Gwei) []eth2p0.Deposit
 	for i := range len(privKeys) {
 		sk, pk := GetKeys(t, privKeys[i])
 
-		msg, err := deposit.NewMessage(pk, withdrawalAddrs[i], amount)
+		msg, err := deposit.NewMessage(pk, withdrawalAddrs[i], amount, false)
 		require.NoError(t, err)
 
 		sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
@@ -0,0 +1,175 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package elrequest provides functions to create execution layer triggerable withdrawal requests (EIP-7002)
+// and consolidation requests (EIP-7251) as transactions that can be submitted by a withdrawal address.
+package elrequest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// WithdrawalRequestAddress is the EIP-7002 withdrawal request predeploy contract address.
+	// See https://eips.ethereum.org/EIPS/eip-7002#configuration.
+	WithdrawalRequestAddress = "0x00000961Ef480Eb55e80D19ad83579A64c007002"
+
+	// ConsolidationRequestAddress is the EIP-7251 consolidation request predeploy contract address.
+	// See https://eips.ethereum.org/EIPS/eip-7251#execution-layer.
+	ConsolidationRequestAddress = "0x0000BBdDc7CE488642fb579F8B00f3a590007251"
+
+	// MinRequestFee is the minimum request fee in wei, which applies if the request queue isn't congested.
+	MinRequestFee = 1
+
+	// safeTxBuilderVersion is the Safe transaction builder version of the generated batch files.
+	safeTxBuilderVersion = "1.16.5"
+)
+
+// Type is the type of execution layer request.
+type Type string
+
+const (
+	TypeWithdrawal    Type = "withdrawal"
+	TypeConsolidation Type = "consolidation"
+)
+
+// Request is an execution layer request transaction.
+type Request struct {
+	// Type is the type of the request.
+	Type Type
+	// PubKey is the validator (source) public key.
+	PubKey eth2p0.BLSPubKey
+	// TargetPubKey is the target validator public key of consolidation requests.
+	TargetPubKey eth2p0.BLSPubKey
+	// Amount is the withdrawal amount in gwei of withdrawal requests, zero indicates a full exit.
+	Amount eth2p0.Gwei
+	// Fee is the request fee in wei sent as transaction value.
+	Fee uint64
+}
+
+// NewWithdrawal returns a withdrawal request for the provided validator.
+// A zero amount requests a full exit, otherwise a partial withdrawal of amount gwei.
+func NewWithdrawal(pubkey eth2p0.BLSPubKey, amount eth2p0.Gwei, fee uint64) Request {
+	return Request{
+		Type:   TypeWithdrawal,
+		PubKey: pubkey,
+		Amount: amount,
+		Fee:    fee,
+	}
+}
+
+// NewConsolidation returns a consolidation request of the source validator into the target validator.
+// Identical source and target validators request switching to compounding withdrawal credentials.
+func NewConsolidation(source, target eth2p0.BLSPubKey, fee uint64) Request {
+	return Request{
+		Type:         TypeConsolidation,
+		PubKey:       source,
+		TargetPubKey: target,
+		Fee:          fee,
+	}
+}
+
+// IsFullExit returns true if the request is a full exit withdrawal request.
+func (r Request) IsFullExit() bool {
+	return r.Type == TypeWithdrawal && r.Amount == 0
+}
+
+// IsSwitchToCompounding returns true if the request is a switch to compounding consolidation request.
+func (r Request) IsSwitchToCompounding() bool {
+	return r.Type == TypeConsolidation && r.PubKey == r.TargetPubKey
+}
+
+// To returns the predeploy contract address the request is sent to.
+func (r Request) To() string {
+	if r.Type == TypeConsolidation {
+		return ConsolidationRequestAddress
+	}
+
+	return WithdrawalRequestAddress
+}
+
+// Data returns the request transaction calldata.
+// Withdrawal requests are encoded as pubkey (48 bytes) followed by the big-endian amount (8 bytes).
+// Consolidation requests are encoded as source pubkey (48 bytes) followed by target pubkey (48 bytes).
+func (r Request) Data() []byte {
+	if r.Type == TypeConsolidation {
+		return append(append([]byte(nil), r.PubKey[:]...), r.TargetPubKey[:]...)
+	}
+
+	return binary.BigEndian.AppendUint64(append([]byte(nil), r.PubKey[:]...), uint64(r.Amount))
+}
+
+// String returns a human-readable description of the request.
+func (r Request) String() string {
+	switch {
+	case r.IsFullExit():
+		return fmt.Sprintf("Full exit of validator %#x", r.PubKey)
+	case r.Type == TypeWithdrawal:
+		return fmt.Sprintf("Partial withdrawal of %d gwei from validator %#x", r.Amount, r.PubKey)
+	case r.IsSwitchToCompounding():
+		return fmt.Sprintf("Switch validator %#x to compounding withdrawal credentials", r.PubKey)
+	default:
+		return fmt.Sprintf("Consolidation of validator %#x into validator %#x", r.PubKey, r.TargetPubKey)
+	}
+}
+
+// SafeBatch is a Safe transaction builder batch file which can be imported into a Safe multisig wallet.
+type SafeBatch struct {
+	Version      string            `json:"version"`
+	ChainID      string            `json:"chainId"`
+	CreatedAt    int64             `json:"createdAt"`
+	Meta         SafeMeta          `json:"meta"`
+	Transactions []SafeTransaction `json:"transactions"`
+}
+
+// SafeMeta is the metadata of a Safe transaction builder batch file.
+type SafeMeta struct {
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	TxBuilderVersion       string `json:"txBuilderVersion"`
+	CreatedFromSafeAddress string `json:"createdFromSafeAddress"`
+}
+
+// SafeTransaction is a transaction of a Safe transaction builder batch file.
+// The transactions contain raw calldata since the predeploy contracts don't define an ABI.
+type SafeTransaction struct {
+	To                   string `json:"to"`
+	Value                string `json:"value"`
+	Data                 string `json:"data"`
+	ContractMethod       any    `json:"contractMethod"`
+	ContractInputsValues any    `json:"contractInputsValues"`
+}
+
+// NewSafeBatch returns a Safe transaction builder batch containing the provided requests.
+func NewSafeBatch(chainID uint64, safeAddress string, createdAt time.Time, reqs []Request) SafeBatch {
+	var (
+		txs   []SafeTransaction
+		descs []string
+	)
+	for _, req := range reqs {
+		txs = append(txs, SafeTransaction{
+			To:    req.To(),
+			Value: strconv.FormatUint(req.Fee, 10),
+			Data:  fmt.Sprintf("%#x", req.Data()),
+		})
+		descs = append(descs, req.String())
+	}
+
+	return SafeBatch{
+		Version:   "1.0",
+		ChainID:   strconv.FormatUint(chainID, 10),
+		CreatedAt: createdAt.UnixMilli(),
+		Meta: SafeMeta{
+			Name:                   "Charon execution layer requests",
+			Description:            strings.Join(descs, "; "),
+			TxBuilderVersion:       safeTxBuilderVersion,
+			CreatedFromSafeAddress: safeAddress,
+		},
+		Transactions: txs,
+	}
+}
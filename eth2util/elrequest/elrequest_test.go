@@ -0,0 +1,83 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package elrequest_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/elrequest"
+)
+
+func TestWithdrawalData(t *testing.T) {
+	pubkey := eth2p0.BLSPubKey{0xaa, 47: 0xbb}
+
+	full := elrequest.NewWithdrawal(pubkey, 0, elrequest.MinRequestFee)
+	require.True(t, full.IsFullExit())
+	require.Equal(t, elrequest.WithdrawalRequestAddress, full.To())
+	require.Len(t, full.Data(), 56)
+	require.Equal(t, pubkey[:], full.Data()[:48])
+	require.Equal(t, make([]byte, 8), full.Data()[48:])
+
+	partial := elrequest.NewWithdrawal(pubkey, 1000000000, elrequest.MinRequestFee)
+	require.False(t, partial.IsFullExit())
+	require.Equal(t, "000000003b9aca00", hex.EncodeToString(partial.Data()[48:]))
+}
+
+func TestConsolidationData(t *testing.T) {
+	source := eth2p0.BLSPubKey{0x01}
+	target := eth2p0.BLSPubKey{0x02}
+
+	req := elrequest.NewConsolidation(source, target, elrequest.MinRequestFee)
+	require.False(t, req.IsSwitchToCompounding())
+	require.Equal(t, elrequest.ConsolidationRequestAddress, req.To())
+	require.Len(t, req.Data(), 96)
+	require.Equal(t, source[:], req.Data()[:48])
+	require.Equal(t, target[:], req.Data()[48:])
+
+	require.True(t, elrequest.NewConsolidation(source, source, elrequest.MinRequestFee).IsSwitchToCompounding())
+}
+
+func TestSafeBatch(t *testing.T) {
+	reqs := []elrequest.Request{
+		elrequest.NewWithdrawal(eth2p0.BLSPubKey{0x01}, 0, 3),
+		elrequest.NewConsolidation(eth2p0.BLSPubKey{0x02}, eth2p0.BLSPubKey{0x02}, 1),
+	}
+
+	const safe = "0xc0404ed740a69d11201f5ed297c5732f562c6e4e"
+	batch := elrequest.NewSafeBatch(17000, safe, time.UnixMilli(1700000000000), reqs)
+
+	b, err := json.Marshal(batch)
+	require.NoError(t, err)
+
+	var resp struct {
+		ChainID   string `json:"chainId"`
+		CreatedAt int64  `json:"createdAt"`
+		Meta      struct {
+			CreatedFromSafeAddress string `json:"createdFromSafeAddress"`
+		} `json:"meta"`
+		Transactions []struct {
+			To    string `json:"to"`
+			Value string `json:"value"`
+			Data  string `json:"data"`
+		} `json:"transactions"`
+	}
+	require.NoError(t, json.Unmarshal(b, &resp))
+
+	require.Equal(t, "17000", resp.ChainID)
+	require.EqualValues(t, 1700000000000, resp.CreatedAt)
+	require.Equal(t, safe, resp.Meta.CreatedFromSafeAddress)
+	require.Len(t, resp.Transactions, 2)
+	require.Equal(t, elrequest.WithdrawalRequestAddress, resp.Transactions[0].To)
+	require.Equal(t, "3", resp.Transactions[0].Value)
+	require.True(t, strings.HasPrefix(resp.Transactions[0].Data, "0x01"))
+	require.Len(t, resp.Transactions[0].Data, 2+56*2)
+	require.Equal(t, elrequest.ConsolidationRequestAddress, resp.Transactions[1].To)
+	require.Len(t, resp.Transactions[1].Data, 2+96*2)
+}
@@ -14,6 +14,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"slices"
 	"strings"
 	"testing"
 
@@ -292,6 +293,36 @@ func KeysharesToValidatorPubkey(cl *manifestpb.Cluster, shares []tbls.PrivateKey
 	return ret, nil
 }
 
+// ArchivedKeysharesToValidatorPubkey maps each archived share to the associated archived validator in cl,
+// i.e. a validator removed from the cluster. It returns an error if a keyshare does not belong to an archived validator.
+func ArchivedKeysharesToValidatorPubkey(cl *manifestpb.Cluster, shares []tbls.PrivateKey) (ValidatorShares, error) {
+	ret := make(map[core.PubKey]IndexedKeyShare)
+
+	for shareIdx, share := range shares {
+		pubShare, err := tbls.SecretToPublicKey(share)
+		if err != nil {
+			return nil, errors.Wrap(err, "private share to public share")
+		}
+
+		idx := slices.IndexFunc(cl.GetArchivedValidators(), func(val *manifestpb.Validator) bool {
+			return slices.ContainsFunc(val.GetPubShares(), func(valShare []byte) bool {
+				return tbls.PublicKey(valShare) == pubShare
+			})
+		})
+		if idx < 0 {
+			return nil, errors.New("public key share from provided private key share not found in archived validators")
+		}
+
+		valHex := fmt.Sprintf("0x%x", cl.GetArchivedValidators()[idx].GetPublicKey())
+		ret[core.PubKey(valHex)] = IndexedKeyShare{
+			Share: share,
+			Index: shareIdx + 1,
+		}
+	}
+
+	return ret, nil
+}
+
 // ShareIdxForCluster returns the share index for the Charon cluster's ENR identity key, given a *manifestpb.Cluster.
 func ShareIdxForCluster(cl *manifestpb.Cluster, identityKey k1.PublicKey) (uint64, error) {
 	pids, err := manifest.ClusterPeerIDs(cl)
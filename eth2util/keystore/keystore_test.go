@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -380,6 +382,29 @@ func TestKeyshareToValidatorPubkey(t *testing.T) {
 	}
 }
 
+func TestArchivedKeyshareToValidatorPubkey(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	lock, _, shares := cluster.NewForT(t, 3, 3, 4, 0, random)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	cl, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	// Archive the second validator.
+	cl.ArchivedValidators = append(cl.ArchivedValidators, cl.GetValidators()[1])
+	cl.Validators = slices.Delete(cl.Validators, 1, 2)
+
+	ret, err := keystore.ArchivedKeysharesToValidatorPubkey(cl, []tbls.PrivateKey{shares[1][2]})
+	require.NoError(t, err)
+	require.Len(t, ret, 1)
+	require.Equal(t, shares[1][2], ret[core.PubKey(lock.Validators[1].PublicKeyHex())].Share)
+
+	_, err = keystore.ArchivedKeysharesToValidatorPubkey(cl, []tbls.PrivateKey{shares[0][2]})
+	require.ErrorContains(t, err, "not found in archived validators")
+}
+
 func TestShareIdxForCluster(t *testing.T) {
 	valAmt := 100
 	operatorAmt := 4
@@ -99,17 +99,33 @@ func Verify(ctx context.Context, eth2Cl eth2wrap.Client, domain DomainName, epoc
 	ctx, span := tracer.Start(ctx, "eth2util.Verify")
 	defer span.End()
 
-	sigData, err := GetDataRoot(ctx, eth2Cl, domain, epoch, sigRoot)
+	set, err := NewVerifySet(ctx, eth2Cl, domain, epoch, sigRoot, signature, pubkey)
 	if err != nil {
 		return err
 	}
 
+	span.AddEvent("tbls.Verify")
+
+	return tbls.Verify(set.PublicKey, set.Data, set.Signature)
+}
+
+// NewVerifySet returns the signature set of the eth2 domain signed root for batch verification, see tbls.BatchVerify.
+func NewVerifySet(ctx context.Context, eth2Cl eth2wrap.Client, domain DomainName, epoch eth2p0.Epoch, sigRoot eth2p0.Root,
+	signature eth2p0.BLSSignature, pubkey tbls.PublicKey,
+) (tbls.VerifySet, error) {
+	sigData, err := GetDataRoot(ctx, eth2Cl, domain, epoch, sigRoot)
+	if err != nil {
+		return tbls.VerifySet{}, err
+	}
+
 	var zeroSig eth2p0.BLSSignature
 	if signature == zeroSig {
-		return errors.New("no signature found")
+		return tbls.VerifySet{}, errors.New("no signature found")
 	}
 
-	span.AddEvent("tbls.Verify")
-
-	return tbls.Verify(pubkey, sigData[:], tbls.Signature(signature))
+	return tbls.VerifySet{
+		PublicKey: pubkey,
+		Data:      sigData[:],
+		Signature: tbls.Signature(signature),
+	}, nil
 }
@@ -79,6 +79,12 @@ var (
 		Name:      "peer_network_sent_bytes_total",
 		Help:      "Total number of network bytes sent to the peer by protocol.",
 	}, []string{"peer", "protocol"})
+
+	compressionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "compression_sent_bytes_total",
+		Help:      "Total number of message bytes sent by compressed protocols by protocol and type ('compressed' or 'uncompressed').",
+	}, []string{"protocol", "type"})
 )
 
 func observePing(p peer.ID, d time.Duration) {
@@ -91,6 +97,11 @@ func incPingError(p peer.ID) {
 	pingSuccess.WithLabelValues(PeerName(p)).Set(0)
 }
 
+func observeCompression(pID protocol.ID, uncompressed, compressed int) {
+	compressionCounter.WithLabelValues(string(pID), "uncompressed").Add(float64(uncompressed))
+	compressionCounter.WithLabelValues(string(pID), "compressed").Add(float64(compressed))
+}
+
 var _ metrics.Reporter = bandwithReporter{}
 
 // WithBandwidthReporter returns a libp2p option that enables bandwidth reporting via prometheus.
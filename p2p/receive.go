@@ -45,6 +45,14 @@ func RegisterHandler(logTopic string, tcpNode host.Host, pID protocol.ID,
 		opt(&o)
 	}
 
+	for _, pID := range o.protocols {
+		registerReplayHandler(tcpNode, pID, replayHandler{
+			logTopic:    logTopic,
+			zeroReq:     zeroReq,
+			handlerFunc: handlerFunc,
+		})
+	}
+
 	matchProtocol := func(pID protocol.ID) bool {
 		return o.readersByProtocol[pID] != nil
 	}
@@ -89,6 +97,8 @@ func RegisterHandler(logTopic string, tcpNode host.Host, pID protocol.ID,
 			return
 		}
 
+		recordReceived(ctx, tcpNode, s.Protocol(), s.Conn().RemotePeer(), req)
+
 		resp, ok, err := handlerFunc(ctx, s.Conn().RemotePeer(), req)
 		if err != nil {
 			log.Error(ctx, "LibP2P handle stream error", err, z.Any("duration", time.Since(t0)))
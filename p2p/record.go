@@ -0,0 +1,115 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/protonil"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+var (
+	recordMu       sync.RWMutex
+	recorders      = make(map[peer.ID]*recorder.Recorder)
+	replayHandlers = make(map[peer.ID]map[protocol.ID]replayHandler)
+)
+
+// replayHandler is a handler registered via RegisterHandler that replayed messages are delivered to.
+type replayHandler struct {
+	logTopic    string
+	zeroReq     func() proto.Message
+	handlerFunc HandlerFunc
+}
+
+// SetRecorder records all inbound messages received by handlers registered via RegisterHandler on the host.
+func SetRecorder(tcpNode host.Host, rec *recorder.Recorder) {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+
+	recorders[tcpNode.ID()] = rec
+}
+
+// EnableReplay enables replaying recorded inbound messages to handlers subsequently registered
+// via RegisterHandler on the host.
+func EnableReplay(tcpNode host.Host) {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+
+	replayHandlers[tcpNode.ID()] = make(map[protocol.ID]replayHandler)
+}
+
+// Replay delivers the recorded inbound message to the handler registered on the host for its protocol
+// as if received from the recorded peer. Responses are discarded.
+func Replay(ctx context.Context, tcpNode host.Host, rec recorder.Record) error {
+	recordMu.RLock()
+	handler, ok := replayHandlers[tcpNode.ID()][protocol.ID(rec.Target)]
+	recordMu.RUnlock()
+	if !ok {
+		return errors.New("no handler registered for protocol", z.Str("protocol", rec.Target))
+	}
+
+	peerID, err := peer.Decode(rec.Peer)
+	if err != nil {
+		return errors.Wrap(err, "decode peer id")
+	}
+
+	req := handler.zeroReq()
+	if err := proto.Unmarshal(rec.Body, req); err != nil {
+		return errors.Wrap(err, "unmarshal recorded message")
+	} else if err := protonil.Check(req); err != nil {
+		return errors.Wrap(err, "invalid recorded message")
+	}
+
+	ctx = log.WithTopic(ctx, handler.logTopic)
+	ctx = log.WithCtx(ctx, z.Str("peer", PeerName(peerID)), z.Str("protocol", rec.Target))
+
+	_, _, err = handler.handlerFunc(ctx, peerID, req)
+
+	return err
+}
+
+// registerReplayHandler registers the handler for the protocol if replay is enabled for the host.
+func registerReplayHandler(tcpNode host.Host, pID protocol.ID, handler replayHandler) {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+
+	if handlers, ok := replayHandlers[tcpNode.ID()]; ok {
+		handlers[pID] = handler
+	}
+}
+
+// recordReceived records the inbound message if a recorder is set for the host.
+func recordReceived(ctx context.Context, tcpNode host.Host, pID protocol.ID, peerID peer.ID, req proto.Message) {
+	recordMu.RLock()
+	rec, ok := recorders[tcpNode.ID()]
+	recordMu.RUnlock()
+	if !ok {
+		return
+	}
+
+	b, err := proto.Marshal(req)
+	if err != nil {
+		log.Warn(ctx, "Failed marshalling received message for recording", err)
+		return
+	}
+
+	err = rec.Record(recorder.Record{
+		Source: recorder.SourceP2P,
+		Target: string(pID),
+		Peer:   peerID.String(),
+		Body:   b,
+	})
+	if err != nil {
+		log.Warn(ctx, "Failed recording received message", err)
+	}
+}
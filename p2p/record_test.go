@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/recorder"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRecordReplay(t *testing.T) {
+	var (
+		pID      = protocol.ID("record")
+		ctx      = context.Background()
+		path     = filepath.Join(t.TempDir(), "record")
+		server   = testutil.CreateHost(t, testutil.AvailableAddr(t))
+		client   = testutil.CreateHost(t, testutil.AvailableAddr(t))
+		replayed = testutil.CreateHost(t, testutil.AvailableAddr(t))
+	)
+
+	client.Peerstore().AddAddrs(server.ID(), server.Addrs(), peerstore.PermanentAddrTTL)
+
+	rec, err := recorder.New(path)
+	require.NoError(t, err)
+	p2p.SetRecorder(server, rec)
+
+	received := make(chan *pbv1.Duty, 1)
+	handler := func(_ context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
+		require.Equal(t, client.ID(), peerID)
+		duty, ok := req.(*pbv1.Duty)
+		require.True(t, ok)
+		received <- duty
+
+		return duty, true, nil
+	}
+	zeroReq := func() proto.Message { return new(pbv1.Duty) }
+
+	p2p.RegisterHandler("server", server, pID, zeroReq, handler)
+
+	err = p2p.SendReceive(ctx, client, server.ID(), &pbv1.Duty{Slot: 99}, new(pbv1.Duty), pID)
+	require.NoError(t, err)
+	<-received
+	require.NoError(t, rec.Close())
+
+	records, err := recorder.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, recorder.SourceP2P, records[0].Source)
+	require.Equal(t, string(pID), records[0].Target)
+	require.Equal(t, client.ID().String(), records[0].Peer)
+
+	// Replay is only supported for handlers registered after enabling it.
+	require.ErrorContains(t, p2p.Replay(ctx, replayed, records[0]), "no handler registered")
+
+	p2p.EnableReplay(replayed)
+	p2p.RegisterHandler("replayed", replayed, pID, zeroReq, handler)
+
+	require.NoError(t, p2p.Replay(ctx, replayed, records[0]))
+	require.EqualValues(t, 99, (<-received).GetSlot())
+}
@@ -4,6 +4,7 @@ package p2p
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -228,6 +229,18 @@ func WithDelimitedProtocol(pID protocol.ID) func(*sendRecvOpts) {
 	}
 }
 
+// WithSnappyProtocol returns an option that adds a length delimited snappy compressed read/writer for the provided protocol.
+// Adding it in front of the default protocol results in compression being negotiated with peers that support it.
+func WithSnappyProtocol(pID protocol.ID) func(*sendRecvOpts) {
+	return func(opts *sendRecvOpts) {
+		if !slices.Contains(opts.protocols, pID) {
+			opts.protocols = append([]protocol.ID{pID}, opts.protocols...) // Add to front
+		}
+		opts.writersByProtocol[pID] = func(s network.Stream) pbio.Writer { return newSnappyWriter(s, pID) }
+		opts.readersByProtocol[pID] = func(s network.Stream) pbio.Reader { return newSnappyReader(s, maxMsgSize) }
+	}
+}
+
 // SetFuzzerDefaultsUnsafe sets default reader and writer functions to fuzzed versions of the same if p2p fuzz is enabled.
 //
 // The fuzzReaderWriter is responsible for creating a customized reader and writer for each network stream
@@ -3,6 +3,7 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"sync"
 	"testing"
@@ -157,3 +158,21 @@ func TestNonZeroResponse(t *testing.T) {
 	err := SendReceive(ctx, nil, "", nil, &pbv1.Duty{Slot: 1}, "")
 	require.ErrorContains(t, err, "bug: response proto must be zero value")
 }
+
+func TestSnappyReadWriter(t *testing.T) {
+	msg := &pbv1.ParSignedData{Data: make([]byte, 1024), Signature: []byte("signature")}
+
+	var buf bytes.Buffer
+	require.NoError(t, newSnappyWriter(&buf, "test").WriteMsg(msg))
+	require.Less(t, buf.Len(), proto.Size(msg))
+
+	compressed := buf.Bytes()
+
+	resp := new(pbv1.ParSignedData)
+	require.NoError(t, newSnappyReader(bytes.NewReader(compressed), proto.Size(msg)).ReadMsg(resp))
+	require.True(t, proto.Equal(msg, resp))
+
+	// Messages decompressing beyond the max size are rejected.
+	err := newSnappyReader(bytes.NewReader(compressed), proto.Size(msg)-1).ReadMsg(resp)
+	require.ErrorContains(t, err, "snappy decoded message too large")
+}
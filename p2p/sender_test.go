@@ -213,3 +213,67 @@ func testSend(t *testing.T, clientBasicProtoID, serverBasicProtoID, delimitedID
 		require.NoError(t, <-serverErrChan)
 	})
 }
+
+func TestSendSnappy(t *testing.T) {
+	var (
+		basicID  = protocol.ID("basic")
+		snappyID = protocol.ID("snappy")
+	)
+
+	tests := []struct {
+		name         string
+		snappyClient bool
+		snappyServer bool
+	}{
+		{
+			name:         "snappy client and server",
+			snappyClient: true,
+			snappyServer: true,
+		},
+		{
+			name:         "snappy client and basic server",
+			snappyClient: true,
+			snappyServer: false,
+		},
+		{
+			name:         "basic client and snappy server",
+			snappyClient: false,
+			snappyServer: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx    = context.Background()
+				server = testutil.CreateHost(t, testutil.AvailableAddr(t))
+				client = testutil.CreateHost(t, testutil.AvailableAddr(t))
+			)
+
+			var serverOpt, clientOpt []p2p.SendRecvOption
+			if test.snappyServer {
+				serverOpt = append(serverOpt, p2p.WithSnappyProtocol(snappyID))
+			}
+			if test.snappyClient {
+				clientOpt = append(clientOpt, p2p.WithSnappyProtocol(snappyID))
+			}
+
+			client.Peerstore().AddAddrs(server.ID(), server.Addrs(), peerstore.PermanentAddrTTL)
+
+			// A large compressible message.
+			msg := &pbv1.ParSignedData{Data: make([]byte, 1<<16), Signature: testutil.RandomBytes96()}
+
+			received := make(chan proto.Message, 1)
+			p2p.RegisterHandler("server", server, basicID,
+				func() proto.Message { return new(pbv1.ParSignedData) },
+				func(_ context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+					received <- req
+					return nil, false, nil
+				},
+				serverOpt...,
+			)
+
+			require.NoError(t, p2p.Send(ctx, client, basicID, server.ID(), msg, clientOpt...))
+			testutil.RequireProtoEqual(t, msg, <-received)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-msgio"
+	"github.com/libp2p/go-msgio/pbio"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+var (
+	_ pbio.Reader = snappyReader{}
+	_ pbio.Writer = snappyWriter{}
+)
+
+// newSnappyWriter returns a pbio.Writer that writes length delimited snappy compressed protobufs.
+func newSnappyWriter(w io.Writer, pID protocol.ID) snappyWriter {
+	return snappyWriter{
+		w:   msgio.NewVarintWriter(w),
+		pID: pID,
+	}
+}
+
+// snappyWriter implements pbio.Writer writing length delimited snappy compressed protobufs.
+type snappyWriter struct {
+	w   msgio.Writer
+	pID protocol.ID
+}
+
+// WriteMsg marshals, compresses and writes the message.
+func (w snappyWriter) WriteMsg(msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal proto")
+	}
+
+	compressed := snappy.Encode(nil, b)
+	observeCompression(w.pID, len(b), len(compressed))
+
+	return w.w.WriteMsg(compressed)
+}
+
+// newSnappyReader returns a pbio.Reader that reads length delimited snappy compressed protobufs
+// of at most maxSize uncompressed bytes.
+func newSnappyReader(r io.Reader, maxSize int) snappyReader {
+	return snappyReader{
+		r:       msgio.NewVarintReaderSize(r, snappy.MaxEncodedLen(maxSize)),
+		maxSize: maxSize,
+	}
+}
+
+// snappyReader implements pbio.Reader reading length delimited snappy compressed protobufs.
+type snappyReader struct {
+	r       msgio.ReadCloser
+	maxSize int
+}
+
+// ReadMsg reads, decompresses and unmarshals the message.
+func (r snappyReader) ReadMsg(msg proto.Message) error {
+	compressed, err := r.r.ReadMsg()
+	if err != nil {
+		return err //nolint:wrapcheck // Wrapping breaks error type checks of callers.
+	}
+	defer r.r.ReleaseMsg(compressed)
+
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return errors.Wrap(err, "snappy decoded length")
+	} else if size > r.maxSize {
+		return errors.New("snappy decoded message too large", z.Int("size", size), z.Int("max", r.maxSize))
+	}
+
+	b, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return errors.Wrap(err, "snappy decode")
+	}
+
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return errors.Wrap(err, "unmarshal proto")
+	}
+
+	return nil
+}
@@ -3,13 +3,16 @@
 package tbls
 
 import (
+	"crypto/rand"
 	"io"
+	"runtime"
 	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
@@ -341,6 +344,196 @@ func (Herumi) VerifyAggregate(publicShares []PublicKey, signature Signature, dat
 	return nil
 }
 
+// batchVerifyScalarBytes is the size of the random scalars used in BatchVerify. 64 bit scalars
+// bound the probability of an invalid batch passing verification by 2^-64, while being much
+// cheaper to multiply by than full width scalars.
+const batchVerifyScalarBytes = 8
+
+// BatchVerify verifies all signature sets at once by checking a random linear combination of them:
+// e(g1, sum(r_i*sig_i)) == prod(e(r_i*pk_i, H(msg_i))), which holds with overwhelming probability only if all sets are valid.
+// Sets signing the same message share a single hash and pairing, and the work is spread over all available CPUs.
+func (Herumi) BatchVerify(sets []VerifySet) error {
+	if len(sets) == 0 {
+		return errors.New("no signature sets to verify")
+	}
+
+	randBytes := make([]byte, len(sets)*batchVerifyScalarBytes)
+	if _, err := rand.Read(randBytes); err != nil {
+		return errors.Wrap(err, "read random scalars")
+	}
+
+	// Group sets by message, so each message is only hashed and paired once.
+	var (
+		groupIdx = make(map[string]int)
+		groups   [][]int
+	)
+	for i, set := range sets {
+		idx, ok := groupIdx[string(set.Data)]
+		if !ok {
+			idx = len(groups)
+			groupIdx[string(set.Data)] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], i)
+	}
+
+	var (
+		pubkeys = make([]bls.G1, len(sets))
+		sigs    = make([]bls.G2, len(sets))
+		randoms = make([]bls.Fr, len(sets))
+	)
+	err := forEachParallel(len(sets), func(i int) error {
+		// Copy the arrays since cgo doesn't allow passing pointers into structs containing Go pointers.
+		pubkey, sig := sets[i].PublicKey, sets[i].Signature
+
+		var pk bls.PublicKey
+		if err := pk.Deserialize(pubkey[:]); err != nil {
+			return errors.Wrap(err, "cannot set compressed public key in Herumi format")
+		} else if pk.IsZero() {
+			return errors.New("zero public key")
+		}
+
+		var s bls.Sign
+		if err := s.Deserialize(sig[:]); err != nil {
+			return errors.Wrap(err, "cannot unmarshal signature into Herumi signature")
+		}
+
+		scalar := randBytes[i*batchVerifyScalarBytes : (i+1)*batchVerifyScalarBytes]
+		if isZero(scalar) {
+			scalar[0] = 1 // Zero scalars would exclude the set from the check.
+		}
+		if err := randoms[i].SetLittleEndian(scalar); err != nil {
+			return errors.Wrap(err, "set random scalar")
+		}
+
+		pubkeys[i] = *bls.CastFromPublicKey(&pk)
+		sigs[i] = *bls.CastFromSign(&s)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pair the random linear combination of public keys of each message with the hash of the message.
+	var (
+		g1s = make([]bls.G1, len(groups)+1)
+		g2s = make([]bls.G2, len(groups)+1)
+	)
+	_ = forEachParallel(len(groups), func(i int) error {
+		var (
+			groupPubkeys = make([]bls.G1, 0, len(groups[i]))
+			groupRandoms = make([]bls.Fr, 0, len(groups[i]))
+		)
+		for _, idx := range groups[i] {
+			groupPubkeys = append(groupPubkeys, pubkeys[idx])
+			groupRandoms = append(groupRandoms, randoms[idx])
+		}
+
+		bls.G1MulVec(&g1s[i], groupPubkeys, groupRandoms)
+		g2s[i] = *bls.CastFromSign(bls.HashAndMapToSignature(sets[groups[i][0]].Data))
+
+		return nil
+	})
+
+	// Pair the negated generator with the random linear combination of signatures.
+	var gen bls.PublicKey
+	bls.GetGeneratorOfPublicKey(&gen)
+	bls.G1Neg(&g1s[len(groups)], bls.CastFromPublicKey(&gen))
+	g2s[len(groups)] = g2MulVecParallel(sigs, randoms)
+
+	if !finalExpIsOne(millerLoopVecParallel(g1s, g2s)) {
+		return errors.New("batch signature verification failed")
+	}
+
+	return nil
+}
+
+// g2MulVecParallel returns the multi-scalar multiplication of the points by the scalars, split over all available CPUs.
+func g2MulVecParallel(points []bls.G2, scalars []bls.Fr) bls.G2 {
+	chunks := parallelChunks(len(points))
+	results := make([]bls.G2, len(chunks))
+	_ = forEachParallel(len(chunks), func(i int) error {
+		bls.G2MulVec(&results[i], points[chunks[i][0]:chunks[i][1]], scalars[chunks[i][0]:chunks[i][1]])
+		return nil
+	})
+
+	resp := results[0]
+	for i := 1; i < len(results); i++ {
+		bls.G2Add(&resp, &resp, &results[i])
+	}
+
+	return resp
+}
+
+// millerLoopVecParallel returns the product of the Miller loops of the point pairs, split over all available CPUs.
+func millerLoopVecParallel(g1s []bls.G1, g2s []bls.G2) bls.GT {
+	chunks := parallelChunks(len(g1s))
+	results := make([]bls.GT, len(chunks))
+	_ = forEachParallel(len(chunks), func(i int) error {
+		bls.MillerLoopVec(&results[i], g1s[chunks[i][0]:chunks[i][1]], g2s[chunks[i][0]:chunks[i][1]])
+		return nil
+	})
+
+	resp := results[0]
+	for i := 1; i < len(results); i++ {
+		bls.GTMul(&resp, &resp, &results[i])
+	}
+
+	return resp
+}
+
+// finalExpIsOne returns true if the final exponentiation of the Miller loop result is one.
+func finalExpIsOne(e bls.GT) bool {
+	bls.FinalExp(&e, &e)
+	return e.IsOne()
+}
+
+// parallelChunks returns the [start, end) ranges splitting n items evenly over all available CPUs.
+func parallelChunks(n int) [][2]int {
+	count := min(n, runtime.GOMAXPROCS(0))
+
+	var resp [][2]int
+	for i := range count {
+		resp = append(resp, [2]int{i * n / count, (i + 1) * n / count})
+	}
+
+	return resp
+}
+
+// forEachParallel calls fn for each index in [0, n) using all available CPUs.
+// It returns the first error returned by fn, if any.
+func forEachParallel(n int, fn func(i int) error) error {
+	var (
+		eg    errgroup.Group
+		chunk = parallelChunks(n)
+	)
+	for _, c := range chunk {
+		eg.Go(func() error {
+			for i := c[0]; i < c[1]; i++ {
+				if err := fn(i); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	return eg.Wait() //nolint:wrapcheck // Errors are already wrapped by fn.
+}
+
+// isZero returns true if all bytes are zero.
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 // generateInsecureSecret generates a secret that is not cryptographically secure using the
 // provided random number generator. This is useful for testing.
 func generateInsecureSecret(t *testing.T, random io.Reader) (bls.SecretKey, error) {
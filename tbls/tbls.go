@@ -22,6 +22,13 @@ type (
 
 	// Signature is a byte slice containing a BLS12-381 signature.
 	Signature [96]byte
+
+	// VerifySet is a signature of data by the private key associated with the public key, see BatchVerify.
+	VerifySet struct {
+		PublicKey PublicKey
+		Data      []byte
+		Signature Signature
+	}
 )
 
 // Implementation defines the backing implementation for all the public functions of this package.
@@ -66,6 +73,11 @@ type Implementation interface {
 	// Aggregate combines signs in a single Signature with standard BLS signature aggregation,
 	// as defined by the standard: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-03#section-2.8.
 	Aggregate(signs []Signature) (Signature, error)
+
+	// BatchVerify verifies all the signature sets at once by verifying a random linear combination of them,
+	// which is significantly faster than verifying each signature individually.
+	// It returns an error if any of the signatures is invalid, without identifying which.
+	BatchVerify(sets []VerifySet) error
 }
 
 // SetImplementation sets newImpl as the package backing implementation.
@@ -139,3 +151,10 @@ func VerifyAggregate(shares []PublicKey, signature Signature, data []byte) error
 func Aggregate(signs []Signature) (Signature, error) {
 	return impl.Aggregate(signs)
 }
+
+// BatchVerify verifies all the signature sets at once by verifying a random linear combination of them,
+// which is significantly faster than verifying each signature individually.
+// It returns an error if any of the signatures is invalid, without identifying which.
+func BatchVerify(sets []VerifySet) error {
+	return impl.BatchVerify(sets)
+}
@@ -4,10 +4,13 @@ package tbls_test
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/obolnetwork/charon/tbls"
@@ -166,6 +169,55 @@ func (ts *TestSuite) Test_VerifyAggregate() {
 	ts.Require().NoError(tbls.VerifyAggregate(pshares, sig, data))
 }
 
+func (ts *TestSuite) Test_BatchVerify() {
+	newSet := func(data []byte) tbls.VerifySet {
+		secret, err := tbls.GenerateSecretKey()
+		ts.Require().NoError(err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		ts.Require().NoError(err)
+
+		sig, err := tbls.Sign(secret, data)
+		ts.Require().NoError(err)
+
+		return tbls.VerifySet{
+			PublicKey: pubkey,
+			Data:      data,
+			Signature: sig,
+		}
+	}
+
+	var sets []tbls.VerifySet
+	for i := range 20 {
+		data := make([]byte, 32)
+		data[0] = byte(i)
+		sets = append(sets, newSet(data))
+	}
+
+	ts.Require().NoError(tbls.BatchVerify(sets))
+
+	// A single invalid signature fails the batch.
+	invalid := append([]tbls.VerifySet(nil), sets...)
+	invalid[7].Signature = sets[8].Signature
+	ts.Require().Error(tbls.BatchVerify(invalid))
+
+	// Messages of other lengths are also supported.
+	ts.Require().NoError(tbls.BatchVerify(append(sets, newSet([]byte("arbitrary length message")))))
+
+	// Sets signing the same data are grouped, swapped signatures still fail the batch.
+	sameData := append([]tbls.VerifySet(nil), sets...)
+	for range 5 {
+		sameData = append(sameData, newSet([]byte("same data")))
+	}
+	ts.Require().NoError(tbls.BatchVerify(sameData))
+
+	n := len(sameData)
+	sameData[n-1].Signature, sameData[n-2].Signature = sameData[n-2].Signature, sameData[n-1].Signature
+	ts.Require().Error(tbls.BatchVerify(sameData))
+
+	ts.Require().Error(tbls.BatchVerify(nil))
+}
+
 func runSuite(t *testing.T, i tbls.Implementation) {
 	t.Helper()
 	ts := NewTestSuite(i)
@@ -199,6 +251,7 @@ func runBenchmark(b *testing.B, impl tbls.Implementation) {
 		s.Test_Verify()
 		s.Test_Sign()
 		s.Test_VerifyAggregate()
+		s.Test_BatchVerify()
 	}
 }
 
@@ -206,6 +259,62 @@ func BenchmarkHerumiImplementation(b *testing.B) {
 	runBenchmark(b, tbls.Herumi{})
 }
 
+func BenchmarkVerify(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		var sets, sameDataSets []tbls.VerifySet
+		for i := range n {
+			secret, err := tbls.GenerateSecretKey()
+			require.NoError(b, err)
+
+			pubkey, err := tbls.SecretToPublicKey(secret)
+			require.NoError(b, err)
+
+			data := make([]byte, 32)
+			binary.BigEndian.PutUint64(data, uint64(i))
+
+			sig, err := tbls.Sign(secret, data)
+			require.NoError(b, err)
+
+			sets = append(sets, tbls.VerifySet{PublicKey: pubkey, Data: data, Signature: sig})
+
+			// Partial signatures of a duty by different shares sign the same data.
+			sameData := []byte("same data")
+			sig, err = tbls.Sign(secret, sameData)
+			require.NoError(b, err)
+
+			sameDataSets = append(sameDataSets, tbls.VerifySet{PublicKey: pubkey, Data: sameData, Signature: sig})
+		}
+
+		b.Run(fmt.Sprintf("individual_%d", n), func(b *testing.B) {
+			for range b.N {
+				for _, set := range sets {
+					require.NoError(b, tbls.Verify(set.PublicKey, set.Data, set.Signature))
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batch_%d", n), func(b *testing.B) {
+			for range b.N {
+				require.NoError(b, tbls.BatchVerify(sets))
+			}
+		})
+
+		b.Run(fmt.Sprintf("individual_same_data_%d", n), func(b *testing.B) {
+			for range b.N {
+				for _, set := range sameDataSets {
+					require.NoError(b, tbls.Verify(set.PublicKey, set.Data, set.Signature))
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batch_same_data_%d", n), func(b *testing.B) {
+			for range b.N {
+				require.NoError(b, tbls.BatchVerify(sameDataSets))
+			}
+		})
+	}
+}
+
 func TestRandomized(t *testing.T) {
 	runSuite(t, randomizedImpl{
 		implementations: []tbls.Implementation{
@@ -338,6 +447,15 @@ func (r randomizedImpl) Aggregate(signs []tbls.Signature) (tbls.Signature, error
 	return impl.Aggregate(signs)
 }
 
+func (r randomizedImpl) BatchVerify(sets []tbls.VerifySet) error {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return err
+	}
+
+	return impl.BatchVerify(sets)
+}
+
 func FuzzRandomImplementations(f *testing.F) {
 	f.Fuzz(func(t *testing.T, _ byte) {
 		TestRandomized(t)
@@ -542,6 +542,14 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		NodePeerCountFunc: func(context.Context) (int, error) {
 			return 80, nil
 		},
+		ValidatorLivenessFunc: func(_ context.Context, _ eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+			var resp []*eth2wrap.ValidatorLiveness
+			for _, index := range indices {
+				resp = append(resp, &eth2wrap.ValidatorLiveness{Index: index})
+			}
+
+			return resp, nil
+		},
 		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 			return attStore.NewAttestationData(ctx, slot, index)
 		},
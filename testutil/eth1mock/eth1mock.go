@@ -0,0 +1,155 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package eth1mock provides an in-process mock execution layer JSON-RPC server
+// supporting ERC-1271 isValidSignature calls to registered contracts.
+package eth1mock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+// VerifyFunc returns true if sig is a valid contract signature of hash.
+type VerifyFunc func(hash [32]byte, sig []byte) bool
+
+// Mock is a mock execution layer JSON-RPC server.
+type Mock struct {
+	mu        sync.Mutex
+	srv       *httptest.Server
+	chainID   uint64
+	contracts map[string]VerifyFunc
+}
+
+// New returns a new started mock execution layer JSON-RPC server of the chain with the provided chain ID.
+func New(chainID uint64) *Mock {
+	m := &Mock{chainID: chainID, contracts: make(map[string]VerifyFunc)}
+	m.srv = httptest.NewServer(http.HandlerFunc(m.handle))
+
+	return m
+}
+
+// Address returns the JSON-RPC endpoint of the mock.
+func (m *Mock) Address() string {
+	return m.srv.URL
+}
+
+// Close stops the mock server.
+func (m *Mock) Close() {
+	m.srv.Close()
+}
+
+// AddContract registers an ERC-1271 contract at the provided address.
+func (m *Mock) AddContract(address string, verify VerifyFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.contracts[strings.ToLower(address)] = verify
+}
+
+// AddMultisig registers an ERC-1271 multisig contract at the provided address that
+// accepts concatenated 65 byte secp256k1 signatures of at least threshold distinct owners.
+func (m *Mock) AddMultisig(address string, threshold int, owners ...string) {
+	isOwner := make(map[string]bool)
+	for _, owner := range owners {
+		isOwner[strings.ToLower(owner)] = true
+	}
+
+	m.AddContract(address, func(hash [32]byte, sig []byte) bool {
+		if len(sig) == 0 || len(sig)%65 != 0 {
+			return false
+		}
+
+		signed := make(map[string]bool)
+		for i := 0; i < len(sig); i += 65 {
+			pubkey, err := k1util.Recover(hash[:], sig[i:i+65])
+			if err != nil {
+				return false
+			}
+
+			signer := strings.ToLower(eth2util.PublicKeyToAddress(pubkey))
+			if !isOwner[signer] {
+				return false
+			}
+			signed[signer] = true
+		}
+
+		return len(signed) >= threshold
+	})
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (m *Mock) handle(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	result, rpcErr := m.dispatch(req)
+
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != "" {
+		resp["error"] = map[string]any{"code": -32000, "message": rpcErr}
+	} else {
+		resp["result"] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// dispatch returns the result or error message of the JSON-RPC request.
+func (m *Mock) dispatch(req rpcRequest) (string, string) {
+	if req.Method == "eth_chainId" {
+		return "0x" + strconv.FormatUint(m.chainID, 16), ""
+	} else if req.Method != "eth_call" {
+		return "", "method not supported"
+	}
+
+	if len(req.Params) == 0 {
+		return "", "missing params"
+	}
+
+	var call struct {
+		To   string `json:"to"`
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(req.Params[0], &call); err != nil {
+		return "", "invalid call"
+	}
+
+	m.mu.Lock()
+	verify, ok := m.contracts[strings.ToLower(call.To)]
+	m.mu.Unlock()
+
+	if !ok {
+		// Calls to externally owned accounts succeed with empty results.
+		return "0x", ""
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(call.Data, "0x"))
+	if err != nil {
+		return "", "invalid call data"
+	}
+
+	hash, sig, err := eth1wrap.UnpackIsValidSignature(data)
+	if err != nil {
+		return "", "execution reverted"
+	}
+
+	return "0x" + hex.EncodeToString(eth1wrap.IsValidSignatureResult(verify(hash, sig))), ""
+}
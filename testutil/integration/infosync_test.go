@@ -103,9 +103,10 @@ func (a *priorityAsserter) Callback(t *testing.T, i int) func(ctx context.Contex
 
 	return func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
 		expect := map[string]string{
-			"version":  fmt.Sprint(version.Supported()),
-			"protocol": fmt.Sprint(app.Protocols()),
-			"proposal": fmt.Sprint(app.ProposalTypes(false, false)),
+			"version":        fmt.Sprint(version.Supported()),
+			"protocol":       fmt.Sprint(app.Protocols()),
+			"proposal":       fmt.Sprint(app.ProposalTypes(false, false)),
+			"builder_config": "[builder_api=false,boost_factor=default,min_bid_gwei=0]",
 		}
 
 		if !assert.Len(t, results, len(expect)) {
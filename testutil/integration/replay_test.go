@@ -0,0 +1,185 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/recorder"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core/validatorapi"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+//go:generate go test . -integration -v -run=TestRecordReplay
+
+// TestRecordReplay records a short simnet run of a cluster with mock validator clients against a beacon mock
+// served over http, and asserts that replaying the recording of a node reproduces identical submissions.
+func TestRecordReplay(t *testing.T) {
+	skipIfDisabled(t)
+
+	const (
+		n            = 3
+		attestations = 2
+	)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, 1, n, n, seed, random, func(definition *cluster.Definition) {
+		definition.ForkVersion = []byte{0x01, 0x01, 0x70, 0x00}
+	})
+
+	pubkey, err := lock.Validators[0].PublicKey()
+	require.NoError(t, err)
+
+	bmock, err := beaconmock.New(
+		beaconmock.WithSlotDuration(time.Second),
+		beaconmock.WithSlotsPerEpoch(2),
+		beaconmock.WithDeterministicAttesterDuties(0),
+		beaconmock.WithNoProposerDuties(),
+		beaconmock.WithNoSyncCommitteeDuties(),
+		beaconmock.WithValidatorSet(beaconmock.ValidatorSet{
+			0: {
+				Balance: eth2p0.Gwei(31300000000),
+				Index:   0,
+				Status:  eth2v1.ValidatorStateActiveOngoing,
+				Validator: &eth2p0.Validator{
+					WithdrawalCredentials: []byte("12345678901234567890123456789012"),
+					EffectiveBalance:      eth2p0.Gwei(31300000000),
+					PublicKey:             eth2p0.BLSPubKey(pubkey),
+					ExitEpoch:             18446744073709551615,
+					WithdrawableEpoch:     18446744073709551615,
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	defer bmock.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	router, err := validatorapi.NewRouter(ctx, bmock, bmock, false)
+	require.NoError(t, err)
+
+	// Stop recording once all nodes submitted a few attestations.
+	var (
+		mu        sync.Mutex
+		submitted int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r)
+
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v1/beacon/pool/attestations" {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		submitted++
+		if submitted == attestations*n {
+			cancel()
+		}
+	}))
+	defer srv.Close()
+
+	// Nodes connect to each other directly.
+	var (
+		peerIDs []peer.ID
+		addrs   []*net.TCPAddr
+	)
+	for i := range n {
+		peerID, err := p2p.PeerIDFromKey(p2pKeys[i].PubKey())
+		require.NoError(t, err)
+
+		peerIDs = append(peerIDs, peerID)
+		addrs = append(addrs, testutil.AvailableAddr(t))
+	}
+
+	newConfig := func(node int) app.Config {
+		return app.Config{
+			Log:                     log.DefaultConfig(),
+			Feature:                 featureset.DefaultConfig(),
+			BeaconNodeAddrs:         []string{srv.URL},
+			BeaconNodeTimeout:       time.Second * 2,
+			BeaconNodeSubmitTimeout: time.Second * 2,
+			MonitoringAddr:          testutil.AvailableAddr(t).String(), // Random monitoring address
+			ValidatorAPIAddr:        testutil.AvailableAddr(t).String(), // Random validatorapi address
+			TestConfig: app.TestConfig{
+				Lock:       &lock,
+				P2PKey:     p2pKeys[node],
+				SimnetKeys: []tbls.PrivateKey{secretShares[0][node]},
+			},
+			P2P: p2p.Config{
+				TCPAddrs: []string{addrs[node].String()},
+			},
+		}
+	}
+
+	dir := t.TempDir()
+
+	var eg errgroup.Group
+	for i := range n {
+		conf := newConfig(i)
+		conf.RecordFile = filepath.Join(dir, fmt.Sprintf("record%d", i))
+		conf.SimnetVMock = true
+		conf.TestConfig.TCPNodeCallback = func(tcpNode host.Host) {
+			for j, addr := range addrs {
+				maddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", addr.IP, addr.Port))
+				require.NoError(t, err)
+				tcpNode.Peerstore().AddAddr(peerIDs[j], maddr, peerstore.PermanentAddrTTL)
+			}
+		}
+
+		eg.Go(func() error {
+			defer cancel()
+			return app.Run(ctx, conf)
+		})
+	}
+
+	err = eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+	require.GreaterOrEqual(t, submitted, attestations*n, "recording timed out")
+
+	recordFile := filepath.Join(dir, "record0")
+	records, err := recorder.ReadFile(recordFile)
+	require.NoError(t, err)
+
+	var recorded int
+	for _, rec := range records {
+		if rec.Source == recorder.SourceBN && rec.Method == http.MethodPost && rec.Target == "/eth/v1/beacon/pool/attestations" {
+			recorded++
+		}
+	}
+	require.Positive(t, recorded)
+
+	// Replay runs on a fake clock, so it completes faster than the recording took.
+	err = app.Replay(context.Background(), newConfig(0), recordFile)
+	testutil.RequireNoError(t, err)
+}
@@ -0,0 +1,248 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapimock
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	fullDepositBaseTmpl = "/exp/deposit"
+	fullDepositEndTmp   = "/" + lockHashPath + "/" + shareIndexPath + "/" + valPubkeyPath
+
+	partialDepositTmpl = "/exp/partial_deposits/" + lockHashPath
+)
+
+// depositBlob represents a partially signed deposit data with its share index.
+type depositBlob struct {
+	eth2p0.DepositData
+	shareIdx uint64
+}
+
+func (ts *testServer) HandlePartialDeposit(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	vars := mux.Vars(request)
+
+	var data obolapi.PartialDepositRequest
+
+	if err := json.NewDecoder(request.Body).Decode(&data); err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	lockHash := vars[cleanTmpl(lockHashPath)]
+	if lockHash == "" {
+		writeErr(writer, http.StatusBadRequest, "invalid lock hash")
+		return
+	}
+
+	lock, ok := ts.lockFiles[lockHash]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	}
+
+	// check that data has been signed with ShareIdx-th identity key
+	if data.ShareIdx == 0 || data.ShareIdx > uint64(len(lock.Operators)) {
+		writeErr(writer, http.StatusBadRequest, "invalid share index")
+		return
+	}
+
+	depositsRoot, err := data.HashTreeRoot()
+	if err != nil {
+		writeErr(writer, http.StatusInternalServerError, "cannot calculate hash tree root for provided partial deposits")
+		return
+	}
+
+	if err := verifyIdentitySignature(lock.Operators[data.ShareIdx-1], data.Signature, depositsRoot[:]); err != nil {
+		writeErr(writer, http.StatusBadRequest, "cannot verify signature: "+err.Error())
+		return
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		writeErr(writer, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for _, dd := range data.PartialDepositData {
+		valHex := dd.PublicKey.String()
+
+		var (
+			partialPubkey []byte
+			compounding   bool
+			withdrawAddr  string
+		)
+		for i, lockVal := range lock.Validators {
+			if strings.EqualFold(valHex, lockVal.PublicKeyHex()) {
+				partialPubkey = lockVal.PubShares[data.ShareIdx-1]
+				withdrawAddr = lock.ValidatorAddresses[i].WithdrawalAddress
+				compounding = lock.ValidatorAddresses[i].Compounding
+
+				break
+			}
+		}
+
+		if partialPubkey == nil {
+			writeErr(writer, http.StatusBadRequest, fmt.Sprintf("could not find validator %s in lock file", valHex))
+			return
+		}
+
+		msg, err := deposit.NewMessage(dd.PublicKey, withdrawAddr, dd.Amount, compounding)
+		if err != nil {
+			writeErr(writer, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !bytes.Equal(msg.WithdrawalCredentials, dd.WithdrawalCredentials) {
+			writeErr(writer, http.StatusBadRequest, "withdrawal credentials don't match cluster lock")
+			return
+		}
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+		if err != nil {
+			writeErr(writer, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if err := tbls.Verify(tbls.PublicKey(partialPubkey), sigRoot[:], tbls.Signature(dd.Signature)); err != nil {
+			writeErr(writer, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// replace any previous partial deposit of the same share and amount
+		var stored []depositBlob
+		for _, existing := range ts.partialDeposits[valHex] {
+			if existing.shareIdx == data.ShareIdx && existing.Amount == dd.Amount {
+				continue
+			}
+			stored = append(stored, existing)
+		}
+
+		ts.partialDeposits[valHex] = append(stored, depositBlob{
+			DepositData: dd,
+			shareIdx:    data.ShareIdx,
+		})
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+}
+
+func (ts *testServer) HandleFullDeposit(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	authToken, ok := request.Context().Value(tokenContextKey).([]byte)
+	if !ok {
+		log.Error(request.Context(), "received context without token, that's impossible!", nil)
+		return
+	}
+
+	vars := mux.Vars(request)
+
+	valPubkey := vars[cleanTmpl(valPubkeyPath)]
+	lockHash := vars[cleanTmpl(lockHashPath)]
+	shareIndex, err := strconv.ParseUint(vars[cleanTmpl(shareIndexPath)], 10, 64)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "malformed share index")
+		return
+	}
+
+	valPubkeyBytes, err := from0x(valPubkey, 48)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid public key")
+		return
+	}
+
+	lockHashBytes, err := from0x(lockHash, 32)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid lock hash")
+		return
+	}
+
+	lock, ok := ts.lockFiles[lockHash]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	}
+
+	if shareIndex == 0 || shareIndex > uint64(len(lock.Operators)) {
+		writeErr(writer, http.StatusBadRequest, "invalid share index")
+		return
+	}
+
+	depositAuthData := obolapi.FullDepositAuthBlob{
+		LockHash:        lockHashBytes,
+		ValidatorPubkey: valPubkeyBytes,
+		ShareIndex:      shareIndex,
+	}
+
+	depositAuthDataRoot, err := depositAuthData.HashTreeRoot()
+	if err != nil {
+		writeErr(writer, http.StatusInternalServerError, "cannot calculate deposit auth data root")
+		return
+	}
+
+	if err := verifyIdentitySignature(lock.Operators[shareIndex-1], authToken, depositAuthDataRoot[:]); err != nil {
+		writeErr(writer, http.StatusBadRequest, "cannot verify signature: "+err.Error())
+		return
+	}
+
+	// group partial deposits by amount, only amounts with threshold partial signatures are returned
+	byAmount := make(map[eth2p0.Gwei][]depositBlob)
+	for _, pDeposit := range ts.partialDeposits[eth2p0.BLSPubKey(valPubkeyBytes).String()] {
+		byAmount[pDeposit.Amount] = append(byAmount[pDeposit.Amount], pDeposit)
+	}
+
+	amounts := slices.Collect(maps.Keys(byAmount))
+	slices.Sort(amounts)
+
+	ret := obolapi.FullDepositResponse{PublicKey: valPubkey}
+	for _, amount := range amounts {
+		partials := byAmount[amount]
+		if len(partials) < lock.Threshold {
+			continue
+		}
+
+		sigs := make([]string, len(lock.Operators))
+		for _, partial := range partials {
+			sigs[partial.shareIdx-1] = "0x" + hex.EncodeToString(partial.Signature[:])
+			ret.WithdrawalCredentials = "0x" + hex.EncodeToString(partial.WithdrawalCredentials)
+		}
+
+		ret.Amounts = append(ret.Amounts, obolapi.FullDepositAmount{
+			Amount:     strconv.FormatUint(uint64(amount), 10),
+			Signatures: sigs,
+		})
+	}
+
+	if len(ret.Amounts) == 0 {
+		writeErr(writer, http.StatusNotFound, "not enough partial deposits stored")
+		return
+	}
+
+	if err := json.NewEncoder(writer).Encode(ret); err != nil {
+		writeErr(writer, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal deposit data").Error())
+		return
+	}
+}
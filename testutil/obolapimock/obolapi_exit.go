@@ -73,6 +73,9 @@ type testServer struct {
 	// store the partial exits by the validator pubkey
 	partialExits map[string][]exitBlob
 
+	// store the partial deposits by the validator pubkey
+	partialDeposits map[string][]depositBlob
+
 	// store the lock file by its lock hash
 	lockFiles map[string]cluster.Lock
 
@@ -318,11 +321,12 @@ func cleanTmpl(tmpl string) string {
 // It returns a http.Handler to be served over HTTP, and a function to add cluster lock files to its database.
 func MockServer(dropOnePsig bool, beacon eth2wrap.Client) (http.Handler, func(lock cluster.Lock)) {
 	ts := testServer{
-		lock:         sync.Mutex{},
-		partialExits: map[string][]exitBlob{},
-		lockFiles:    map[string]cluster.Lock{},
-		dropOnePsig:  dropOnePsig,
-		beacon:       beacon,
+		lock:            sync.Mutex{},
+		partialExits:    map[string][]exitBlob{},
+		partialDeposits: map[string][]depositBlob{},
+		lockFiles:       map[string]cluster.Lock{},
+		dropOnePsig:     dropOnePsig,
+		beacon:          beacon,
 	}
 
 	router := mux.NewRouter()
@@ -333,6 +337,12 @@ func MockServer(dropOnePsig bool, beacon eth2wrap.Client) (http.Handler, func(lo
 
 	router.HandleFunc(partialExitTmpl, ts.HandlePartialExit).Methods(http.MethodPost)
 
+	fullDeposit := router.PathPrefix(fullDepositBaseTmpl).Subrouter()
+	fullDeposit.Use(authMiddleware)
+	fullDeposit.HandleFunc(fullDepositEndTmp, ts.HandleFullDeposit).Methods(http.MethodGet)
+
+	router.HandleFunc(partialDepositTmpl, ts.HandlePartialDeposit).Methods(http.MethodPost)
+
 	return router, ts.addLockFiles
 }
 